@@ -12,14 +12,104 @@
 
 require_relative "tastytrade/version"
 require_relative "tastytrade/client"
+require_relative "tastytrade/contract_multiplier"
 require_relative "tastytrade/models"
 require_relative "tastytrade/session"
+require_relative "tastytrade/account_stream"
+require_relative "tastytrade/dxlink_stream"
 require_relative "tastytrade/order"
+require_relative "tastytrade/option_symbol"
+require_relative "tastytrade/complex_order_request"
+require_relative "tastytrade/order_defaults"
+require_relative "tastytrade/market_hours"
+require_relative "tastytrade/tick_size"
 require_relative "tastytrade/order_validator"
+require_relative "tastytrade/scheduled_order"
+require_relative "tastytrade/position_simulator"
+require_relative "tastytrade/csv_export"
+require_relative "tastytrade/fee_schedule"
 require_relative "tastytrade/instruments/equity"
+require_relative "tastytrade/instruments/cryptocurrency"
+require_relative "tastytrade/instruments/future_option"
+require_relative "tastytrade/instruments/future_option_chain"
 
 module Tastytrade
-  class Error < StandardError; end
+  # Base class for all Tastytrade errors.
+  #
+  # Errors raised for API responses carry the request that failed so they are
+  # self-describing in logs, e.g. "Session expired or invalid: token expired
+  # (GET /accounts/5WV12345/orders returned 403)". When the response body has
+  # an error code, predicates such as {#insufficient_buying_power?} match it
+  # and the codes of any nested errors, e.g. preflight check failures.
+  class Error < StandardError
+    # Error codes grouped by the predicate that matches them
+    INSUFFICIENT_BUYING_POWER_CODES = %w[insufficient_buying_power margin_check_failed insufficient_funds].freeze
+    MARKET_CLOSED_CODES = %w[market_closed trading_closed outside_trading_hours].freeze
+    INVALID_SYMBOL_CODES = %w[invalid_symbol unknown_symbol symbol_not_found].freeze
+    ACCOUNT_RESTRICTED_CODES = %w[account_restricted account_closing_only closing_only].freeze
+
+    # @return [String, nil] HTTP method of the failed request ("GET", "POST", ...)
+    attr_reader :http_method
+
+    # @return [String, nil] Path of the failed request
+    attr_reader :endpoint
+
+    # @return [Integer, nil] HTTP status code of the response
+    attr_reader :status
+
+    # @return [String, nil] Request ID from the response headers, if present
+    attr_reader :request_id
+
+    # @return [String, nil] API error code, e.g. "preflight_check_failure"
+    attr_reader :code
+
+    # @return [Array<Hash>] Nested errors from the response, each with "code" and "message"
+    attr_reader :details
+
+    # @param message [String, nil] Error message
+    # @param http_method [String, nil] HTTP method of the failed request
+    # @param endpoint [String, nil] Path of the failed request
+    # @param status [Integer, nil] HTTP status code
+    # @param request_id [String, nil] Request ID from the response headers
+    # @param code [String, nil] API error code
+    # @param details [Array<Hash>, nil] Nested errors from the response
+    def initialize(message = nil, http_method: nil, endpoint: nil, status: nil, request_id: nil, code: nil,
+                   details: nil)
+      @http_method = http_method
+      @endpoint = endpoint
+      @status = status
+      @request_id = request_id
+      @code = code
+      @details = Array(details)
+      super(message)
+    end
+
+    # @return [Array<String>] The error code followed by the codes of nested errors
+    def codes
+      [@code, *@details.grep(Hash).map { |detail| detail["code"] }].compact
+    end
+
+    def insufficient_buying_power?
+      codes.intersect?(INSUFFICIENT_BUYING_POWER_CODES)
+    end
+
+    def market_closed?
+      codes.intersect?(MARKET_CLOSED_CODES)
+    end
+
+    def invalid_symbol?
+      codes.intersect?(INVALID_SYMBOL_CODES)
+    end
+
+    def account_restricted?
+      codes.intersect?(ACCOUNT_RESTRICTED_CODES)
+    end
+
+    # @return [Boolean] true if the error carries request details
+    def request_context?
+      !@http_method.nil? || !@endpoint.nil?
+    end
+  end
 
   # Authentication errors
   class AuthenticationError < Error; end
@@ -28,6 +118,16 @@ module Tastytrade
   class InvalidCredentialsError < AuthenticationError; end
   class NetworkTimeoutError < Error; end
 
+  # Raised when a streaming websocket connection cannot be opened or is lost
+  class StreamError < Error; end
+
+  # Raised when the DXLink streamer rejects the quote token, e.g. once it has expired
+  class StreamUnauthorizedError < StreamError; end
+
+  # Raised in strict time parsing mode when a timestamp cannot be parsed,
+  # see {StrictTimeHash}
+  class TimeParseError < Error; end
+
   # Order errors
   class OrderError < Error; end
   class InvalidOrderError < OrderError; end
@@ -38,6 +138,24 @@ module Tastytrade
   class OrderNotEditableError < OrderError; end
   class InsufficientQuantityError < OrderError; end
 
+  # Raised by {Models::Account#place_order_checked} when the dry run returns
+  # warnings and they were not allowed. The order is not submitted.
+  class OrderWarningsError < OrderError
+    # @return [Array<Hash>] Warnings from the dry run, each with "code" and "message"
+    attr_reader :warnings
+
+    # @return [Models::OrderResponse] The dry-run response
+    attr_reader :dry_run_response
+
+    # @param dry_run_response [Models::OrderResponse] Dry-run response with warnings
+    def initialize(dry_run_response)
+      @dry_run_response = dry_run_response
+      @warnings = dry_run_response.warnings
+      messages = @warnings.map { |warning| warning.is_a?(Hash) ? warning["message"] || warning["code"] : warning }
+      super("Dry run returned warnings: #{messages.join("; ")}")
+    end
+  end
+
   # Order validation errors
 
   # Base class for order validation errors. Contains an array of specific
@@ -71,4 +189,55 @@ module Tastytrade
   # API URLs
   API_URL = "https://api.tastyworks.com"
   CERT_URL = "https://api.cert.tastyworks.com"
+
+  # Account streamer URLs
+  STREAMER_URL = "wss://streamer.tastyworks.com"
+  CERT_STREAMER_URL = "wss://streamer.cert.tastyworks.com"
+
+  # JSON object read by a client in strict time parsing mode.
+  #
+  # Models built from one parse their timestamps strictly, raising
+  # {TimeParseError} for a value that cannot be parsed instead of reading it
+  # as nil, so bad data surfaces where it arrives.
+  #
+  # @example
+  #   session = Tastytrade::Session.new(username: "user", password: "pass", strict_time_parsing: true)
+  class StrictTimeHash < Hash; end
+
+  class << self
+    # Normalizes a symbol for a lookup request
+    #
+    # Surrounding whitespace is removed and letters are upper-cased. Spaces
+    # inside the symbol, such as the padding of an OCC option symbol, are kept.
+    # Sessions created with normalize_symbols: false send symbols as given.
+    #
+    # @param symbol [String, nil] Symbol as given, e.g. " aapl" or "/esz4"
+    # @param session [Tastytrade::Session, nil] Session the lookup is made with
+    # @return [String, nil] Normalized symbol, or the symbol unchanged when the session turns normalization off
+    def normalize_symbol(symbol, session = nil)
+      return symbol if symbol.nil?
+      return symbol if session.respond_to?(:normalize_symbols?) && !session.normalize_symbols?
+
+      symbol.to_s.strip.upcase
+    end
+
+    # Parses a timestamp from an API response
+    #
+    # Strict mode is set per session with the strict_time_parsing: option,
+    # see {StrictTimeHash}.
+    #
+    # @param value [String, nil] Timestamp, e.g. "2024-03-15T14:30:00.000+00:00"
+    # @param strict [Boolean] Raise for an unparseable timestamp instead of returning nil
+    # @return [Time, nil] Parsed time, or nil for a blank value or, unless strict, an unparseable one
+    # @raise [TimeParseError] in strict mode if the value cannot be parsed
+    def parse_time(value, strict: false)
+      return nil if value.nil? || value.to_s.empty?
+
+      Time.parse(value.to_s)
+    rescue ArgumentError
+      raise TimeParseError, "Invalid time: #{value.inspect}" if strict
+
+      nil
+    end
+  end
 end
@@ -0,0 +1,214 @@
+# frozen_string_literal: true
+
+require "json"
+require_relative "websocket_connection"
+
+module Tastytrade
+  # Order and position updates pushed over the account streamer websocket
+  #
+  # Messages are JSON text frames. The client sends actions carrying the
+  # session token:
+  #
+  #   {"action": "connect", "value": ["5WT0001"], "auth-token": "...", "request-id": 1}
+  #   {"action": "heartbeat", "auth-token": "...", "request-id": 2}
+  #
+  # The server acknowledges each action with its status, e.g.
+  # {"status": "ok", "action": "connect", "request-id": 1}, and pushes
+  # notifications as {"type": "Order", "data": {...}, "timestamp": ...}.
+  # Order notifications are delivered as {Models::LiveOrder} and
+  # CurrentPosition notifications as {Models::CurrentPosition}; other types
+  # are ignored.
+  #
+  # A heartbeat is sent every heartbeat_interval seconds to keep the
+  # connection alive. If the connection drops, the stream reconnects with
+  # exponential backoff and subscribes its accounts again.
+  #
+  # @example React to fills without polling
+  #   stream = session.account_stream.connect
+  #   updates = stream.subscribe("5WT0001")
+  #   while (order = updates.orders.pop)
+  #     puts "#{order.id} #{order.status}"
+  #   end
+  class AccountStream
+    HEARTBEAT_INTERVAL = 20
+    RECONNECT_DELAY = 1
+    MAX_RECONNECT_DELAY = 30
+    MAX_RECONNECT_ATTEMPTS = 10
+
+    # Queues of updates for one account; both are closed when the stream closes,
+    # so pop returns nil
+    Subscription = Struct.new(:account_number, :orders, :positions, keyword_init: true)
+
+    attr_reader :url, :last_error
+
+    # @param session [Tastytrade::Session] Authenticated session
+    # @param url [String, nil] Streamer URL (default: production or cert, matching the session)
+    # @param heartbeat_interval [Numeric, nil] Seconds between heartbeats, nil to disable
+    # @param reconnect_delay [Numeric] Seconds before the first reconnect attempt, doubled on each attempt
+    # @param max_reconnect_attempts [Integer] Attempts before giving up, 0 to disable reconnection
+    # @param connector [#call, nil] Called with the URL to build a connection; defaults to
+    #   {WebSocketConnection}
+    # @param sleeper [#call] Sleeps for the given number of seconds
+    # @param logger [Logger, nil] Debug logger for stream activity
+    def initialize(session, url: nil, heartbeat_interval: HEARTBEAT_INTERVAL, reconnect_delay: RECONNECT_DELAY,
+                   max_reconnect_attempts: MAX_RECONNECT_ATTEMPTS, connector: nil, sleeper: nil, logger: nil)
+      @session = session
+      @url = url || (session.is_test ? CERT_STREAMER_URL : STREAMER_URL)
+      @heartbeat_interval = heartbeat_interval
+      @reconnect_delay = reconnect_delay
+      @max_reconnect_attempts = max_reconnect_attempts
+      @connector = connector || ->(stream_url) { WebSocketConnection.new(stream_url) }
+      @sleeper = sleeper || ->(seconds) { sleep(seconds) }
+      @logger = logger
+      @subscriptions = {}
+      @request_id = 0
+      @mutex = Mutex.new
+      @closed = false
+    end
+
+    # Opens the websocket and starts the heartbeat
+    #
+    # @return [self]
+    # @raise [StreamError] if the connection cannot be opened
+    def connect
+      open_connection
+      start_heartbeat
+      self
+    end
+
+    # Subscribes to order and position updates for an account
+    #
+    # Subscribing to an account twice returns the existing subscription.
+    #
+    # @param account_number [String] Account number
+    # @return [Subscription] Queues receiving the account's updates
+    # @raise [StreamError] if the stream is not connected
+    def subscribe(account_number)
+      raise StreamError, "Account stream is not connected" unless connected?
+
+      subscription = @mutex.synchronize do
+        @subscriptions[account_number] ||= Subscription.new(account_number: account_number, orders: Queue.new,
+                                                            positions: Queue.new)
+      end
+      send_action("connect", value: @subscriptions.keys)
+      subscription
+    end
+
+    # @return [Boolean] true while the websocket is open
+    def connected?
+      !@connection.nil? && @connection.open?
+    end
+
+    # Sends a heartbeat to keep the connection alive
+    #
+    # @return [Boolean] true if the heartbeat was sent
+    def heartbeat
+      send_action("heartbeat")
+    end
+
+    # Closes the websocket and every subscription queue
+    def close
+      @closed = true
+      @heartbeat_thread&.kill
+      @connection&.close
+      close_subscriptions
+    end
+
+    def closed?
+      @closed
+    end
+
+    private
+
+    def open_connection
+      @connection = @connector.call(@url)
+      @connection.connect(on_message: method(:handle_message), on_close: method(:handle_close))
+    end
+
+    def start_heartbeat
+      return unless @heartbeat_interval
+
+      @heartbeat_thread = Thread.new do
+        loop do
+          @sleeper.call(@heartbeat_interval)
+          heartbeat if connected?
+        end
+      end
+    end
+
+    def send_action(action, value: nil)
+      message = { "action" => action, "auth-token" => auth_token, "request-id" => next_request_id }
+      message["value"] = value if value
+      @connection.send_text(message.to_json) ? true : false
+    end
+
+    # Refreshes the token first when it is about to expire
+    def auth_token
+      @session.ensure_valid_token
+      @session.session_token
+    end
+
+    def next_request_id
+      @mutex.synchronize { @request_id += 1 }
+    end
+
+    def handle_message(text)
+      message = JSON.parse(text)
+      return handle_status(message) if message.key?("status")
+
+      subscription = @subscriptions[message.dig("data", "account-number")]
+      return unless subscription
+
+      case message["type"]
+      when "Order"
+        subscription.orders << Models::LiveOrder.new(message["data"])
+      when "CurrentPosition"
+        subscription.positions << Models::CurrentPosition.new(message["data"])
+      end
+    rescue JSON::ParserError => e
+      @logger&.debug("Ignoring malformed account stream message: #{e.message}")
+    end
+
+    def handle_status(message)
+      return if message["status"] == "ok"
+
+      @last_error = StreamError.new("Account stream #{message["action"]} failed: #{message["message"]}")
+      @logger&.debug(@last_error.message)
+    end
+
+    def handle_close(reason)
+      return if @closed
+
+      @logger&.debug("Account stream disconnected: #{reason}")
+      reconnect
+    end
+
+    def reconnect
+      delay = @reconnect_delay
+      @max_reconnect_attempts.times do |attempt|
+        @sleeper.call(delay)
+        begin
+          open_connection
+          send_action("connect", value: @subscriptions.keys) unless @subscriptions.empty?
+          return
+        rescue StreamError => e
+          @last_error = e
+          @logger&.debug("Account stream reconnect #{attempt + 1} failed: #{e.message}")
+          delay = [delay * 2, MAX_RECONNECT_DELAY].min
+        end
+      end
+
+      @last_error ||= StreamError.new("Account stream disconnected")
+      close
+    end
+
+    def close_subscriptions
+      @mutex.synchronize do
+        @subscriptions.each_value do |subscription|
+          subscription.orders.close
+          subscription.positions.close
+        end
+      end
+    end
+  end
+end
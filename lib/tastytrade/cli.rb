@@ -34,6 +34,7 @@ module Tastytrade
     - Environment variables: TASTYTRADE_USERNAME, TASTYTRADE_PASSWORD (or TT_USERNAME, TT_PASSWORD)
     - Command line option: --username (password will be prompted)
     - Interactive prompts (default)
+    - A remember token saved by an earlier login with --remember (--remember-token)
 
     Optional environment variables:
     - TASTYTRADE_ENVIRONMENT=sandbox (or TT_ENVIRONMENT) for test environment
@@ -43,12 +44,18 @@ module Tastytrade
       $ tastytrade login
       $ tastytrade login --username user@example.com
       $ tastytrade login --no-interactive  # Skip interactive mode
+      $ tastytrade login --remember        # Save a remember token
+      $ tastytrade login --remember-token  # Log in again with it, no password
       $ TASTYTRADE_USERNAME=user@example.com TASTYTRADE_PASSWORD=pass tastytrade login --no-interactive
     LONGDESC
     option :username, aliases: "-u", desc: "Username"
     option :remember, aliases: "-r", type: :boolean, default: false, desc: "Remember credentials"
+    option :remember_token, type: :boolean, default: false,
+                            desc: "Log in with the remember token saved by --remember instead of a password"
     option :no_interactive, type: :boolean, default: false, desc: "Skip interactive mode after login"
     def login
+      return login_with_remember_token if options[:remember_token]
+
       # Try environment variables first
       if (session = Session.from_environment(is_test: options[:test]))
         environment = session.instance_variable_get(:@is_test) ? "sandbox" : "production"
@@ -120,6 +127,31 @@ module Tastytrade
       end
     end
 
+    def login_with_remember_token
+      environment = options[:test] ? "sandbox" : "production"
+      username = options[:username] || config.get("current_username") || prompt.ask("Username:")
+      remember_token = SessionManager.new(username: username, environment: environment).remember_token
+
+      unless remember_token
+        error "No saved remember token for #{username} (#{environment})"
+        info "Login with --remember to save one"
+        exit 1
+        return
+      end
+
+      info "Logging in to #{environment} environment with saved remember token..."
+      session = Session.new(username: username, remember_token: remember_token, remember_me: true,
+                            is_test: options[:test])
+      session.login
+      success "Successfully logged in as #{session.user.email}"
+
+      # Logging in rotates the remember token, so save the new one
+      save_user_session(session, { username: session.user.email, remember: true }, environment)
+
+      @current_session = session
+      interactive_mode unless options[:no_interactive]
+    end
+
     def login_credentials
       {
         username: options[:username] || prompt.ask("Username:"),
@@ -901,6 +933,8 @@ module Tastytrade
           end
         end
 
+        puts "  Fees: #{format_currency(response.fee_calculation.total)}" if response.fee_calculation
+
         if response.warnings.any?
           puts ""
           warning "Warnings:"
@@ -1075,6 +1109,7 @@ module Tastytrade
           ["Equity Buying Power", format_currency(balance.equity_buying_power)],
           ["Day Trading BP", format_currency(balance.day_trading_buying_power)],
           ["Available Trading Funds", format_currency(balance.available_trading_funds)],
+          ["Maintenance Requirement", format_currency(balance.maintenance_requirement)],
           ["BP Usage", "#{balance.buying_power_usage_percentage.to_s("F")}%"]
         ]
       )
@@ -1096,6 +1131,11 @@ module Tastytrade
         puts
         warning "High buying power usage: #{balance.buying_power_usage_percentage.to_s("F")}%"
       end
+
+      if balance.margin_call?
+        puts
+        warning "Account has an outstanding margin call"
+      end
     end
 
     def display_all_account_balances
@@ -1350,6 +1390,8 @@ module Tastytrade
             end
           end
 
+          puts "  Fees: #{format_currency(response.fee_calculation.total)}" if response.fee_calculation
+
           if response.warnings.any?
             warning "Warnings:"
             response.warnings.each { |w| puts "  - #{w}" }
@@ -1807,19 +1849,40 @@ module Tastytrade
         menu.choice "Market", "market"
         menu.choice "Limit", "limit"
         menu.choice "Stop", "stop" unless is_option  # Options typically don't support stop orders
+        menu.choice "Trailing Stop", "trailing_stop" unless is_option
       end
 
       price = nil
+      trailing_value = nil
+      trailing_type = nil
       if order_type == "limit"
         price_label = is_option ? "Limit price per contract:" : "Limit price:"
         price = prompt.ask(price_label, convert: :float)
       elsif order_type == "stop"
         price = prompt.ask("Stop price:", convert: :float)
+      elsif order_type == "trailing_stop"
+        trailing_type = prompt.select("Trail by:") do |menu|
+          menu.choice "Percentage of price", Tastytrade::TrailingValueType::PERCENTAGE
+          menu.choice "Dollar amount", Tastytrade::TrailingValueType::AMOUNT
+        end
+        trailing_value = prompt.ask("Trailing #{trailing_type}:", convert: :float) do |q|
+          q.required true
+          q.validate(->(input) { input.to_f.positive? }, "Must be greater than 0")
+        end
       end
 
       time_in_force = prompt.select("Time in force:") do |menu|
         menu.choice "Day (expires at close)", "day"
         menu.choice "GTC (good till cancelled)", "gtc"
+        menu.choice "GTD (good till date)", "gtd"
+      end
+
+      gtc_date = nil
+      if time_in_force == "gtd"
+        gtc_date = prompt.ask("Good till date (YYYY-MM-DD):") do |q|
+          q.required true
+          q.validate(/^\d{4}-\d{2}-\d{2}$/, "Must be in YYYY-MM-DD format")
+        end
       end
 
       account = @current_account || current_account || select_account_interactively
@@ -1832,8 +1895,13 @@ module Tastytrade
       puts "  Action: #{action}"
       puts "  #{is_option ? "Contracts" : "Quantity"}: #{quantity}"
       puts "  Order Type: #{order_type}"
-      puts "  Price: #{price ? format_currency(price) : "Market"}"
+      if trailing_value
+        puts "  Trailing: #{trailing_type == "percentage" ? "#{trailing_value}%" : format_currency(trailing_value)}"
+      else
+        puts "  Price: #{price ? format_currency(price) : "Market"}"
+      end
       puts "  Time in Force: #{time_in_force.upcase}"
+      puts "  Good Till: #{gtc_date}" if gtc_date
       puts "  Account: #{account.account_number}"
 
       if is_option && price
@@ -1856,7 +1924,10 @@ module Tastytrade
         quantity: quantity,
         type: order_type,
         price: price,
+        trailing_value: trailing_value,
+        trailing_type: trailing_type,
         time_in_force: time_in_force,
+        gtc_date: gtc_date,
         instrument_type: is_option ? "Option" : nil,  # Let CLI::Orders detect automatically if nil
         skip_confirmation: true
       }
@@ -953,16 +953,7 @@ module Tastytrade
       end
 
       def apply_chain_filters(nested_chain, options)
-        chain = nested_chain
-
-        # Filter by DTE
-        if options[:dte]
-          chain = chain.filter_by_dte(max_dte: options[:dte])
-        end
-
-        if options[:min_dte]
-          chain = chain.filter_by_dte(min_dte: options[:min_dte])
-        end
+        chain = nested_chain.filter(min_dte: options[:min_dte], max_dte: options[:dte])
 
         # Filter by expiration type
         case options[:type]
@@ -974,19 +965,11 @@ module Tastytrade
           chain = chain.quarterly_expirations
         end
 
-        # For display purposes, we'll filter the expirations and strikes in memory
-        # Create a filtered version for display
-        filtered_chain = chain.dup
-        filtered_exps = chain.expirations ? chain.expirations.dup : []
-
-        # Limit number of expirations
-        if options[:expirations] && filtered_exps.any?
-          filtered_exps = filtered_exps.first(options[:expirations])
-        end
+        chain = chain.filter(expirations_limit: options[:expirations])
 
         # Filter by moneyness
-        if options[:moneyness] != "all" && filtered_exps.any?
-          filtered_exps.each do |exp|
+        if options[:moneyness] != "all"
+          chain.expirations.each do |exp|
             next unless exp.strikes
 
             filtered_strikes = exp.strikes.select do |strike|
@@ -998,26 +981,8 @@ module Tastytrade
           end
         end
 
-        # Filter by strikes - just take the middle strikes
-        if options[:strikes] && filtered_exps.any?
-          filtered_exps.each do |exp|
-            next unless exp.strikes && exp.strikes.any?
-
-            # Take middle strikes
-            total_strikes = exp.strikes.length
-            if total_strikes > options[:strikes]
-              sorted_strikes = exp.strikes.sort_by(&:strike_price)
-              start_idx = (total_strikes - options[:strikes]) / 2
-              end_idx = start_idx + options[:strikes] - 1
-              filtered_strikes = sorted_strikes[start_idx..end_idx]
-              exp.instance_variable_set(:@strikes, filtered_strikes)
-            end
-          end
-        end
-
-        # Update the chain's expirations with our filtered version
-        filtered_chain.instance_variable_set(:@expirations, filtered_exps)
-        filtered_chain
+        # Keep the middle strikes of each expiration
+        chain.filter(strikes_per_expiration: options[:strikes])
       end
 
       def estimate_current_price(expirations)
@@ -1126,11 +1091,9 @@ module Tastytrade
 
       def extract_underlying_from_occ(occ_symbol)
         # OCC format: AAPL240315C00150000 or AAPL 240315C00150000 (with space)
-        # Extract underlying (everything before the date)
-        # Remove any spaces first
-        clean_symbol = occ_symbol.gsub(/\s+/, "")
-        match = clean_symbol.match(/^([A-Z]+)\d{6}[CP]\d+$/)
-        match ? match[1] : clean_symbol
+        Tastytrade::OptionSymbol.parse(occ_symbol).underlying
+      rescue ArgumentError
+        occ_symbol.gsub(/\s+/, "")
       end
 
       def find_option_by_symbol(nested_chain, symbol)
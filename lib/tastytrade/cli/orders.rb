@@ -217,9 +217,13 @@ module Tastytrade
       option :symbol, type: :string, required: true, desc: "Symbol to trade (e.g., AAPL, SPY, or OCC option symbol)"
       option :action, type: :string, required: true, desc: "Order action (buy_to_open, sell_to_close, etc.)"
       option :quantity, type: :numeric, required: true, desc: "Number of shares or contracts"
-      option :type, type: :string, default: "limit", desc: "Order type (market, limit)"
+      option :type, type: :string, default: "limit", desc: "Order type (market, limit, stop, trailing_stop)"
       option :price, type: :numeric, desc: "Limit price (required for limit orders)"
-      option :time_in_force, type: :string, default: "day", desc: "Order duration (day, gtc)"
+      option :stop_trigger, type: :numeric, desc: "Price that triggers a stop order (required for stop orders)"
+      option :trailing_value, type: :numeric, desc: "Distance a trailing stop follows the price"
+      option :trailing_type, type: :string, default: "percentage", desc: "Trailing value type (percentage, amount)"
+      option :time_in_force, type: :string, default: "day", desc: "Order duration (day, gtc, gtd)"
+      option :gtc_date, type: :string, desc: "Last day a GTD order works (YYYY-MM-DD)"
       option :instrument_type, type: :string, default: "equity", desc: "Instrument type (equity, option)"
       option :dry_run, type: :boolean, default: false, desc: "Perform validation only without placing the order"
       option :skip_confirmation, type: :boolean, default: false, desc: "Skip confirmation prompt"
@@ -260,8 +264,10 @@ module Tastytrade
                        Tastytrade::OrderType::LIMIT
                      when "stop", "stp"
                        Tastytrade::OrderType::STOP
+                     when "trailing_stop", "trail"
+                       Tastytrade::OrderType::TRAILING_STOP
                      else
-                       error "Invalid order type. Must be: market, limit, or stop"
+                       error "Invalid order type. Must be: market, limit, stop, or trailing_stop"
           exit 1
         end
 
@@ -271,17 +277,30 @@ module Tastytrade
           exit 1
         end
 
+        # Validate trigger for stop orders
+        if order_type == Tastytrade::OrderType::STOP && options[:stop_trigger].nil?
+          error "Stop trigger is required for stop orders"
+          exit 1
+        end
+
         # Map time in force
         time_in_force = case options[:time_in_force].downcase
                         when "day", "d"
                           Tastytrade::OrderTimeInForce::DAY
                         when "gtc", "g", "good_till_cancelled"
                           Tastytrade::OrderTimeInForce::GTC
+                        when "gtd", "good_till_date"
+                          Tastytrade::OrderTimeInForce::GTD
                         else
-                          error "Invalid time in force. Must be: day or gtc"
+                          error "Invalid time in force. Must be: day, gtc or gtd"
                           exit 1
         end
 
+        if time_in_force == Tastytrade::OrderTimeInForce::GTD && options[:gtc_date].nil?
+          error "GTC date is required for GTD orders (--gtc-date YYYY-MM-DD)"
+          exit 1
+        end
+
         # Determine instrument type
         instrument_type = if options[:instrument_type]
           case options[:instrument_type].downcase
@@ -314,12 +333,29 @@ module Tastytrade
           instrument_type: instrument_type
         )
 
-        order = Tastytrade::Order.new(
+        order_params = {
           type: order_type,
           time_in_force: time_in_force,
           legs: leg,
           price: options[:price] ? BigDecimal(options[:price].to_s) : nil
-        )
+        }
+        order_params[:gtc_date] = options[:gtc_date] if time_in_force == Tastytrade::OrderTimeInForce::GTD
+        if order_type == Tastytrade::OrderType::STOP
+          order_params.delete(:price)
+          order_params[:stop_trigger] = BigDecimal(options[:stop_trigger].to_s)
+        end
+        if order_type == Tastytrade::OrderType::TRAILING_STOP
+          order_params.delete(:price)
+          order_params[:trailing_value] = options[:trailing_value]
+          order_params[:trailing_value_type] = options[:trailing_type]&.downcase
+        end
+
+        begin
+          order = Tastytrade::Order.new(**order_params)
+        rescue ArgumentError => e
+          error e.message
+          exit 1
+        end
 
         # Display order summary
         puts ""
@@ -331,7 +367,14 @@ module Tastytrade
         puts "  Quantity: #{options[:quantity]} #{instrument_type == "Option" ? "contract(s)" : "share(s)"}"
         puts "  Type: #{order_type}"
         puts "  Time in Force: #{time_in_force}"
-        puts "  Price: #{options[:price] ? format_currency(options[:price]) : "Market"}"
+        puts "  Good Till: #{order.gtc_date}" if order.gtd?
+        if order.trailing_stop?
+          puts "  Trailing: #{format_trailing_value(order)}"
+        elsif order.stop?
+          puts "  Stop Trigger: #{format_currency(order.stop_trigger)}"
+        else
+          puts "  Price: #{options[:price] ? format_currency(options[:price]) : "Market"}"
+        end
         puts ""
 
         # Perform dry-run validation first
@@ -690,6 +733,14 @@ module Tastytrade
 
       private
 
+      def format_trailing_value(order)
+        if order.trailing_value_type == Tastytrade::TrailingValueType::PERCENTAGE
+          "#{order.trailing_value.to_s("F")}%"
+        else
+          format_currency(order.trailing_value)
+        end
+      end
+
       def create_vertical_spread(builder, expiration)
         unless options[:long_strike] && options[:short_strike]
           error "Vertical spread requires --long-strike and --short-strike"
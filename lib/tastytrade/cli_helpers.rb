@@ -237,19 +237,12 @@ module Tastytrade
         password: nil,
         is_test: environment == "sandbox"
       )
-
-      # Set the tokens and expiration directly
-      session.instance_variable_set(:@session_token, session_data[:session_token])
-      session.instance_variable_set(:@remember_token, session_data[:remember_token])
-      if session_data[:session_expiration]
-        session.instance_variable_set(:@session_expiration, Time.parse(session_data[:session_expiration]))
-      end
-
-      # Set user data if available
-      if session_data[:user_data]
-        user = Tastytrade::Models::User.new(session_data[:user_data])
-        session.instance_variable_set(:@user, user)
-      end
+      session.restore_state(
+        session_token: session_data[:session_token],
+        remember_token: session_data[:remember_token],
+        session_expiration: session_data[:session_expiration],
+        user: session_data[:user_data]
+      )
 
       # Check if session needs refresh
       if session.expired? && session.remember_token
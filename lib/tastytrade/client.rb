@@ -3,122 +3,348 @@
 require "faraday"
 require "faraday/retry"
 require "json"
+require "logger"
+require_relative "json_item_parser"
+require_relative "metrics"
+require_relative "rate_limiter"
+require_relative "version"
 
 module Tastytrade
   # HTTP client wrapper for Tastytrade API communication
   class Client
-    attr_reader :base_url
+    attr_reader :base_url, :logger, :rate_limiter, :timeout, :open_timeout, :user_agent, :metrics,
+                :pool_size, :idle_timeout, :strict_time_parsing
 
     DEFAULT_TIMEOUT = 30
+    DEFAULT_MAX_RETRIES = 2
+    DEFAULT_RETRY_INTERVAL = 0.5
+    DEFAULT_USER_AGENT = "tastytrade-ruby/#{VERSION}".freeze
 
-    def initialize(base_url:, timeout: DEFAULT_TIMEOUT)
+    # Connections kept open when pooling is enabled with only an idle timeout
+    DEFAULT_POOL_SIZE = 5
+
+    # Statuses retried with exponential backoff, honoring any Retry-After header
+    RETRY_STATUSES = [429, 500, 502, 503, 504].freeze
+
+    # Methods retried by default; POST only with retry_non_idempotent
+    IDEMPOTENT_METHODS = %i[get put delete].freeze
+
+    # Header and body keys whose values are never written to the log
+    REDACTED_KEYS = %w[Authorization password remember-token session-token].freeze
+
+    # Logger used when none is given: debug output on stderr when DEBUG_HTTP or
+    # DEBUG_SESSION is set, otherwise no logging
+    #
+    # @return [Logger, nil]
+    def self.default_logger
+      return nil unless ENV["DEBUG_HTTP"] || ENV["DEBUG_SESSION"]
+
+      Logger.new($stderr, level: Logger::DEBUG, progname: "tastytrade")
+    end
+
+    # Response headers checked, in order, for a request ID to attach to errors
+    REQUEST_ID_HEADERS = %w[X-Request-Id X-Amzn-Trace-Id].freeze
+
+    # @param base_url [String] API base URL, which may include a path prefix such as
+    #   "https://proxy.example.com/tastytrade"
+    # @param timeout [Numeric] Request timeout in seconds, e.g. short for health checks or
+    #   long for bulk transaction pulls
+    # @param open_timeout [Numeric, nil] Connection timeout in seconds, defaults to timeout
+    # @param max_retries [Integer] Retries for rate-limited (429) and 5xx responses, 0 to disable
+    # @param retry_interval [Numeric] Delay in seconds before the first retry, doubled on each retry
+    # @param retry_non_idempotent [Boolean] Also retry POST requests, which may place an order twice
+    # @param logger [Logger, nil] Logger for requests, responses and retries at debug level.
+    #   Tokens and passwords are redacted. Defaults to {.default_logger}
+    # @param rate_limit [Integer, RateLimiter, nil] Requests per minute, or a limiter to share
+    #   between clients. Requests and retries wait for a token; nil disables limiting
+    # @param user_agent [String] User-Agent header sent with every request
+    # @param adapter [Symbol, Array] Faraday adapter name, or name and arguments, e.g.
+    #   [:test, stubs] to answer requests in memory (see {Testing})
+    # @param metrics [#observe_request, nil] Hook told the latency and status of every request
+    #   (see {Metrics}). Defaults to a no-op
+    # @param pool_size [Integer, nil] Keep up to this many connections open and reuse them, e.g. for
+    #   a quote poller that would otherwise reconnect on every request. Requires the
+    #   faraday-net_http_persistent gem
+    # @param idle_timeout [Numeric, nil] Seconds a pooled connection may sit unused before it is
+    #   closed; setting it also enables pooling
+    # @param strict_time_parsing [Boolean] Parse response objects as {StrictTimeHash}, so models
+    #   built from them raise {TimeParseError} for malformed timestamps instead of reading nil
+    # @raise [ArgumentError] if pooling is combined with an adapter, or the pool size is not positive
+    def initialize(base_url:, timeout: DEFAULT_TIMEOUT, open_timeout: nil, max_retries: DEFAULT_MAX_RETRIES,
+                   retry_interval: DEFAULT_RETRY_INTERVAL, retry_non_idempotent: false, logger: nil,
+                   rate_limit: nil, user_agent: DEFAULT_USER_AGENT, adapter: nil, metrics: nil,
+                   pool_size: nil, idle_timeout: nil, strict_time_parsing: false)
       @base_url = base_url
       @timeout = timeout
+      @open_timeout = open_timeout || timeout
+      @max_retries = max_retries
+      @retry_interval = retry_interval
+      @retry_non_idempotent = retry_non_idempotent
+      @logger = logger || self.class.default_logger
+      @rate_limiter = rate_limit.is_a?(Integer) ? RateLimiter.new(rate_limit) : rate_limit
+      @user_agent = user_agent
+      @metrics = metrics || Metrics::NULL_HOOK
+      @strict_time_parsing = strict_time_parsing
+      if pool_size || idle_timeout
+        raise ArgumentError, "Connection pooling cannot be combined with a custom adapter" if adapter
+
+        configure_pool(pool_size || DEFAULT_POOL_SIZE, idle_timeout)
+      else
+        @adapter = adapter ? Array(adapter) : [Faraday.default_adapter]
+      end
+    end
+
+    # @return [Boolean] true if connections are pooled and reused
+    def pooled?
+      !@pool_size.nil?
     end
 
     def get(path, params = {}, headers = {})
-      response = connection.get(path, params, default_headers.merge(headers))
+      headers = default_headers.merge(headers)
+      log_request(__method__, path, headers, params)
+      response = perform(__method__, path) { connection.get(relative_path(path), params, headers) }
       handle_response(response)
-    rescue Faraday::ConnectionFailed => e
-      raise Tastytrade::NetworkTimeoutError, "Request timed out: #{e.message}"
+    end
+
+    # GET a list endpoint, yielding each element of data.items as it is read
+    #
+    # The body is parsed while it streams in rather than after it has been read
+    # in full, so large lists are never held in memory at once.
+    #
+    # @param path [String] API endpoint path
+    # @param params [Hash] Query parameters
+    # @param headers [Hash] Request headers
+    # @yieldparam item [Hash] Each item of the response
+    # @return [nil]
+    def get_items(path, params = {}, headers = {}, &block)
+      headers = default_headers.merge(headers)
+      log_request(:get, path, headers, params)
+      parser = JSONItemParser.new(object_class: json_object_class, &block)
+      streamed = false
+      error_body = +""
+      response = perform(:get, path) do
+        connection.get(relative_path(path), params, headers) do |request|
+          request.options.on_data = proc do |chunk, _received, env|
+            streamed = true
+            env && !(200..299).cover?(env.status) ? error_body << chunk : parser << chunk
+          end
+        end
+      end
+      log_response(response)
+
+      unless (200..299).cover?(response.status)
+        response.env.body = error_body if streamed
+        handle_error(response)
+      end
+      # Adapters without streaming support deliver the whole body at once
+      parser << response.body if !streamed && response.body
+      nil
     end
 
     def post(path, body = {}, headers = {})
-      response = connection.post(path, body.to_json, default_headers.merge(headers))
+      headers = default_headers.merge(headers)
+      log_request(__method__, path, headers, body)
+      response = perform(__method__, path) { connection.post(relative_path(path), body.to_json, headers) }
       handle_response(response)
-    rescue Faraday::ConnectionFailed => e
-      raise Tastytrade::NetworkTimeoutError, "Request timed out: #{e.message}"
     end
 
     def put(path, body = {}, headers = {})
-      response = connection.put(path, body.to_json, default_headers.merge(headers))
+      headers = default_headers.merge(headers)
+      log_request(__method__, path, headers, body)
+      response = perform(__method__, path) { connection.put(relative_path(path), body.to_json, headers) }
       handle_response(response)
-    rescue Faraday::ConnectionFailed => e
-      raise Tastytrade::NetworkTimeoutError, "Request timed out: #{e.message}"
     end
 
     def delete(path, headers = {})
-      response = connection.delete(path, nil, default_headers.merge(headers))
+      headers = default_headers.merge(headers)
+      log_request(__method__, path, headers)
+      response = perform(__method__, path) { connection.delete(relative_path(path), nil, headers) }
       handle_response(response)
-    rescue Faraday::ConnectionFailed => e
-      raise Tastytrade::NetworkTimeoutError, "Request timed out: #{e.message}"
     end
 
     private
 
+    # Runs the request, reporting its status and duration to the metrics hook
+    def perform(method, path)
+      started = Process.clock_gettime(Process::CLOCK_MONOTONIC)
+      status = nil
+      response = yield
+      status = response.status
+      response
+    rescue Faraday::ConnectionFailed, Faraday::TimeoutError => e
+      raise_timeout(e, method, path)
+    ensure
+      duration = Process.clock_gettime(Process::CLOCK_MONOTONIC) - started
+      @metrics.observe_request(method.to_s.upcase, Metrics.endpoint_template(path), status, duration)
+    end
+
+    def log_request(method, path, headers, payload = nil)
+      return unless @logger
+
+      message = "#{method.to_s.upcase} #{path} headers=#{redact(headers)}"
+      message += " #{method == :get ? "params" : "body"}=#{redact(payload)}" if payload && !payload.empty?
+      @logger.debug(message)
+    end
+
+    def log_response(response)
+      return unless @logger
+
+      @logger.debug("#{response.env&.method.to_s.upcase} #{response.env&.url&.path} returned #{response.status}")
+    end
+
+    def redact(hash)
+      hash.to_h { |key, value| [key, REDACTED_KEYS.include?(key.to_s) && value ? "[REDACTED]" : value] }
+    end
+
+    def raise_timeout(error, method, path)
+      raise Tastytrade::NetworkTimeoutError.new("Request timed out: #{error.message}",
+                                                http_method: method.to_s.upcase, endpoint: path)
+    end
+
+    # Paths are resolved relative to the base URL so a path prefix on it is kept;
+    # a leading slash would replace the prefix.
+    def relative_path(path)
+      path.delete_prefix("/")
+    end
+
     def connection
-      @connection ||= Faraday.new(url: base_url) do |faraday|
-        faraday.request :retry, max: 2, interval: 0.5,
-                                retry_statuses: [429, 503, 504],
-                                methods: %i[get put delete]
+      @connection ||= Faraday.new(url: base_url.end_with?("/") ? base_url : "#{base_url}/") do |faraday|
+        faraday.request :retry, retry_options
+        faraday.use RateLimiter::Middleware, @rate_limiter if @rate_limiter
         faraday.options.timeout = @timeout
-        faraday.options.open_timeout = @timeout
-        faraday.adapter Faraday.default_adapter
+        faraday.options.open_timeout = @open_timeout
+        faraday.adapter(*@adapter, &@adapter_block)
+      end
+    end
+
+    def configure_pool(pool_size, idle_timeout)
+      raise ArgumentError, "Pool size must be a positive integer" unless pool_size.is_a?(Integer) && pool_size.positive?
+      raise ArgumentError, "Idle timeout must be positive" if idle_timeout && !idle_timeout.positive?
+
+      begin
+        require "faraday/net_http_persistent"
+      rescue LoadError
+        raise ArgumentError, "Connection pooling requires the faraday-net_http_persistent gem"
       end
+
+      @pool_size = pool_size
+      @idle_timeout = idle_timeout
+      @adapter = [:net_http_persistent, { pool_size: pool_size }]
+      @adapter_block = ->(http) { http.idle_timeout = idle_timeout } if idle_timeout
+    end
+
+    def retry_options
+      {
+        max: @max_retries,
+        interval: @retry_interval,
+        backoff_factor: 2,
+        retry_statuses: RETRY_STATUSES,
+        methods: @retry_non_idempotent ? IDEMPOTENT_METHODS + %i[post] : IDEMPOTENT_METHODS,
+        retry_block: method(:log_retry)
+      }
+    end
+
+    def log_retry(env:, retry_count:, will_retry_in:, **)
+      return unless @logger
+
+      @logger.debug("Retrying #{env.method.to_s.upcase} #{env.url.path} " \
+                    "(attempt #{retry_count + 1} of #{@max_retries}) in #{will_retry_in}s")
     end
 
     def default_headers
       {
         "Accept" => "application/json",
-        "Content-Type" => "application/json"
+        "Content-Type" => "application/json",
+        "User-Agent" => @user_agent
       }
     end
 
     def handle_response(response)
+      log_response(response)
       return handle_success(response) if (200..299).cover?(response.status)
 
       handle_error(response)
     end
 
     def handle_success(response)
-      return nil if response.body.nil? || response.body.empty?
+      # 204 No Content and blank bodies have nothing to parse
+      return nil if response.status == 204 || response.body.nil? || response.body.strip.empty?
 
-      # API returns data in a 'data' field for most endpoints
+      # Most endpoints wrap their payload in a "data" field; the body is returned
+      # as-is either way and callers read "data" themselves
       parse_json(response.body)
     end
 
     def handle_error(response)
-      error_details = parse_error_message(response)
-
-      case response.status
-      when 401
-        raise Tastytrade::InvalidCredentialsError, "Authentication failed: #{error_details}"
-      when 403
-        raise Tastytrade::SessionExpiredError, "Session expired or invalid: #{error_details}"
-      when 404
-        raise Tastytrade::Error, "Resource not found: #{error_details}"
-      when 429
-        raise Tastytrade::Error, "Rate limit exceeded: #{error_details}"
-      when 400..499
-        raise Tastytrade::Error, "Client error: #{error_details}"
-      when 500..599
-        raise Tastytrade::Error, "Server error: #{error_details}"
-      else
-        raise Tastytrade::Error, "Unexpected response: #{error_details}"
+      error_details, code, details = parse_error_body(response)
+
+      error_class, prefix = case response.status
+                            when 401 then [Tastytrade::InvalidCredentialsError, "Authentication failed"]
+                            when 403 then [Tastytrade::SessionExpiredError, "Session expired or invalid"]
+                            when 404 then [Tastytrade::Error, "Resource not found"]
+                            when 429 then [Tastytrade::Error, "Rate limit exceeded"]
+                            when 400..499 then [Tastytrade::Error, "Client error"]
+                            when 500..599 then [Tastytrade::Error, "Server error"]
+                            else [Tastytrade::Error, "Unexpected response"]
+      end
+
+      raise_with_context(error_class, "#{prefix}: #{error_details}", response, code: code, details: details)
+    end
+
+    def raise_with_context(error_class, message, response, code: nil, details: nil)
+      http_method = response.env&.method&.to_s&.upcase
+      endpoint = response.env&.url&.path
+      request_id = REQUEST_ID_HEADERS.lazy.map { |name| response.headers[name] }.find { |value| value }
+
+      context = "#{http_method} #{endpoint} returned #{response.status}"
+      context += ", request ID #{request_id}" if request_id
+
+      error_class = rejection_error_class(code, details) || error_class if error_class == Tastytrade::Error
+      raise error_class.new("#{message} (#{context})", http_method: http_method, endpoint: endpoint,
+                                                       status: response.status, request_id: request_id,
+                                                       code: code, details: details)
+    end
+
+    # Order rejections with a recognized code raise the matching OrderError subclass
+    def rejection_error_class(code, details)
+      codes = [code, *Array(details).grep(Hash).map { |detail| detail["code"] }].compact
+      if codes.intersect?(Tastytrade::Error::INSUFFICIENT_BUYING_POWER_CODES)
+        Tastytrade::InsufficientFundsError
+      elsif codes.intersect?(Tastytrade::Error::MARKET_CLOSED_CODES)
+        Tastytrade::MarketClosedError
       end
     end
 
+    def json_object_class
+      @strict_time_parsing ? StrictTimeHash : Hash
+    end
+
     def parse_json(body)
-      JSON.parse(body)
+      JSON.parse(body, object_class: json_object_class)
     rescue JSON::ParserError => e
       raise Tastytrade::Error, "Invalid JSON response: #{e.message}"
     end
 
-    def parse_error_message(response)
-      return response.status.to_s if response.body.nil? || response.body.empty?
+    # Returns the message, code and nested errors from an error response. The
+    # error may be at the top level or nested under "error".
+    def parse_error_body(response)
+      return [response.status.to_s, nil, []] if response.body.nil? || response.body.empty?
 
       data = parse_json(response.body)
+      data = data["error"] if data["error"].is_a?(Hash)
+      details = data["errors"].is_a?(Array) ? data["errors"].grep(Hash) : []
 
       # Handle preflight check failures with detailed errors
-      if data["code"] == "preflight_check_failure" && data["errors"]
-        error_details = data["errors"].map { |e| e["message"] }.join(", ")
-        return "#{data["message"]}: #{error_details}"
+      if data["code"] == "preflight_check_failure" && details.any?
+        error_details = details.map { |e| e["message"] }.join(", ")
+        return ["#{data["message"]}: #{error_details}", data["code"], details]
       end
 
       # Handle both old and new API error formats
-      data["error"] || data["message"] || data["reason"] || response.status.to_s
+      message = data["error"] || data["message"] || data["reason"] || response.status.to_s
+      [message, data["code"], details]
     rescue StandardError
-      response.status.to_s
+      [response.status.to_s, nil, []]
     end
   end
 end
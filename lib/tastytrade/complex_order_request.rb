@@ -0,0 +1,132 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require_relative "order"
+
+module Tastytrade
+  # A complex order (OTO, OCO or OTOCO) ready to submit.
+  #
+  # The group's shape is checked on construction: OTO and OTOCO orders need a
+  # trigger order, while OCO orders have no trigger and at least two orders
+  # that cancel each other.
+  #
+  # @example Bracket an entry with a profit target and a stop
+  #   bracket = Tastytrade::ComplexOrderRequest.new(
+  #     type: Tastytrade::Models::ComplexOrder::OTOCO,
+  #     trigger_order: entry,
+  #     orders: [profit_target, stop_loss]
+  #   )
+  #   account.place_complex_order(session, bracket)
+  class ComplexOrderRequest
+    # Sides of a bracket's entry
+    BRACKET_DIRECTIONS = %i[long short].freeze
+
+    attr_reader :type, :trigger_order, :orders
+
+    # Builds an OTOCO bracket: a limit entry that, once filled, activates a
+    # limit profit target and a stop loss, each closing the position
+    #
+    # A long bracket buys to open and sells to close, so prices must satisfy
+    # target > entry > stop. A short bracket sells to open and buys to close,
+    # requiring target < entry < stop.
+    #
+    # @param symbol [String] Symbol to trade
+    # @param quantity [Integer] Quantity for every order
+    # @param entry [Numeric, String] Entry limit price
+    # @param target [Numeric, String] Profit target limit price
+    # @param stop [Numeric, String] Stop loss trigger price
+    # @param direction [Symbol] :long or :short
+    # @param instrument_type [String] Instrument type of the legs
+    # @param time_in_force [String] OrderTimeInForce of the entry
+    # @param exit_time_in_force [String] OrderTimeInForce of the target and stop
+    # @return [ComplexOrderRequest] OTOCO order ready to submit
+    # @raise [ArgumentError] if the direction is unknown or the prices are out of order
+    #
+    # @example Buy 100 AAPL at 150, take profit at 160, stop out at 145
+    #   bracket = ComplexOrderRequest.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 160, stop: 145)
+    #   account.place_complex_order(session, bracket)
+    def self.bracket(symbol:, quantity:, entry:, target:, stop:, direction: :long, instrument_type: "Equity",
+                     time_in_force: OrderTimeInForce::DAY, exit_time_in_force: OrderTimeInForce::GTC)
+      entry, target, stop = [entry, target, stop].map { |price| BigDecimal(price.to_s) }
+      validate_bracket_prices!(direction, entry, target, stop)
+
+      open_action, close_action = if direction == :long
+                                    [OrderAction::BUY_TO_OPEN, OrderAction::SELL_TO_CLOSE]
+                                  else
+                                    [OrderAction::SELL_TO_OPEN, OrderAction::BUY_TO_CLOSE]
+                                  end
+      leg = lambda do |action|
+        OrderLeg.new(action: action, symbol: symbol, quantity: quantity, instrument_type: instrument_type)
+      end
+
+      new(
+        type: Models::ComplexOrder::OTOCO,
+        trigger_order: Order.new(type: OrderType::LIMIT, time_in_force: time_in_force, legs: leg.call(open_action),
+                                 price: entry),
+        orders: [
+          Order.new(type: OrderType::LIMIT, time_in_force: exit_time_in_force, legs: leg.call(close_action),
+                    price: target),
+          Order.new(type: OrderType::STOP, time_in_force: exit_time_in_force, legs: leg.call(close_action),
+                    stop_trigger: stop)
+        ]
+      )
+    end
+
+    def self.validate_bracket_prices!(direction, entry, target, stop)
+      unless BRACKET_DIRECTIONS.include?(direction)
+        raise ArgumentError, "Invalid bracket direction: #{direction}. Must be one of: #{BRACKET_DIRECTIONS.join(", ")}"
+      end
+
+      raise ArgumentError, "Bracket prices must be greater than 0" unless [entry, target, stop].all?(&:positive?)
+
+      if direction == :long && !(target > entry && entry > stop)
+        raise ArgumentError, "Long brackets require target > entry > stop"
+      elsif direction == :short && !(target < entry && entry < stop)
+        raise ArgumentError, "Short brackets require target < entry < stop"
+      end
+    end
+    private_class_method :validate_bracket_prices!
+
+    # @param type [String] One of {Models::ComplexOrder::TYPES}
+    # @param orders [Array<Order>] Contingent orders (the OCO pair for OTOCO)
+    # @param trigger_order [Order, nil] Order whose fill activates the others
+    # @raise [ArgumentError] if the orders do not match the complex order type
+    def initialize(type:, orders:, trigger_order: nil)
+      @type = type
+      @trigger_order = trigger_order
+      @orders = Array(orders)
+
+      validate!
+    end
+
+    def to_api_params
+      params = {
+        "type" => @type,
+        "orders" => @orders.map(&:to_api_params)
+      }
+      params["trigger-order"] = @trigger_order.to_api_params if @trigger_order
+      params
+    end
+
+    private
+
+    def validate!
+      unless Models::ComplexOrder::TYPES.include?(@type)
+        raise ArgumentError,
+              "Invalid complex order type: #{@type}. Must be one of: #{Models::ComplexOrder::TYPES.join(", ")}"
+      end
+
+      case @type
+      when Models::ComplexOrder::OTO
+        raise ArgumentError, "OTO orders require a trigger order" unless @trigger_order
+        raise ArgumentError, "OTO orders require exactly one contingent order" unless @orders.size == 1
+      when Models::ComplexOrder::OTOCO
+        raise ArgumentError, "OTOCO orders require a trigger order" unless @trigger_order
+        raise ArgumentError, "OTOCO orders require exactly two contingent orders" unless @orders.size == 2
+      when Models::ComplexOrder::OCO
+        raise ArgumentError, "OCO orders cannot have a trigger order" if @trigger_order
+        raise ArgumentError, "OCO orders require at least two orders" if @orders.size < 2
+      end
+    end
+  end
+end
@@ -0,0 +1,49 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  # Dollars per point of price for one unit of an instrument.
+  #
+  # Shares count once and a standard equity option covers 100 shares. Futures
+  # and futures options differ by product, e.g. 50 for /ES and 5 for /MES, so
+  # their multiplier must come from the API's position or instrument data.
+  #
+  # @example
+  #   ContractMultiplier.for("Equity Option")         # => 100
+  #   ContractMultiplier.for("Future", "50")          # => 50
+  #   ContractMultiplier.for("Future")                # => nil
+  module ContractMultiplier
+    # Multipliers that are the same for every instrument of a type, keyed by
+    # both position and order leg instrument types
+    STANDARD = {
+      "Equity" => 1,
+      "Equity Option" => 100,
+      "Option" => 100,
+      "Cryptocurrency" => 1
+    }.freeze
+
+    # Instrument types whose multiplier varies by product
+    FUTURES_INSTRUMENT_TYPES = ["Future", "Future Option"].freeze
+
+    # @param instrument_type [String] Position or order leg instrument type
+    # @param multiplier [Numeric, String, nil] Multiplier reported for the instrument or position
+    # @return [Integer, BigDecimal, nil] The reported multiplier if given, otherwise the standard one;
+    #   nil for a futures instrument without a reported multiplier
+    def self.for(instrument_type, multiplier = nil)
+      return parse(multiplier) unless multiplier.nil? || multiplier.to_s.empty?
+      return nil if FUTURES_INSTRUMENT_TYPES.include?(instrument_type)
+
+      STANDARD.fetch(instrument_type, 1)
+    end
+
+    # Whole multipliers stay Integer; fractional ones become BigDecimal
+    #
+    # @param value [Numeric, String] Multiplier
+    # @return [Integer, BigDecimal]
+    def self.parse(value)
+      multiplier = BigDecimal(value.to_s)
+      multiplier.frac.zero? ? multiplier.to_i : multiplier
+    end
+  end
+end
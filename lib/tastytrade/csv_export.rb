@@ -0,0 +1,98 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "csv"
+require "date"
+require "time"
+
+module Tastytrade
+  # CSV export of transactions and positions for bookkeeping and taxes
+  #
+  # Columns are fixed and always written with a header row. Decimals are
+  # written in plain notation, times in UTC ISO 8601, dates as YYYY-MM-DD and
+  # missing values as empty cells.
+  #
+  # @example Write a year of transactions to a file
+  #   transactions = account.get_transactions(session, start_date: Date.new(2024, 1, 1))
+  #   File.open("transactions-2024.csv", "w") { |file| Tastytrade::CSVExport.transactions(transactions, io: file) }
+  module CSVExport
+    TRANSACTION_COLUMNS = {
+      "ID" => :id,
+      "Account" => :account_number,
+      "Executed At" => :executed_at,
+      "Transaction Date" => :transaction_date,
+      "Type" => :transaction_type,
+      "Sub Type" => :transaction_sub_type,
+      "Action" => :action,
+      "Symbol" => :symbol,
+      "Underlying Symbol" => :underlying_symbol,
+      "Instrument Type" => :instrument_type,
+      "Quantity" => :quantity,
+      "Price" => :price,
+      "Value" => :value,
+      "Value Effect" => :value_effect,
+      "Commission" => :commission,
+      "Clearing Fees" => :clearing_fees,
+      "Regulatory Fees" => :regulatory_fees,
+      "Net Value" => :net_value,
+      "Net Value Effect" => :net_value_effect,
+      "Order ID" => :order_id,
+      "Description" => :description
+    }.freeze
+
+    POSITION_COLUMNS = {
+      "Account" => :account_number,
+      "Symbol" => :symbol,
+      "Underlying Symbol" => :underlying_symbol,
+      "Instrument Type" => :instrument_type,
+      "Quantity" => :quantity,
+      "Direction" => :quantity_direction,
+      "Average Open Price" => :average_open_price,
+      "Close Price" => :close_price,
+      "Multiplier" => :multiplier,
+      "Cost Effect" => :cost_effect,
+      "Realized Day Gain" => :realized_day_gain,
+      "Expires At" => :expires_at,
+      "Created At" => :created_at,
+      "Updated At" => :updated_at
+    }.freeze
+
+    class << self
+      # @param transactions [Array<Models::Transaction>] Transactions to export
+      # @param io [IO, nil] Stream to write to instead of returning a string
+      # @return [String, IO] CSV text, or the stream when io is given
+      def transactions(transactions, io: nil)
+        generate(TRANSACTION_COLUMNS, transactions, io)
+      end
+
+      # @param positions [Array<Models::CurrentPosition>] Positions to export
+      # @param io [IO, nil] Stream to write to instead of returning a string
+      # @return [String, IO] CSV text, or the stream when io is given
+      def positions(positions, io: nil)
+        generate(POSITION_COLUMNS, positions, io)
+      end
+
+      private
+
+      def generate(columns, records, io)
+        rows = records.map { |record| columns.values.map { |attr| format_value(record.public_send(attr)) } }
+        rows.unshift(columns.keys)
+        return CSV.generate { |csv| rows.each { |row| csv << row } } unless io
+
+        csv = CSV.new(io)
+        rows.each { |row| csv << row }
+        io
+      end
+
+      def format_value(value)
+        case value
+        when nil then nil
+        when BigDecimal then value.to_s("F")
+        when Time, DateTime then value.to_time.utc.iso8601
+        when Date then value.iso8601
+        else value.to_s
+        end
+      end
+    end
+  end
+end
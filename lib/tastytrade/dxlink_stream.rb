@@ -0,0 +1,259 @@
+# frozen_string_literal: true
+
+require "json"
+require_relative "websocket_connection"
+
+module Tastytrade
+  # Market data events from the DXLink streamer
+  #
+  # Messages are JSON text frames tagged with a type and channel. The client
+  # sends SETUP and AUTH with a {Models::QuoteToken} on channel 0, then opens a
+  # FEED channel once the server reports AUTH_STATE AUTHORIZED:
+  #
+  #   {"type": "CHANNEL_REQUEST", "channel": 1, "service": "FEED", "parameters": {"contract": "AUTO"}}
+  #
+  # Subscribing sends FEED_SETUP with the fields wanted for the event type and
+  # FEED_SUBSCRIPTION with the symbols. Events arrive in the compact format,
+  # one flat array of field values per event type:
+  #
+  #   {"type": "FEED_DATA", "channel": 1, "data": ["Greeks", ["Greeks", ".SPY240315C450", 0.52, ...]]}
+  #
+  # Each event is yielded as a Hash of field name to value. A keepalive is
+  # sent every keepalive_interval seconds.
+  #
+  # If the connection drops, the stream reconnects with exponential backoff,
+  # authorizes again and replays every subscription. :disconnected and
+  # :reconnected are pushed to {#statuses} as this happens. A rejected token
+  # is replaced from token_provider when one is given; otherwise the stream
+  # closes with a {StreamUnauthorizedError} as its {#last_error}.
+  #
+  # @example
+  #   token = session.quote_token
+  #   stream = DXLinkStream.new(token.token, token.dxlink_url,
+  #                             token_provider: -> { session.quote_token(refresh: true).token }).connect
+  #   stream.subscribe("Greeks", [".SPY240315C450"], %w[eventType eventSymbol delta]) { |event| p event }
+  class DXLinkStream
+    PROTOCOL_VERSION = "0.1-DXF-JS/0.3.0"
+    FEED_CHANNEL = 1
+    KEEPALIVE_TIMEOUT = 60
+    KEEPALIVE_INTERVAL = 30
+    CONNECT_TIMEOUT = 10
+    RECONNECT_DELAY = 1
+    MAX_RECONNECT_DELAY = 30
+    MAX_RECONNECT_ATTEMPTS = 10
+
+    # @return [Queue<Symbol>] :disconnected and :reconnected as the connection drops and
+    #   recovers; closed, so pop returns nil, when the stream closes
+    attr_reader :statuses
+
+    attr_reader :url, :last_error
+
+    # @param token [String] Quote streamer token
+    # @param url [String] DXLink websocket URL
+    # @param keepalive_interval [Numeric, nil] Seconds between keepalives, nil to disable
+    # @param reconnect_delay [Numeric] Seconds before the first reconnect attempt, doubled on each attempt
+    # @param max_reconnect_attempts [Integer] Attempts before giving up, 0 to disable reconnection
+    # @param token_provider [#call, nil] Returns a new token when the server rejects the current one
+    # @param connector [#call, nil] Called with the URL to build a connection; defaults to
+    #   {WebSocketConnection}
+    # @param sleeper [#call] Sleeps for the given number of seconds, between keepalives and
+    #   before reconnect attempts
+    # @param logger [Logger, nil] Debug logger for stream activity
+    def initialize(token, url, keepalive_interval: KEEPALIVE_INTERVAL, reconnect_delay: RECONNECT_DELAY,
+                   max_reconnect_attempts: MAX_RECONNECT_ATTEMPTS, token_provider: nil, connector: nil,
+                   sleeper: nil, logger: nil)
+      @token = token
+      @url = url
+      @keepalive_interval = keepalive_interval
+      @reconnect_delay = reconnect_delay
+      @max_reconnect_attempts = max_reconnect_attempts
+      @token_provider = token_provider
+      @connector = connector || ->(stream_url) { WebSocketConnection.new(stream_url) }
+      @sleeper = sleeper || ->(seconds) { sleep(seconds) }
+      @logger = logger
+      @fields = {}
+      @handlers = {}
+      @symbols = {}
+      @ready = Queue.new
+      @statuses = Queue.new
+      @mutex = Mutex.new
+      @streaming = false
+      @closed = false
+    end
+
+    # Opens the websocket, authorizes and waits for the feed channel
+    #
+    # @param timeout [Numeric] Seconds to wait for the feed channel to open
+    # @return [self]
+    # @raise [StreamError] if the connection fails, the token is rejected or the channel does not open
+    def connect(timeout: CONNECT_TIMEOUT)
+      open_feed(timeout)
+      @streaming = true
+      start_keepalive
+      self
+    rescue StreamError
+      close
+      raise
+    end
+
+    # Subscribes to events of one type for the given symbols
+    #
+    # Subscribing to an event type again adds symbols and replaces its fields
+    # and handler.
+    #
+    # @param event_type [String] DXLink event type, e.g. "Greeks" or "Quote"
+    # @param symbols [Array<String>] Streamer symbols
+    # @param fields [Array<String>] Event fields to receive, starting with "eventType" and "eventSymbol"
+    # @yieldparam event [Hash{String => Object}] Field values of one event
+    # @return [self]
+    # @raise [StreamError] if the stream is not connected
+    def subscribe(event_type, symbols, fields, &handler)
+      raise StreamError, "DXLink stream is not connected" unless connected?
+
+      @mutex.synchronize do
+        @fields[event_type] = fields
+        @handlers[event_type] = handler
+        @symbols[event_type] = (@symbols[event_type] || []) | symbols
+      end
+      send_subscription(event_type, fields, symbols)
+      self
+    end
+
+    # @return [Boolean] true while the websocket is open
+    def connected?
+      !@connection.nil? && @connection.open?
+    end
+
+    # Closes the websocket and the status queue
+    def close
+      @closed = true
+      @keepalive_thread&.kill
+      @connection&.close
+      @statuses.close
+    end
+
+    def closed?
+      @closed
+    end
+
+    private
+
+    def open_feed(timeout)
+      @ready.clear
+      @connection = @connector.call(@url)
+      @connection.connect(on_message: method(:handle_message), on_close: method(:handle_close))
+      send_message("type" => "SETUP", "channel" => 0, "version" => PROTOCOL_VERSION,
+                   "keepaliveTimeout" => KEEPALIVE_TIMEOUT, "acceptKeepaliveTimeout" => KEEPALIVE_TIMEOUT)
+      send_message("type" => "AUTH", "channel" => 0, "token" => @token)
+
+      result = @ready.pop(timeout: timeout)
+      raise StreamError, "Timed out waiting for the DXLink feed channel" if result.nil?
+      raise result if result.is_a?(StreamError)
+    rescue StreamError
+      @connection&.close
+      raise
+    end
+
+    def send_subscription(event_type, fields, symbols)
+      send_message("type" => "FEED_SETUP", "channel" => FEED_CHANNEL, "acceptAggregationPeriod" => 0.1,
+                   "acceptDataFormat" => "COMPACT", "acceptEventFields" => { event_type => fields })
+      send_message("type" => "FEED_SUBSCRIPTION", "channel" => FEED_CHANNEL,
+                   "add" => symbols.map { |symbol| { "type" => event_type, "symbol" => symbol } })
+    end
+
+    def send_message(message)
+      @connection.send_text(message.to_json)
+    end
+
+    def start_keepalive
+      return unless @keepalive_interval
+
+      @keepalive_thread = Thread.new do
+        loop do
+          @sleeper.call(@keepalive_interval)
+          send_message("type" => "KEEPALIVE", "channel" => 0) if connected?
+        end
+      end
+    end
+
+    def handle_message(text)
+      message = JSON.parse(text)
+
+      case message["type"]
+      when "AUTH_STATE"
+        open_feed_channel if message["state"] == "AUTHORIZED"
+      when "CHANNEL_OPENED"
+        @ready << true if message["channel"] == FEED_CHANNEL
+      when "ERROR"
+        error_class = message["error"] == "UNAUTHORIZED" ? StreamUnauthorizedError : StreamError
+        @ready << error_class.new("DXLink #{message["error"]}: #{message["message"]}")
+      when "FEED_DATA"
+        dispatch_events(message["data"] || [])
+      end
+    rescue JSON::ParserError => e
+      @logger&.debug("Ignoring malformed DXLink message: #{e.message}")
+    end
+
+    def open_feed_channel
+      send_message("type" => "CHANNEL_REQUEST", "channel" => FEED_CHANNEL, "service" => "FEED",
+                   "parameters" => { "contract" => "AUTO" })
+    end
+
+    # Compact data alternates event types and flat value arrays
+    def dispatch_events(data)
+      data.each_slice(2) do |event_type, values|
+        fields = @fields[event_type]
+        handler = @handlers[event_type]
+        next unless fields && handler && values.is_a?(Array)
+
+        values.each_slice(fields.size) { |event| handler.call(fields.zip(event).to_h) }
+      end
+    end
+
+    def handle_close(reason)
+      @logger&.debug("DXLink stream disconnected: #{reason}")
+      @ready << StreamError.new("DXLink stream disconnected: #{reason}")
+      # Drops during a handshake are reported to the handshake instead
+      return if @closed || !@streaming
+
+      @streaming = false
+      @statuses << :disconnected
+      reconnect
+    end
+
+    def reconnect
+      delay = @reconnect_delay
+      @max_reconnect_attempts.times do |attempt|
+        @sleeper.call(delay)
+        return if @closed
+
+        begin
+          open_feed(CONNECT_TIMEOUT)
+          resubscribe
+          @streaming = true
+          @statuses << :reconnected
+          return
+        rescue StreamUnauthorizedError => e
+          @last_error = e
+          return close unless @token_provider
+
+          @token = @token_provider.call
+        rescue StreamError => e
+          @last_error = e
+          @logger&.debug("DXLink stream reconnect #{attempt + 1} failed: #{e.message}")
+          delay = [delay * 2, MAX_RECONNECT_DELAY].min
+        end
+      end
+
+      @last_error ||= StreamError.new("DXLink stream disconnected")
+      close
+    end
+
+    def resubscribe
+      subscriptions = @mutex.synchronize do
+        @symbols.map { |event_type, symbols| [event_type, @fields[event_type], symbols] }
+      end
+      subscriptions.each { |subscription| send_subscription(*subscription) }
+    end
+  end
+end
@@ -0,0 +1,132 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require_relative "order"
+
+module Tastytrade
+  # Rates for estimating an order's fees without a dry run, e.g. in backtests.
+  #
+  # {DEFAULT} uses tastytrade's retail commissions: options cost $1 per
+  # contract to open, capped at $10 per leg, and nothing to close; stock
+  # trades are commission free. Clearing and regulatory fees are charged per
+  # contract or share. Regulatory fees change over time, so estimates can be
+  # off by a few cents. Exchange fees on index options such as SPX vary by
+  # product and are left out unless index_option_fee: is given; pass
+  # different rates to match a dry run or a negotiated schedule.
+  #
+  # @example
+  #   fees = FeeSchedule::DEFAULT.estimate(order)
+  #   fees.total  # => BigDecimal("1.14")
+  #
+  # @example Custom rates
+  #   schedule = FeeSchedule.new(option_commission: "0.65", option_commission_cap: nil)
+  class FeeSchedule
+    RATES = {
+      # Commission per option contract opened
+      option_commission: "1.0",
+      # Maximum option commission per leg, nil for no cap
+      option_commission_cap: "10.0",
+      # Commission per option contract closed
+      option_closing_commission: "0.0",
+      option_clearing_fee: "0.1",
+      option_regulatory_fee: "0.04",
+      # Exchange fee per contract on cash-settled index options such as SPX, not
+      # estimated by default
+      index_option_fee: "0.0",
+      equity_commission: "0.0",
+      equity_clearing_fee: "0.0008",
+      # Charged per share sold
+      equity_regulatory_fee: "0.000166"
+    }.freeze
+
+    # Leg instrument types fees can be estimated for
+    INSTRUMENT_TYPES = %w[Equity Option].freeze
+
+    attr_reader(*RATES.keys)
+
+    # @param rates [Hash{Symbol => Numeric, String, nil}] Rates to change from {RATES}, in dollars
+    # @raise [ArgumentError] if a rate is unknown or negative
+    def initialize(**rates)
+      unknown = rates.keys - RATES.keys
+      raise ArgumentError, "Unknown fee rate: #{unknown.join(", ")}" unless unknown.empty?
+
+      RATES.merge(rates).each do |name, value|
+        rate = value.nil? ? nil : BigDecimal(value.to_s)
+        raise ArgumentError, "Fee rate #{name} cannot be negative" if rate&.negative?
+
+        instance_variable_set("@#{name}", rate)
+      end
+      freeze
+    end
+
+    # Estimates the fees for an order or its legs
+    #
+    # @param order [Order, Array<OrderLeg>] Order or legs to estimate
+    # @return [Models::FeeCalculation] Estimated fees, each rounded to the cent
+    # @raise [ArgumentError] if a leg is not an equity or option leg, or is a notional leg
+    #   without a quantity
+    def estimate(order)
+      legs = order.is_a?(Order) ? order.legs : Array(order)
+      fees = Hash.new(BigDecimal("0"))
+      legs.each do |leg|
+        unless INSTRUMENT_TYPES.include?(leg.instrument_type)
+          raise ArgumentError, "Cannot estimate fees for #{leg.instrument_type} legs"
+        end
+        if leg.quantity.nil?
+          raise ArgumentError, "Cannot estimate fees for notional leg #{leg.symbol} without a quantity"
+        end
+
+        leg_fees = leg.instrument_type == "Option" ? option_fees(leg) : equity_fees(leg)
+        leg_fees.each { |name, amount| fees[name] += amount }
+      end
+
+      build_calculation(fees)
+    end
+
+    private
+
+    def option_fees(leg)
+      contracts = leg.quantity
+      commission = opening?(leg) ? @option_commission * contracts : @option_closing_commission * contracts
+      commission = [commission, @option_commission_cap].min if @option_commission_cap
+      index_root = Models::Option::CASH_SETTLED_ROOTS.include?(leg.symbol.split.first)
+
+      {
+        commission: commission,
+        clearing_fees: @option_clearing_fee * contracts,
+        regulatory_fees: @option_regulatory_fee * contracts,
+        proprietary_index_option_fees: index_root ? @index_option_fee * contracts : BigDecimal("0")
+      }
+    end
+
+    def equity_fees(leg)
+      shares = leg.quantity
+      sold = [OrderAction::SELL_TO_OPEN, OrderAction::SELL_TO_CLOSE].include?(leg.action)
+
+      {
+        commission: @equity_commission * shares,
+        clearing_fees: @equity_clearing_fee * shares,
+        regulatory_fees: sold ? @equity_regulatory_fee * shares : BigDecimal("0")
+      }
+    end
+
+    def opening?(leg)
+      [OrderAction::BUY_TO_OPEN, OrderAction::SELL_TO_OPEN].include?(leg.action)
+    end
+
+    def build_calculation(fees)
+      names = %i[regulatory_fees clearing_fees commission proprietary_index_option_fees]
+      amounts = names.to_h { |name| [name, fees[name].round(2, BigDecimal::ROUND_HALF_UP)] }
+      amounts[:total_fees] = amounts.values.sum(BigDecimal("0"))
+
+      data = amounts.each_with_object({}) do |(name, amount), hash|
+        key = name.to_s.tr("_", "-")
+        hash[key] = amount.to_s("F")
+        hash["#{key}-effect"] = amount.zero? ? "None" : PriceEffect::DEBIT
+      end
+      Models::FeeCalculation.new(data)
+    end
+
+    DEFAULT = new
+  end
+end
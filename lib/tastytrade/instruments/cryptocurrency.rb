@@ -0,0 +1,95 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "uri"
+
+module Tastytrade
+  module Instruments
+    # Represents a cryptocurrency instrument such as BTC/USD
+    #
+    # Cryptocurrencies trade in fractional quantities, so legs built from them
+    # carry a decimal quantity.
+    class Cryptocurrency
+      INSTRUMENT_TYPE = "Cryptocurrency"
+
+      attr_reader :id, :symbol, :instrument_type, :short_description, :description,
+                  :is_closing_only, :active, :tick_size, :streamer_symbol,
+                  :destination_venue_symbols
+
+      def initialize(data = {})
+        @id = data["id"]
+        @symbol = data["symbol"]
+        @instrument_type = data["instrument-type"]
+        @short_description = data["short-description"]
+        @description = data["description"]
+        @is_closing_only = data["is-closing-only"]
+        @active = data["active"]
+        @tick_size = data["tick-size"] ? BigDecimal(data["tick-size"].to_s) : nil
+        @streamer_symbol = data["streamer-symbol"]
+        @destination_venue_symbols = (data["destination-venue-symbols"] || []).map do |venue|
+          DestinationVenueSymbol.new(venue)
+        end
+      end
+
+      # Get a cryptocurrency by symbol
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Cryptocurrency symbol, e.g. "BTC/USD"
+      # @return [Cryptocurrency] Cryptocurrency instrument
+      def self.get(session, symbol)
+        symbol = Tastytrade.normalize_symbol(symbol, session)
+        response = session.get("/instruments/cryptocurrencies/#{URI.encode_www_form_component(symbol)}")
+        new(response["data"])
+      end
+
+      # Get several cryptocurrencies, or every available one when no symbols are given
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbols [Array<String>, nil] Cryptocurrency symbols
+      # @return [Array<Cryptocurrency>] Cryptocurrency instruments
+      def self.get_all(session, symbols = nil)
+        symbols &&= Array(symbols).map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+        params = symbols ? { "symbol[]" => symbols } : {}
+        response = session.get("/instruments/cryptocurrencies", params)
+        (response.dig("data", "items") || []).map { |item| new(item) }
+      end
+
+      def closing_only?
+        @is_closing_only == true
+      end
+
+      def active?
+        @active == true
+      end
+
+      # Create an order leg for this cryptocurrency
+      #
+      # @param action [String] Order action (from OrderAction module)
+      # @param quantity [Numeric, String] Quantity, fractional amounts allowed
+      # @return [OrderLeg] Order leg for this cryptocurrency
+      def build_leg(action:, quantity:)
+        OrderLeg.new(
+          action: action,
+          symbol: @symbol,
+          quantity: quantity,
+          instrument_type: INSTRUMENT_TYPE
+        )
+      end
+
+      # Symbol used by a destination venue for a cryptocurrency
+      class DestinationVenueSymbol
+        attr_reader :id, :symbol, :destination_venue, :max_quantity_precision,
+                    :max_price_precision, :routable
+
+        def initialize(data = {})
+          @id = data["id"]
+          @symbol = data["symbol"]
+          @destination_venue = data["destination-venue"]
+          @max_quantity_precision = data["max-quantity-precision"]
+          @max_price_precision = data["max-price-precision"]
+          @routable = data["routable"]
+        end
+      end
+    end
+  end
+end
@@ -4,7 +4,10 @@ module Tastytrade
   module Instruments
     # Represents an equity instrument
     class Equity
-      attr_reader :symbol, :description, :exchange, :cusip, :active
+      # Symbols requested per instruments call, keeping the query string short
+      DEFAULT_BATCH_SIZE = 100
+
+      attr_reader :symbol, :description, :exchange, :cusip, :active, :tick_sizes, :option_tick_sizes
 
       def initialize(data = {})
         @symbol = data["symbol"]
@@ -12,6 +15,8 @@ module Tastytrade
         @exchange = data["exchange"]
         @cusip = data["cusip"]
         @active = data["active"]
+        @tick_sizes = data["tick-sizes"] || []
+        @option_tick_sizes = data["option-tick-sizes"] || []
       end
 
       # Get equity information for a symbol
@@ -20,10 +25,35 @@ module Tastytrade
       # @param symbol [String] Equity symbol
       # @return [Equity] Equity instrument
       def self.get(session, symbol)
-        response = session.get("/instruments/equities/#{symbol}")
+        response = session.get("/instruments/equities/#{Tastytrade.normalize_symbol(symbol, session)}")
         new(response["data"])
       end
 
+      # Get several equities, fetched in batches of batch_size symbols
+      #
+      # Each batch is one request sending its symbols as symbol[] parameters.
+      # Batches are fetched one after another to stay within rate limits.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbols [Array<String>] Equity symbols
+      # @param batch_size [Integer] Symbols per request
+      # @return [Array<Equity>] Equity instruments the API returned
+      # @raise [ArgumentError] if batch_size is not a positive integer
+      #
+      # @example
+      #   Equity.get_all(session, watchlist_symbols).reject(&:active)
+      def self.get_all(session, symbols, batch_size: DEFAULT_BATCH_SIZE)
+        unless batch_size.is_a?(Integer) && batch_size.positive?
+          raise ArgumentError, "Batch size must be a positive integer"
+        end
+
+        symbols = Array(symbols).map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+        symbols.uniq.each_slice(batch_size).flat_map do |batch|
+          response = session.get("/instruments/equities", { "symbol[]" => batch })
+          (response.dig("data", "items") || []).map { |item| new(item) }
+        end
+      end
+
       # Create an order leg for this equity
       #
       # @param action [String] Order action (from OrderAction module)
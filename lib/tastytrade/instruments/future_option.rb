@@ -0,0 +1,112 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+require "uri"
+
+module Tastytrade
+  module Instruments
+    # Represents an option on a futures contract, e.g. "./ESZ4 EW4X4 241129C5800"
+    #
+    # Future option symbols combine the underlying future, the option contract
+    # code and the expiration, type and strike. Numeric fields may arrive as
+    # strings or numbers and are parsed as BigDecimal.
+    class FutureOption
+      INSTRUMENT_TYPE = "Future Option"
+
+      attr_reader :symbol, :underlying_symbol, :product_code, :expiration_date, :root_symbol,
+                  :option_root_symbol, :strike_price, :option_type, :exercise_style, :is_vanilla,
+                  :future_price_ratio, :multiplier, :is_closing_only, :underlying_count,
+                  :days_to_expiration, :settlement_type, :streamer_symbol, :active
+
+      def initialize(data = {})
+        @symbol = data["symbol"]
+        @underlying_symbol = data["underlying-symbol"]
+        @product_code = data["product-code"]
+        @expiration_date = parse_date(data["expiration-date"])
+        @root_symbol = data["root-symbol"]
+        @option_root_symbol = data["option-root-symbol"]
+        @strike_price = parse_decimal(data["strike-price"])
+        @option_type = data["option-type"]
+        @exercise_style = data["exercise-style"]
+        @is_vanilla = data["is-vanilla"]
+        @future_price_ratio = parse_decimal(data["future-price-ratio"])
+        @multiplier = parse_decimal(data["multiplier"])
+        @is_closing_only = data["is-closing-only"]
+        @underlying_count = parse_decimal(data["underlying-count"])
+        @days_to_expiration = data["days-to-expiration"]
+        @settlement_type = data["settlement-type"]
+        @streamer_symbol = data["streamer-symbol"]
+        @active = data["active"]
+      end
+
+      # Get a future option by symbol
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Future option symbol, e.g. "./ESZ4 EW4X4 241129C5800"
+      # @return [FutureOption] Future option instrument
+      def self.get(session, symbol)
+        symbol = Tastytrade.normalize_symbol(symbol, session)
+        response = session.get("/instruments/future-options/#{URI.encode_uri_component(symbol)}")
+        new(response["data"])
+      end
+
+      # Get several future options by symbol
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbols [Array<String>] Future option symbols
+      # @return [Array<FutureOption>] Future option instruments
+      def self.get_all(session, symbols)
+        symbols = Array(symbols).map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+        response = session.get("/instruments/future-options", { "symbol[]" => symbols })
+        (response.dig("data", "items") || []).map { |item| new(item) }
+      end
+
+      def call?
+        @option_type == "C"
+      end
+
+      def put?
+        @option_type == "P"
+      end
+
+      def closing_only?
+        @is_closing_only == true
+      end
+
+      def vanilla?
+        @is_vanilla == true
+      end
+
+      # Create an order leg for this future option
+      #
+      # @param action [String] Order action (from OrderAction module)
+      # @param quantity [Integer] Number of contracts
+      # @return [OrderLeg] Order leg for this future option
+      def build_leg(action:, quantity:)
+        OrderLeg.new(
+          action: action,
+          symbol: @symbol,
+          quantity: quantity,
+          instrument_type: INSTRUMENT_TYPE
+        )
+      end
+
+      private
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty?
+
+        BigDecimal(value.to_s)
+      end
+
+      def parse_date(value)
+        return nil if value.nil? || value.to_s.empty?
+
+        Date.parse(value.to_s)
+      rescue Date::Error
+        nil
+      end
+    end
+  end
+end
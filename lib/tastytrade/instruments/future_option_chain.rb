@@ -0,0 +1,78 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Instruments
+    # Nested option chain for a futures product, e.g. "ES"
+    #
+    # A product can list several option roots (standard, weekly and end-of-month
+    # contracts); each is a separate chain with expirations on different
+    # underlying futures. Strikes use the same shape as equity option chains.
+    #
+    # @example
+    #   chain = FutureOptionChain.get(session, "ES").first
+    #   expiration = chain.expirations.first
+    #   expiration.underlying_symbol  # => "/ESZ4"
+    #   expiration.strikes.first.call # => "./ESZ4 EW4X4 241129C5800"
+    class FutureOptionChain
+      attr_reader :underlying_symbol, :root_symbol, :exercise_style, :expirations
+
+      # An expiration of one option root on one underlying future
+      class Expiration
+        attr_reader :underlying_symbol, :root_symbol, :option_root_symbol, :option_contract_symbol,
+                    :asset, :expiration_date, :days_to_expiration, :expiration_type, :settlement_type,
+                    :notional_value, :display_factor, :strike_factor, :strikes
+
+        def initialize(data = {})
+          @underlying_symbol = data["underlying-symbol"]
+          @root_symbol = data["root-symbol"]
+          @option_root_symbol = data["option-root-symbol"]
+          @option_contract_symbol = data["option-contract-symbol"]
+          @asset = data["asset"]
+          @expiration_date = data["expiration-date"] ? Date.parse(data["expiration-date"]) : nil
+          @days_to_expiration = data["days-to-expiration"]
+          @expiration_type = data["expiration-type"]
+          @settlement_type = data["settlement-type"]
+          @notional_value = parse_decimal(data["notional-value"])
+          @display_factor = parse_decimal(data["display-factor"])
+          @strike_factor = parse_decimal(data["strike-factor"])
+          @strikes = (data["strikes"] || []).map { |strike| Models::NestedOptionChain::Strike.new(strike) }
+        end
+
+        private
+
+        def parse_decimal(value)
+          return nil if value.nil? || value.to_s.empty?
+
+          BigDecimal(value.to_s)
+        end
+      end
+
+      def initialize(data = {})
+        @underlying_symbol = data["underlying-symbol"]
+        @root_symbol = data["root-symbol"]
+        @exercise_style = data["exercise-style"]
+        @expirations = (data["expirations"] || []).map { |expiration| Expiration.new(expiration) }
+      end
+
+      # Get the nested option chains for a futures product
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param product_code [String] Futures product code, e.g. "ES"
+      # @return [Array<FutureOptionChain>] One chain per option root
+      def self.get(session, product_code)
+        response = session.get("/futures-option-chains/#{product_code.delete_prefix("/")}/nested")
+        (response.dig("data", "option-chains") || []).map { |chain| new(chain) }
+      end
+
+      # Returns all expiration dates in chronological order
+      #
+      # @return [Array<Date>] Sorted array of expiration dates
+      def expiration_dates
+        @expirations.map(&:expiration_date).compact.uniq.sort
+      end
+    end
+  end
+end
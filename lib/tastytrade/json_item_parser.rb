@@ -0,0 +1,109 @@
+# frozen_string_literal: true
+
+require "json"
+
+module Tastytrade
+  # Incremental parser for list responses that yields each element of
+  # data.items as soon as it has been read.
+  #
+  # Chunks of the response body are fed in as they arrive, so only the item
+  # being read is held in memory rather than the whole body and every parsed
+  # item. Used by {Client#get_items} for large responses such as a full
+  # option chain.
+  #
+  # @example
+  #   parser = JSONItemParser.new { |item| puts item["symbol"] }
+  #   parser << '{"data":{"items":[{"symbol":"SPY   240315C0'
+  #   parser << '0450000"}]}}'
+  class JSONItemParser
+    # Containers enclosing the items: the root object, "data" and "items"
+    ITEMS_PATH = [nil, "data", "items"].freeze
+
+    # @param object_class [Class] Class for parsed JSON objects
+    # @yieldparam item [Hash] Each parsed item
+    def initialize(object_class: Hash, &block)
+      raise ArgumentError, "A block is required" unless block
+
+      @object_class = object_class
+      @block = block
+      @path = []
+      @key = nil
+      @token = nil
+      @in_string = false
+      @escaped = false
+      @item = nil
+      @item_depth = 0
+    end
+
+    # Parses the next part of the body, yielding any items it completes
+    #
+    # @param chunk [String] Next part of the response body
+    # @return [self]
+    # @raise [Tastytrade::Error] if an item is not valid JSON
+    def <<(chunk)
+      chunk.each_char { |char| consume(char) }
+      self
+    end
+
+    private
+
+    def consume(char)
+      @item << char if @item
+      return consume_string(char) if @in_string
+
+      case char
+      when '"'
+        @in_string = true
+        @token = +"" unless @item
+      when ":" then @key = @token unless @item
+      when "," then @key = nil unless @item
+      when "{", "[" then open_container(char)
+      when "}", "]" then close_container
+      end
+    end
+
+    def consume_string(char)
+      if @escaped
+        @escaped = false
+      elsif char == "\\"
+        @escaped = true
+      elsif char == '"'
+        @in_string = false
+      elsif @token && !@item
+        @token << char
+      end
+    end
+
+    def open_container(char)
+      if @item
+        @item_depth += 1
+      elsif char == "{" && @path == ITEMS_PATH
+        @item = +"{"
+        @item_depth = 1
+      else
+        @path << @key
+        @key = nil
+      end
+    end
+
+    def close_container
+      unless @item
+        @path.pop
+        return
+      end
+
+      @item_depth -= 1
+      return unless @item_depth.zero?
+
+      item = @item
+      @item = nil
+      @block.call(parse(item))
+    end
+
+    def parse(item)
+      JSON.parse(item, object_class: @object_class)
+    rescue JSON::ParserError => e
+      raise Tastytrade::Error, "Invalid JSON response: #{e.message}"
+    end
+  end
+end
@@ -0,0 +1,254 @@
+# frozen_string_literal: true
+
+require "date"
+require "time"
+require_relative "order"
+
+module Tastytrade
+  # US equity market calendar and trading session helpers.
+  #
+  # All calculations are done in US/Eastern time. Daylight saving time is derived
+  # from the US rules (second Sunday in March through the first Sunday in November)
+  # so no timezone database is required. Holidays follow the NYSE schedule.
+  #
+  # @example Check whether the market is open
+  #   Tastytrade::MarketHours.regular_hours?(Time.now)
+  #
+  # @example Get guidance for an after-hours Day order
+  #   suggestion = Tastytrade::MarketHours.suggest_time_in_force(OrderType::LIMIT)
+  #   puts suggestion.warning if suggestion.warning
+  module MarketHours
+    PRE_MARKET_OPEN = [4, 0].freeze
+    REGULAR_OPEN = [9, 30].freeze
+    REGULAR_CLOSE = [16, 0].freeze
+    EARLY_CLOSE = [13, 0].freeze
+    AFTER_HOURS_CLOSE = [20, 0].freeze
+
+    # Trading sessions returned by {.session_for}
+    SESSIONS = %i[pre_market regular after_hours closed].freeze
+
+    # Suggested time in force with an optional explanation
+    Suggestion = Struct.new(:time_in_force, :warning, keyword_init: true) do
+      # @return [Boolean] true if the order needs the user's attention
+      def warning?
+        !warning.nil?
+      end
+    end
+
+    class << self
+      # Convert a time to US/Eastern
+      #
+      # @param time [Time] Time to convert
+      # @return [Time] The same instant with the Eastern UTC offset
+      def eastern_time(time = Time.now)
+        utc = time.getutc
+        utc.getlocal(dst?(utc) ? -4 * 3600 : -5 * 3600)
+      end
+
+      # Check if US daylight saving time is in effect at a given instant
+      #
+      # @param time [Time] Time to check
+      # @return [Boolean] true during daylight saving time
+      def dst?(time)
+        utc = time.getutc
+        year = utc.year
+        starts = Time.utc(year, 3, nth_weekday(year, 3, 0, 2), 7)
+        ends = Time.utc(year, 11, nth_weekday(year, 11, 0, 1), 6)
+        utc >= starts && utc < ends
+      end
+
+      # NYSE holidays for a year, adjusted to their observed dates
+      #
+      # @param year [Integer] Calendar year
+      # @return [Array<Date>] Sorted holiday dates
+      def holidays(year)
+        dates = [
+          new_years_day(year),
+          Date.new(year, 1, nth_weekday(year, 1, 1, 3)), # Martin Luther King Jr. Day
+          Date.new(year, 2, nth_weekday(year, 2, 1, 3)), # Washington's Birthday
+          easter(year) - 2, # Good Friday
+          Date.new(year, 5, last_weekday(year, 5, 1)), # Memorial Day
+          (observed(Date.new(year, 6, 19)) if year >= 2022), # Juneteenth
+          observed(Date.new(year, 7, 4)), # Independence Day
+          Date.new(year, 9, nth_weekday(year, 9, 1, 1)), # Labor Day
+          Date.new(year, 11, nth_weekday(year, 11, 4, 4)), # Thanksgiving
+          observed(Date.new(year, 12, 25)) # Christmas
+        ]
+
+        dates.compact.sort
+      end
+
+      # @param date [Date] Date to check
+      # @return [Boolean] true if the market is closed for a holiday
+      def holiday?(date)
+        holidays(date.year).include?(date)
+      end
+
+      # @param date [Date] Date to check
+      # @return [Boolean] true if the market closes early (1:00 PM ET)
+      def early_close?(date)
+        return false unless trading_day?(date)
+
+        thanksgiving = Date.new(date.year, 11, nth_weekday(date.year, 11, 4, 4))
+        date == thanksgiving + 1 ||
+          (date.month == 12 && date.day == 24) ||
+          (date.month == 7 && date.day == 3)
+      end
+
+      # @param date [Date] Date to check
+      # @return [Boolean] true if the market has a regular session on this date
+      def trading_day?(date)
+        !date.saturday? && !date.sunday? && !holiday?(date)
+      end
+
+      # Determine which trading session a time falls in
+      #
+      # @param time [Time] Time to check
+      # @return [Symbol] One of {SESSIONS}
+      def session_for(time = Time.now)
+        et = eastern_time(time)
+        date = et.to_date
+        return :closed unless trading_day?(date)
+
+        minutes = et.hour * 60 + et.min
+        close = early_close?(date) ? EARLY_CLOSE : REGULAR_CLOSE
+
+        if minutes < to_minutes(PRE_MARKET_OPEN)
+          :closed
+        elsif minutes < to_minutes(REGULAR_OPEN)
+          :pre_market
+        elsif minutes < to_minutes(close)
+          :regular
+        elsif minutes < to_minutes(AFTER_HOURS_CLOSE)
+          :after_hours
+        else
+          :closed
+        end
+      end
+
+      # @param time [Time] Time to check
+      # @return [Boolean] true during the regular trading session
+      def regular_hours?(time = Time.now)
+        session_for(time) == :regular
+      end
+
+      # @param time [Time] Time to check
+      # @return [Boolean] true during the pre-market or after-hours session
+      def extended_hours?(time = Time.now)
+        %i[pre_market after_hours].include?(session_for(time))
+      end
+
+      # Find the next regular session open strictly after the given time
+      #
+      # @param time [Time] Starting time
+      # @return [Time] Next open in US/Eastern
+      def next_open(time = Time.now)
+        et = eastern_time(time)
+        date = et.to_date
+
+        loop do
+          if trading_day?(date)
+            open = eastern_wall_clock(date, *REGULAR_OPEN)
+            return open if open > et
+          end
+          date += 1
+        end
+      end
+
+      # Suggest a time in force for an order given the current trading session.
+      #
+      # Day orders placed outside regular hours sit idle until the next open, which
+      # surprises many users. Outside regular hours this returns a warning and, where
+      # it helps, an alternative: "Ext" for limit orders during the extended session
+      # and "GTC" for limit orders while the market is closed. Market and stop orders
+      # cannot trade in the extended session, so they keep Day with a warning.
+      #
+      # @param order_type [String] Order type (from OrderType)
+      # @param time_in_force [String] Requested time in force
+      # @param now [Time] Time the order would be submitted
+      # @return [Suggestion] Suggested time in force and an optional warning
+      def suggest_time_in_force(order_type, time_in_force: OrderTimeInForce::DAY, now: Time.now)
+        return Suggestion.new(time_in_force: time_in_force) unless time_in_force == OrderTimeInForce::DAY
+
+        session = session_for(now)
+        return Suggestion.new(time_in_force: time_in_force) if session == :regular
+
+        opens_at = next_open(now).strftime("%Y-%m-%d %H:%M ET")
+        message = "Day orders placed outside regular trading hours will not execute until the market " \
+                  "opens at #{opens_at}"
+
+        if order_type == OrderType::LIMIT && session != :closed
+          Suggestion.new(time_in_force: OrderTimeInForce::EXT,
+                         warning: "#{message}. Use #{OrderTimeInForce::EXT} to trade in the extended session")
+        elsif order_type == OrderType::LIMIT
+          Suggestion.new(time_in_force: OrderTimeInForce::GTC,
+                         warning: "#{message}. Use #{OrderTimeInForce::GTC} to keep the order working " \
+                                  "past the next session")
+        else
+          Suggestion.new(time_in_force: time_in_force, warning: message)
+        end
+      end
+
+      private
+
+      def to_minutes(hour_minute)
+        hour_minute[0] * 60 + hour_minute[1]
+      end
+
+      def eastern_wall_clock(date, hour, minute)
+        offset = dst?(Time.utc(date.year, date.month, date.day, 12)) ? -4 * 3600 : -5 * 3600
+        Time.new(date.year, date.month, date.day, hour, minute, 0, offset)
+      end
+
+      # Day of month for the nth occurrence of a weekday (0 = Sunday)
+      def nth_weekday(year, month, wday, nth)
+        first = Date.new(year, month, 1)
+        1 + ((wday - first.wday) % 7) + (nth - 1) * 7
+      end
+
+      # Day of month for the last occurrence of a weekday (0 = Sunday)
+      def last_weekday(year, month, wday)
+        last = Date.new(year, month, -1)
+        last.day - ((last.wday - wday) % 7)
+      end
+
+      # Holidays on a Saturday are observed Friday, on a Sunday the following Monday
+      def observed(date)
+        if date.saturday?
+          date - 1
+        elsif date.sunday?
+          date + 1
+        else
+          date
+        end
+      end
+
+      # NYSE does not observe New Year's Day on the preceding Friday
+      def new_years_day(year)
+        date = Date.new(year, 1, 1)
+        return nil if date.saturday?
+
+        date.sunday? ? date + 1 : date
+      end
+
+      # Gregorian Easter (anonymous algorithm)
+      def easter(year)
+        a = year % 19
+        b = year / 100
+        c = year % 100
+        d = b / 4
+        e = b % 4
+        f = (b + 8) / 25
+        g = (b - f + 1) / 3
+        h = (19 * a + b - d - g + 15) % 30
+        i = c / 4
+        k = c % 4
+        l = (32 + 2 * e + 2 * i - h - k) % 7
+        m = (a + 11 * h + 22 * l) / 451
+        month = (h + l - 7 * m + 114) / 31
+        day = ((h + l - 7 * m + 114) % 31) + 1
+        Date.new(year, month, day)
+      end
+    end
+  end
+end
@@ -0,0 +1,51 @@
+# frozen_string_literal: true
+
+module Tastytrade
+  # Request metrics for monitoring, e.g. latency histograms and error counters.
+  #
+  # A metrics hook is any object responding to
+  # +observe_request(method, endpoint, status, duration)+. The client calls it
+  # once per request with the upper-case HTTP method, the endpoint as a
+  # template (see {.endpoint_template}), the response status (nil when the
+  # request timed out or could not connect) and the duration in seconds.
+  #
+  # @example Prometheus
+  #   class PrometheusHook
+  #     def initialize(registry)
+  #       @duration = registry.histogram(:tastytrade_request_duration_seconds,
+  #                                      docstring: "Request latency", labels: %i[method endpoint status])
+  #     end
+  #
+  #     def observe_request(method, endpoint, status, duration)
+  #       @duration.observe(duration, labels: { method: method, endpoint: endpoint, status: status.to_s })
+  #     end
+  #   end
+  #
+  #   session = Tastytrade::Session.new(username: "user", password: "pass",
+  #                                     metrics: PrometheusHook.new(Prometheus::Client.registry))
+  module Metrics
+    # Hook that discards every observation; the client's default
+    class NullHook
+      def observe_request(_method, _endpoint, _status, _duration); end
+    end
+
+    NULL_HOOK = NullHook.new.freeze
+
+    # Path segments kept as-is: lower-case words such as "orders" or "dry-run".
+    # Anything else, e.g. an account number, order ID or symbol, is a parameter.
+    STATIC_SEGMENT = /\A[a-z][a-z-]*\z/
+
+    # Replaces the variable parts of a path with "{id}" so endpoint labels have
+    # a bounded number of values
+    #
+    # @param path [String] Request path, with or without a query string
+    # @return [String] Path template
+    #
+    # @example
+    #   Metrics.endpoint_template("/accounts/5WT0001/orders/12345")  # => "/accounts/{id}/orders/{id}"
+    def self.endpoint_template(path)
+      segments = path.to_s.split("?", 2).first.split("/", -1)
+      segments.map { |segment| segment.empty? || segment.match?(STATIC_SEGMENT) ? segment : "{id}" }.join("/")
+    end
+  end
+end
@@ -1,16 +1,37 @@
 # frozen_string_literal: true
 
 require_relative "models/base"
+require_relative "models/pagination"
 require_relative "models/user"
+require_relative "models/customer"
 require_relative "models/account"
 require_relative "models/account_balance"
+require_relative "models/net_liq_snapshot"
+require_relative "models/balance_snapshot"
 require_relative "models/current_position"
+require_relative "models/portfolio_greeks"
+require_relative "models/marked_position"
 require_relative "models/order_response"
 require_relative "models/live_order"
+require_relative "models/execution"
+require_relative "models/order_detail"
+require_relative "models/complex_order"
+require_relative "models/complex_order_response"
 require_relative "models/order_status"
+require_relative "models/order_contingent_status"
 require_relative "models/transaction"
 require_relative "models/buying_power_effect"
+require_relative "models/fee_calculation"
 require_relative "models/trading_status"
+require_relative "models/margin_requirements"
 require_relative "models/option"
+require_relative "models/quote"
+require_relative "models/quote_token"
+require_relative "models/greeks"
+require_relative "models/option_quote"
 require_relative "models/option_chain"
 require_relative "models/nested_option_chain"
+require_relative "models/fundamentals"
+require_relative "models/symbol_search_result"
+require_relative "models/earnings_event"
+require_relative "models/dividend_event"
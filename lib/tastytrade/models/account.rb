@@ -4,34 +4,110 @@ module Tastytrade
   module Models
     # Represents a Tastytrade account
     class Account < Base
+      # Outcome of cancelling one order in {#cancel_all_orders}
+      CancelResult = Struct.new(:order, :error, keyword_init: true) do
+        # @return [String, Integer] ID of the order
+        def order_id
+          order.id
+        end
+
+        # @return [Boolean] true if the order was cancelled
+        def success?
+          error.nil?
+        end
+      end
+
+      # One leg of an order cancelled with {#cancel_order_with_result}
+      CancelledLeg = Struct.new(:symbol, :quantity, :filled_quantity, :cancelled_quantity, keyword_init: true)
+
+      # Outcome of {#cancel_order_with_result}: how much of each leg filled
+      # before the cancel and how much was cancelled
+      CancelledOrder = Struct.new(:order, :legs, keyword_init: true) do
+        # @return [Boolean] true if part of the order filled before it was cancelled
+        def partially_filled?
+          legs.any? { |leg| leg.filled_quantity.positive? }
+        end
+
+        # @return [Integer] Contracts or shares filled across all legs
+        def filled_quantity
+          legs.sum(&:filled_quantity)
+        end
+
+        # @return [Integer] Contracts or shares cancelled across all legs
+        def cancelled_quantity
+          legs.sum(&:cancelled_quantity)
+        end
+      end
+
+      # Outcome of {#replace_order_with_result}
+      #
+      # match is :response when the PUT response carried the new order ID,
+      # :live_orders when it was inferred from live orders, or nil when no
+      # replacement order was identified.
+      ReplaceResult = Struct.new(:order_id, :response, :match, keyword_init: true) do
+        # @return [Boolean] true if the API reported the new order ID
+        def confident?
+          match == :response
+        end
+
+        # @return [Boolean] true if the new order ID was inferred from live orders
+        def best_guess?
+          match == :live_orders
+        end
+      end
+
       attr_reader :account_number, :nickname, :account_type_name,
                   :opened_at, :is_closed, :day_trader_status,
                   :is_futures_approved, :margin_or_cash, :is_foreign,
                   :created_at, :external_id, :closed_at, :funding_date,
                   :investment_objective, :suitable_options_level,
-                  :is_test_drive
+                  :is_test_drive, :authority_level
 
       class << self
-        # Get all accounts for the authenticated user
+        # Get all accounts the authenticated user can access
+        #
+        # Each account keeps the user's authority level on it, e.g. "owner" or
+        # "trade-only" for an account managed on someone else's behalf.
         #
         # @param session [Tastytrade::Session] Active session
         # @param include_closed [Boolean] Include closed accounts
         # @return [Array<Account>] List of accounts
+        #
+        # @example
+        #   Account.get_all(session).map { |account| [account.account_number, account.authority_level] }
         def get_all(session, include_closed: false)
           params = include_closed ? { "include-closed" => true } : {}
           response = session.get("/customers/me/accounts/", params)
-          response["data"]["items"].map { |item| new(item["account"]) }
+          response["data"]["items"].map do |item|
+            new(item["account"].merge("authority-level" => item["authority-level"]))
+          end
         end
 
         # Get a specific account by account number
         #
         # @param session [Tastytrade::Session] Active session
-        # @param account_number [String] Account number
+        # @param account_number [String, nil] Account number, defaults to the session's default
+        #   account set with {Session#with_order_defaults}
         # @return [Account] Account instance
-        def get(session, account_number)
+        # @raise [ArgumentError] if no account number is given and the session has no default
+        #
+        # @example The default account
+        #   session.with_order_defaults(account_number: "5WV12345")
+        #   account = Account.get(session)
+        def get(session, account_number = nil)
+          account_number ||= default_account_number(session)
           response = session.get("/accounts/#{account_number}/")
           new(response["data"])
         end
+
+        private
+
+        def default_account_number(session)
+          defaults = session.order_defaults if session.respond_to?(:order_defaults)
+          return defaults.resolve_account_number if defaults
+
+          raise ArgumentError, "No account number given and no default account number configured"
+        end
       end
 
       # Get account balances
@@ -43,21 +119,133 @@ module Tastytrade
         AccountBalance.new(response["data"])
       end
 
+      # Get the net liquidating value history, e.g. for an equity curve
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param time_back [String] Period to fetch: 1d, 1m, 3m, 6m, 1y or all
+      # @return [Array<NetLiqSnapshot>] Snapshots, oldest first
+      # @raise [ArgumentError] if time_back is not an accepted value
+      def get_net_liquidating_value_history(session, time_back: "1y")
+        NetLiqSnapshot.get_history(session, account_number, time_back: time_back)
+      end
+
+      # Get beginning- and end-of-day balance snapshots, e.g. for daily P&L reconciliation
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param snapshot_date [Date, String, nil] Day to fetch
+      # @param time_of_day [String, nil] "BOD" or "EOD"
+      # @return [Array<BalanceSnapshot>] Snapshots, oldest first
+      # @raise [ArgumentError] if time_of_day is not an accepted value
+      def get_balance_snapshots(session, snapshot_date: nil, time_of_day: nil)
+        BalanceSnapshot.get_all(session, account_number, snapshot_date: snapshot_date, time_of_day: time_of_day)
+      end
+
       # Get current positions
       #
+      # Filters are applied by the API. Arrays are sent as repeated parameters,
+      # e.g. symbol[]=AAPL&symbol[]=MSFT. The API takes a single instrument type,
+      # so several instrument types are filtered after fetching.
+      #
       # @param session [Tastytrade::Session] Active session
-      # @param symbol [String, nil] Filter by symbol
-      # @param underlying_symbol [String, nil] Filter by underlying symbol
+      # @param symbol [String, Array<String>, nil] Filter by symbol
+      # @param underlying_symbol [String, Array<String>, nil] Filter by underlying symbol
+      # @param instrument_type [String, Array<String>, nil] Filter by instrument type, e.g. "Equity Option"
       # @param include_closed [Boolean] Include closed positions
       # @return [Array<CurrentPosition>] Position objects
-      def get_positions(session, symbol: nil, underlying_symbol: nil, include_closed: false)
+      #
+      # @example Option positions on two underlyings
+      #   account.get_positions(session, underlying_symbol: %w[SPY QQQ], instrument_type: "Equity Option")
+      def get_positions(session, symbol: nil, underlying_symbol: nil, instrument_type: nil, include_closed: false)
+        instrument_types = Array(instrument_type)
         params = {}
-        params["symbol"] = symbol if symbol
-        params["underlying-symbol"] = underlying_symbol if underlying_symbol
+        params["symbol"] = position_filter_value(symbol) if symbol
+        params["underlying-symbol"] = position_filter_value(underlying_symbol) if underlying_symbol
+        params["instrument-type"] = instrument_types.first if instrument_types.size == 1
         params["include-closed"] = include_closed if include_closed
 
         response = session.get("/accounts/#{account_number}/positions/", params)
-        response["data"]["items"].map { |item| CurrentPosition.new(item) }
+        positions = response["data"]["items"].map { |item| CurrentPosition.new(item) }
+        return positions if instrument_types.size <= 1
+
+        positions.select { |position| instrument_types.include?(position.instrument_type) }
+      end
+
+      # Get futures and futures option positions
+      #
+      # Values such as {CurrentPosition#unrealized_pnl} use each contract's
+      # multiplier, e.g. 50 for /ES and 5 for /MES.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String, Array<String>, nil] Filter by symbol, e.g. "/ESZ3"
+      # @param underlying_symbol [String, Array<String>, nil] Filter by underlying symbol
+      # @param include_closed [Boolean] Include closed positions
+      # @return [Array<CurrentPosition>] Futures and futures option positions
+      def get_futures_positions(session, symbol: nil, underlying_symbol: nil, include_closed: false)
+        get_positions(session, symbol: symbol, underlying_symbol: underlying_symbol,
+                               instrument_type: CurrentPosition::FUTURES_INSTRUMENT_TYPES,
+                               include_closed: include_closed)
+      end
+
+      # Get option positions grouped by expiration date
+      #
+      # Expirations are read from each position's OCC symbol, falling back to
+      # its expires-at time. Equity positions are grouped under :equity; other
+      # instrument types are left out.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param filters [Hash] Filters for {#get_positions}
+      # @return [Hash{Date, Symbol => Array<CurrentPosition>}] Positions by expiration, earliest first,
+      #   then :equity
+      #
+      # @example Contracts expiring this week
+      #   account.get_positions_by_expiration(session).select { |date, _| date.is_a?(Date) && date <= Date.today + 7 }
+      def get_positions_by_expiration(session, **filters)
+        positions = get_positions(session, **filters)
+        by_expiration = positions.select(&:option?).group_by { |position| position_expiration(position) }
+        by_expiration.delete(nil)
+        grouped = by_expiration.sort_by(&:first).to_h
+
+        equities = positions.select(&:equity?)
+        grouped[:equity] = equities unless equities.empty?
+        grouped
+      end
+
+      # Get open positions valued at current quotes
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param filters [Hash] Filters for {#get_positions}
+      # @return [Array<MarkedPosition>] Positions with market value and unrealized P&L
+      #
+      # @example Unrealized P&L across the account
+      #   account.get_marked_positions(session).sum { |position| position.unrealized_pnl || 0 }
+      def get_marked_positions(session, **filters)
+        MarkedPosition.mark(session, get_positions(session, **filters))
+      end
+
+      # Get net delta, gamma, theta and vega across all positions
+      #
+      # Option greeks are looked up per contract. By default they come from the
+      # option instruments endpoint; pass a greeks_source to supply them from
+      # elsewhere, such as a market data stream.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param greeks_source [#call, nil] Callable taking option symbols and returning
+      #   a Hash of symbol => object responding to delta, gamma, theta and vega
+      # @param include_equities [Boolean] Count share delta from equity positions
+      # @return [PortfolioGreeks] Aggregated greeks
+      def get_portfolio_greeks(session, greeks_source: nil, include_equities: true)
+        positions = get_positions(session)
+        option_symbols = positions.select(&:option?).map(&:symbol)
+
+        greeks = if option_symbols.empty?
+                   {}
+                 elsif greeks_source
+                   greeks_source.call(option_symbols)
+                 else
+                   Option.get(session, option_symbols).to_h { |option| [option.symbol, option] }
+        end
+
+        PortfolioGreeks.calculate(positions, greeks, include_equities: include_equities)
       end
 
       # Get trading status
@@ -65,18 +253,31 @@ module Tastytrade
       # @param session [Tastytrade::Session] Active session
       # @return [Tastytrade::Models::TradingStatus] Trading status object
       def get_trading_status(session)
-        response = session.get("/accounts/#{account_number}/trading-status/")
-        TradingStatus.new(response["data"])
+        TradingStatus.get(session, account_number)
+      end
+
+      # Get the margin requirement breakdown by underlying
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @return [MarginRequirements] Margin report
+      def get_margin_requirements(session)
+        MarginRequirements.get(session, account_number)
       end
 
       # Places an order for this account with comprehensive validation.
       # By default, performs full validation including symbol checks, quantity limits,
       # price validation, account permissions, and buying power verification.
+      # Dry runs skip the checks that call the API but still check the order's
+      # own fields with {Order#validate_structure!}.
+      # An order without a source is tagged with the session's default source,
+      # if one is set with {Session#with_order_defaults}.
       #
       # @param session [Tastytrade::Session] Active session
       # @param order [Tastytrade::Order] Order to place
       # @param dry_run [Boolean] Whether to simulate the order without placing it
       # @param skip_validation [Boolean] Skip pre-submission validation (use with caution)
+      # @param client_order_id [String, nil] Caller-chosen ID sent as ext-client-order-id, overriding
+      #   the order's own; reuse it when retrying a submission so the order is not placed twice
       # @return [OrderResponse] Response from order placement with order ID and status
       # @raise [OrderValidationError] if validation fails with detailed error messages
       # @raise [InsufficientFundsError] if account lacks buying power
@@ -92,20 +293,172 @@ module Tastytrade
       #
       # @example Skip validation when certain order is valid
       #   response = account.place_order(session, order, skip_validation: true)
-      def place_order(session, order, dry_run: false, skip_validation: false)
-        # Validate the order unless explicitly skipped or it's a dry-run
-        unless skip_validation || dry_run
-          validator = OrderValidator.new(session, self, order)
-          validator.validate!
+      #
+      # @example Retry safely after a timeout
+      #   id = SecureRandom.uuid
+      #   begin
+      #     account.place_order(session, order, client_order_id: id)
+      #   rescue Tastytrade::NetworkTimeoutError
+      #     retry
+      #   end
+      def place_order(session, order, dry_run: false, skip_validation: false, client_order_id: nil)
+        # Validate the order unless explicitly skipped; a dry run only needs the offline checks
+        unless skip_validation
+          dry_run ? order.validate_structure! : OrderValidator.new(session, self, order).validate!
         end
 
         endpoint = "/accounts/#{account_number}/orders"
         endpoint += "/dry-run" if dry_run
 
-        response = session.post(endpoint, order.to_api_params)
+        params = order.to_api_params
+        params["ext-client-order-id"] = client_order_id if client_order_id
+        source = default_order_source(session, params["source"])
+        params["source"] = source if source
+        response = session.post(endpoint, params)
         OrderResponse.new(response["data"])
       end
 
+      # Dry-runs an order and submits it only if the dry run is clean
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order [Tastytrade::Order] Order to place
+      # @param allow_warnings [Boolean] Submit even if the dry run returns warnings
+      # @param skip_validation [Boolean] Skip pre-submission validation
+      # @return [OrderResponse] Response from order placement
+      # @raise [OrderValidationError] if the dry run returns errors
+      # @raise [OrderWarningsError] if the dry run returns warnings and allow_warnings is false;
+      #   the error carries the warnings for display
+      #
+      # @example
+      #   begin
+      #     account.place_order_checked(session, order)
+      #   rescue Tastytrade::OrderWarningsError => e
+      #     e.warnings.each { |warning| puts warning["message"] }
+      #   end
+      def place_order_checked(session, order, allow_warnings: false, skip_validation: false)
+        dry_run_response = place_order(session, order, dry_run: true)
+
+        if dry_run_response.errors.any?
+          raise OrderValidationError, dry_run_response.errors.map { |e| e.is_a?(Hash) ? e["message"] || e["code"] : e }
+        end
+        raise OrderWarningsError, dry_run_response if dry_run_response.warnings.any? && !allow_warnings
+
+        place_order(session, order, skip_validation: skip_validation)
+      end
+
+      # Closes a position with an offsetting order for its full quantity
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param position [CurrentPosition] Position to close
+      # @param order_type [String, nil] OrderType constant (default: limit when priced, otherwise market)
+      # @param price [BigDecimal, Numeric, nil] Limit price
+      # @param time_in_force [String, nil] OrderTimeInForce constant, defaults to the session's
+      #   default time in force or day
+      # @param options [Hash] Passed to {#place_order}, e.g. dry_run:
+      # @return [OrderResponse] Response from order placement
+      # @raise [ArgumentError] if the position is already closed
+      #
+      # @example
+      #   position = account.get_positions(session, symbol: "AAPL").first
+      #   account.close_position(session, position, price: 190.50)
+      def close_position(session, position, order_type: nil, price: nil, time_in_force: nil, **options)
+        order = position.closing_order(order_type: order_type, price: price,
+                                       time_in_force: default_time_in_force(session, time_in_force))
+        place_order(session, order, **options)
+      end
+
+      # Places a single-leg equity market order
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity symbol
+      # @param quantity [Integer] Number of shares
+      # @param action [String] OrderAction constant
+      # @param time_in_force [String, nil] OrderTimeInForce constant, defaults to the session's
+      #   default time in force or day
+      # @param options [Hash] Passed to {#place_order}, e.g. dry_run:
+      # @return [OrderResponse] Response from order placement
+      #
+      # @example
+      #   account.place_equity_market_order(session, "AAPL", 10, Tastytrade::OrderAction::BUY_TO_OPEN)
+      def place_equity_market_order(session, symbol, quantity, action, time_in_force: nil, **options)
+        leg = OrderLeg.new(action: action, symbol: symbol, quantity: quantity)
+        order = Order.new(type: OrderType::MARKET, time_in_force: default_time_in_force(session, time_in_force),
+                          legs: leg)
+        place_order(session, order, **options)
+      end
+
+      # Places a single-leg limit order that fills immediately or is cancelled
+      #
+      # With IOC any quantity available at the limit fills and the rest is
+      # cancelled; with FOK the whole quantity fills or nothing does. The order
+      # is finished once the response arrives, so check the response's status
+      # and legs, or {#get_order}, to see whether it filled in full, in part or
+      # not at all.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Symbol to trade
+      # @param quantity [Integer] Number of shares or contracts
+      # @param price [BigDecimal, Numeric, String] Limit price, e.g. at or through the opposite side of the market
+      # @param action [String] OrderAction constant
+      # @param time_in_force [String] OrderTimeInForce::IOC or OrderTimeInForce::FOK
+      # @param instrument_type [String] Leg instrument type, e.g. "Option"
+      # @param options [Hash] Passed to {#place_order}, e.g. dry_run:
+      # @return [OrderResponse] Response from order placement
+      # @raise [ArgumentError] if time_in_force is not IOC or FOK
+      #
+      # @example Buy up to 100 shares at 190.55 or better, cancelling the rest
+      #   response = account.place_marketable_limit_order(session, "AAPL", 100, "190.55",
+      #                                                   Tastytrade::OrderAction::BUY_TO_OPEN)
+      def place_marketable_limit_order(session, symbol, quantity, price, action,
+                                       time_in_force: OrderTimeInForce::IOC, instrument_type: "Equity", **options)
+        unless OrderTimeInForce::IMMEDIATE.include?(time_in_force)
+          raise ArgumentError, "Time in force must be one of: #{OrderTimeInForce::IMMEDIATE.join(", ")}"
+        end
+
+        leg = OrderLeg.new(action: action, symbol: symbol, quantity: quantity, instrument_type: instrument_type)
+        order = Order.new(type: OrderType::LIMIT, time_in_force: time_in_force, legs: leg, price: price)
+        place_order(session, order, **options)
+      end
+
+      # Places an equity market order for a dollar amount, which may buy
+      # fractional shares
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity symbol
+      # @param value [BigDecimal, Numeric, String] Dollar amount to buy or sell
+      # @param action [String] OrderAction constant
+      # @param time_in_force [String, nil] OrderTimeInForce constant, defaults to the session's
+      #   default time in force or day
+      # @param options [Hash] Passed to {#place_order}, e.g. dry_run:
+      # @return [OrderResponse] Response from order placement
+      #
+      # @example Buy $250 of AAPL
+      #   account.place_equity_notional_market_order(session, "AAPL", 250, Tastytrade::OrderAction::BUY_TO_OPEN)
+      def place_equity_notional_market_order(session, symbol, value, action, time_in_force: nil, **options)
+        leg = OrderLeg.new(action: action, symbol: symbol, quantity: nil)
+        order = Order.new(type: OrderType::MARKET, time_in_force: default_time_in_force(session, time_in_force),
+                          legs: leg, value: value)
+        place_order(session, order, **options)
+      end
+
+      # Schedule an order to be placed at a later time
+      #
+      # Scheduling happens client-side in a background thread and only lasts as
+      # long as the current process. See {Tastytrade::ScheduledOrder}.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order [Tastytrade::Order] Order to place
+      # @param at [Time, Symbol] Submission time, or :open for the next regular session open
+      # @param skip_validation [Boolean] Skip pre-submission validation when placing
+      # @return [Tastytrade::ScheduledOrder] Cancellable handle for the pending order
+      def schedule_order(session, order, at:, skip_validation: false)
+        scheduled_at = ScheduledOrder.resolve_time(at)
+
+        ScheduledOrder.new(order: order, scheduled_at: scheduled_at) do |pending|
+          place_order(session, pending, skip_validation: skip_validation)
+        end.start
+      end
+
       # Get transaction history
       #
       # @param session [Tastytrade::Session] Active session
@@ -116,13 +469,22 @@ module Tastytrade
       # @option options [String] :underlying_symbol Filter by underlying symbol
       # @option options [String] :instrument_type Filter by instrument type
       # @option options [Array<String>] :transaction_types Filter by transaction types
-      # @option options [Integer] :per_page Number of results per page (default: 250)
+      # @option options [Integer] :per_page Number of results per page
       # @option options [Integer] :page_offset Page offset for pagination
       # @return [Array<Transaction>] Array of transactions
       def get_transactions(session, **options)
         Transaction.get_all(session, account_number, **options)
       end
 
+      # Get a single page of transaction history with its pagination metadata
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param options [Hash] Same filters as {#get_transactions}
+      # @return [Array(Array<Transaction>, Pagination)] Transactions and pagination metadata
+      def get_transactions_page(session, **options)
+        Transaction.get_page(session, account_number, **options)
+      end
+
       # Get live orders (open and orders from last 24 hours)
       #
       # @param session [Tastytrade::Session] Active session
@@ -145,25 +507,165 @@ module Tastytrade
       # Get order history for this account (beyond 24 hours)
       #
       # @param session [Tastytrade::Session] Active session
-      # @param status [String, nil] Filter by order status
+      # @param status [String, Array<String>, nil] Filter by one or more order statuses;
+      #   values that are not an {OrderStatus} are ignored
       # @param underlying_symbol [String, nil] Filter by underlying symbol
+      # @param underlying_instrument_type [String, nil] Filter by underlying instrument type, e.g. "Equity"
       # @param from_time [Time, nil] Start time for order history
       # @param to_time [Time, nil] End time for order history
+      # @param start_date [Date, String, nil] First day of order history, e.g. "2024-01-02"
+      # @param end_date [Date, String, nil] Last day of order history
       # @param page_offset [Integer, nil] Pagination offset
       # @param page_limit [Integer, nil] Number of results per page (default 250, max 1000)
       # @return [Array<LiveOrder>] Array of historical orders
-      def get_order_history(session, status: nil, underlying_symbol: nil, from_time: nil, to_time: nil,
-                           page_offset: nil, page_limit: nil)
+      #
+      # @example Filled or cancelled SPY orders in January
+      #   account.get_order_history(session, status: %w[Filled Cancelled], underlying_symbol: "SPY",
+      #                                      start_date: Date.new(2024, 1, 1), end_date: Date.new(2024, 1, 31))
+      def get_order_history(session, **filters)
+        get_order_history_page(session, **filters).first
+      end
+
+      # Get one page of order history with its pagination metadata
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param filters [Hash] Same filters as {#get_order_history}
+      # @return [Array(Array<LiveOrder>, Pagination)] Orders and pagination metadata
+      #   (nil when the response has none)
+      def get_order_history_page(session, status: nil, underlying_symbol: nil, underlying_instrument_type: nil,
+                                 from_time: nil, to_time: nil, start_date: nil, end_date: nil, page_offset: nil,
+                                 page_limit: nil)
         params = {}
-        params["status"] = status if status && OrderStatus.valid?(status)
+        add_status_filter(params, status)
         params["underlying-symbol"] = underlying_symbol if underlying_symbol
+        params["underlying-instrument-type"] = underlying_instrument_type if underlying_instrument_type
         params["from-time"] = from_time.iso8601 if from_time
         params["to-time"] = to_time.iso8601 if to_time
+        params["start-date"] = start_date.to_s if start_date
+        params["end-date"] = end_date.to_s if end_date
         params["page-offset"] = page_offset if page_offset
         params["page-limit"] = page_limit if page_limit
 
         response = session.get("/accounts/#{account_number}/orders/", params)
-        response["data"]["items"].map { |item| LiveOrder.new(item) }
+        orders = response["data"]["items"].map { |item| LiveOrder.new(item) }
+        pagination = response["pagination"] ? Pagination.new(response["pagination"]) : nil
+
+        [orders, pagination]
+      end
+
+      # Iterate over the full order history, fetching pages as needed
+      #
+      # Pages are requested lazily, so breaking out early skips the rest.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param filters [Hash] Same filters as {#get_order_history}, except page_offset
+      # @yieldparam order [LiveOrder] Each order
+      # @return [Enumerator<LiveOrder>] if no block is given
+      #
+      # @example
+      #   account.each_order_history(session, status: "Filled").first(500)
+      def each_order_history(session, **filters)
+        return enum_for(:each_order_history, session, **filters) unless block_given?
+
+        page_offset = 0
+        loop do
+          orders, pagination = get_order_history_page(session, **filters, page_offset: page_offset)
+          orders.each { |order| yield order }
+          break if orders.empty? || pagination.nil? || !pagination.next_page?
+
+          page_offset = pagination.next_page_offset
+        end
+      end
+
+      # Get today's executions flattened from their orders, oldest first
+      #
+      # "Today" starts at midnight US/Eastern. Every page of the day's orders
+      # is read. Futures fills are costed with the multipliers of the account's
+      # positions in them, including positions closed today.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param now [Time] Reference time
+      # @return [Array<Execution>] One entry per fill with symbol, side, quantity, price and cost
+      #
+      # @example
+      #   account.get_todays_fills(session).each do |fill|
+      #     puts "#{fill.filled_at} #{fill.side} #{fill.quantity} #{fill.symbol} @ #{fill.price}"
+      #   end
+      def get_todays_fills(session, now: Time.now)
+        eastern = MarketHours.eastern_time(now)
+        start_of_day = Time.new(eastern.year, eastern.month, eastern.day, 0, 0, 0, eastern.utc_offset)
+
+        orders = each_order_history(session, from_time: start_of_day).to_a
+        multipliers = futures_multipliers(session, orders)
+        orders.flat_map { |order| Execution.from_order(order, multipliers: multipliers) }
+              .select { |execution| execution.filled_at.nil? || execution.filled_at >= start_of_day }
+              .sort_by { |execution| execution.filled_at || start_of_day }
+      end
+
+      # Get complex order history (OTO, OCO, OTOCO) with child orders populated
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param status [String, nil] Filter by order status
+      # @param underlying_symbol [String, nil] Filter by underlying symbol
+      # @param from_time [Time, nil] Start time for order history
+      # @param to_time [Time, nil] End time for order history
+      # @param page_offset [Integer, nil] Fetch only this page (all pages when nil)
+      # @param page_limit [Integer, nil] Number of results per page
+      # @return [Array<ComplexOrder>] Array of historical complex orders
+      def get_complex_order_history(session, **options)
+        ComplexOrder.get_history(session, account_number, **options)
+      end
+
+      # Get working complex orders (OTO, OCO, OTOCO) with child orders populated
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @return [Array<ComplexOrder>] Live complex orders
+      def get_live_complex_orders(session)
+        ComplexOrder.get_live(session, account_number)
+      end
+
+      # Place a complex order (OTO, OCO or OTOCO)
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param complex_order [Tastytrade::ComplexOrderRequest] Complex order to place
+      # @param dry_run [Boolean] Simulate the order to see its combined buying power effect,
+      #   fees and warnings without placing it
+      # @return [ComplexOrderResponse] Response with the placed complex order
+      #
+      # @example Check a bracket before placing it
+      #   response = account.place_complex_order(session, bracket, dry_run: true)
+      #   puts response.buying_power_effect.change_in_buying_power.to_s("F")
+      #   puts response.fee_calculation.total.to_s("F")
+      def place_complex_order(session, complex_order, dry_run: false)
+        endpoint = "/accounts/#{account_number}/complex-orders"
+        endpoint += "/dry-run" if dry_run
+
+        response = session.post(endpoint, complex_order.to_api_params)
+        ComplexOrderResponse.new(response["data"])
+      end
+
+      # Get a specific complex order by ID
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param complex_order_id [String, Integer] Complex order ID to retrieve
+      # @return [ComplexOrder] The requested complex order
+      def get_complex_order(session, complex_order_id)
+        response = session.get("/accounts/#{account_number}/complex-orders/#{complex_order_id}/")
+        ComplexOrder.new(response["data"])
+      end
+
+      # Cancel a complex order and all of its working orders
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param complex_order_id [String, Integer] Complex order ID to cancel
+      # @return [nil]
+      # @raise [OrderNotCancellableError] if the complex order cannot be cancelled
+      # @raise [OrderAlreadyFilledError] if the complex order has already filled
+      def cancel_complex_order(session, complex_order_id)
+        session.delete("/accounts/#{account_number}/complex-orders/#{complex_order_id}/")
+        nil
+      rescue Tastytrade::Error => e
+        handle_cancel_error(e)
       end
 
       # Get a specific order by ID
@@ -176,6 +678,121 @@ module Tastytrade
         LiveOrder.new(response["data"])
       end
 
+      # Find an order by the number the exchange assigned it
+      #
+      # Live orders are searched first, then the order history going back the
+      # given number of days.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param ext_exchange_order_number [String] Exchange order number, e.g. from a fill report
+      # @param days [Integer] Days of order history to search
+      # @return [LiveOrder, nil] The order, or nil if none matches
+      #
+      # @example
+      #   order = account.find_order_by_ext_number(session, "12271026815307")
+      def find_order_by_ext_number(session, ext_exchange_order_number, days: 7)
+        matches = ->(order) { order.ext_exchange_order_number == ext_exchange_order_number }
+
+        get_live_orders(session).find(&matches) ||
+          each_order_history(session, start_date: Date.today - days).find(&matches)
+      end
+
+      # Get an order with its net execution price from the leg fills
+      #
+      # Fees are read from the order's trade transactions, which are looked up
+      # from the day the order was created. Futures legs use the multipliers of
+      # the account's positions in them to spread the fees.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to retrieve
+      # @param include_fees [Boolean] Fetch the fees charged for the order
+      # @return [OrderDetail] Order with derived prices
+      #
+      # @example
+      #   detail = account.get_order_detail(session, "12345", include_fees: true)
+      #   puts "Filled at #{detail.net_execution_price}, #{detail.effective_price} after fees"
+      def get_order_detail(session, order_id, include_fees: false)
+        order = get_order(session, order_id)
+        return OrderDetail.new(order) unless include_fees
+
+        OrderDetail.new(order, fee_calculation: order_fee_calculation(session, order),
+                               multipliers: futures_multipliers(session, [order]))
+      end
+
+      # Poll an order and yield each genuine update until it reaches a terminal state
+      #
+      # Changes are detected through the order's updated-at timestamp, so identical
+      # polls do not trigger the block again. Orders without a timestamp fall back to
+      # comparing their serialized state.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to watch
+      # @param interval [Numeric] Seconds between polls
+      # @param timeout [Numeric, nil] Stop watching after this many seconds
+      # @yieldparam order [LiveOrder] Order after each change
+      # @return [LiveOrder] Last order state retrieved
+      #
+      # @example
+      #   account.watch_order(session, "12345") { |order| puts order.status }
+      def watch_order(session, order_id, interval: 1, timeout: nil)
+        raise ArgumentError, "A block is required to watch an order" unless block_given?
+
+        deadline = timeout && (Process.clock_gettime(Process::CLOCK_MONOTONIC) + timeout)
+        last_change = nil
+
+        loop do
+          order = get_order(session, order_id)
+          change = order.updated_at || order.to_h
+
+          unless change == last_change
+            last_change = change
+            yield order
+          end
+
+          return order if order.terminal?
+          return order if deadline && Process.clock_gettime(Process::CLOCK_MONOTONIC) >= deadline
+
+          sleep(interval)
+        end
+      end
+
+      # Poll an order until it fills, is cancelled, rejected or expires
+      #
+      # The delay between polls starts at interval and doubles after each poll
+      # up to max_interval, so a quick fill is seen quickly without polling a
+      # resting order every second.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to wait for
+      # @param interval [Numeric] Seconds before the second poll
+      # @param max_interval [Numeric] Longest delay between polls
+      # @param timeout [Numeric, nil] Stop waiting after this many seconds
+      # @return [LiveOrder] Terminal order, or the last state retrieved if the timeout passed first
+      #
+      # @example
+      #   response = account.place_order(session, order)
+      #   order = account.wait_for_fill(session, response.order_id, timeout: 60)
+      #   puts order.filled? ? "Filled" : "Ended as #{order.status}"
+      def wait_for_fill(session, order_id, interval: 1, max_interval: 10, timeout: nil)
+        deadline = timeout && (Process.clock_gettime(Process::CLOCK_MONOTONIC) + timeout)
+        delay = interval
+
+        loop do
+          order = get_order(session, order_id)
+          return order if order.terminal?
+
+          if deadline
+            remaining = deadline - Process.clock_gettime(Process::CLOCK_MONOTONIC)
+            return order unless remaining.positive?
+
+            delay = [delay, remaining].min
+          end
+
+          sleep(delay)
+          delay = [delay * 2, max_interval].min
+        end
+      end
+
       # Cancel an order
       #
       # @param session [Tastytrade::Session] Active session
@@ -184,10 +801,62 @@ module Tastytrade
       # @raise [OrderNotCancellableError] if order cannot be cancelled
       # @raise [OrderAlreadyFilledError] if order has already been filled
       def cancel_order(session, order_id)
-        session.delete("/accounts/#{account_number}/orders/#{order_id}/")
+        delete_order(session, order_id)
         nil
-      rescue Tastytrade::Error => e
-        handle_cancel_error(e)
+      end
+
+      # Cancel an order and report what filled before the cancel
+      #
+      # Quantities come from the order as the cancel response returns it, or
+      # as fetched afterwards when the response has no order. A leg's filled
+      # quantity is the sum of its fills, falling back to its quantity less
+      # the remaining quantity.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to cancel
+      # @return [CancelledOrder] Cancelled order with filled and cancelled quantities per leg
+      # @raise [OrderNotCancellableError] if order cannot be cancelled
+      # @raise [OrderAlreadyFilledError] if order has already been filled
+      #
+      # @example
+      #   result = account.cancel_order_with_result(session, "12345")
+      #   puts "#{result.filled_quantity} filled, #{result.cancelled_quantity} cancelled"
+      def cancel_order_with_result(session, order_id)
+        response = delete_order(session, order_id)
+        data = response["data"] if response.is_a?(Hash)
+        order = data ? LiveOrder.new(data) : get_order(session, order_id)
+
+        legs = order.legs.map do |leg|
+          filled = leg.fills.any? ? leg.fills.sum { |fill| fill.quantity.to_i } : leg.filled_quantity
+          CancelledLeg.new(symbol: leg.symbol, quantity: leg.quantity, filled_quantity: filled,
+                           cancelled_quantity: leg.quantity.to_i - filled)
+        end
+        CancelledOrder.new(order: order, legs: legs)
+      end
+
+      # Cancel every cancellable live order, e.g. to close out at the end of the day
+      #
+      # Each order is cancelled separately; a failure is recorded in its result
+      # and does not stop the remaining cancellations.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param underlying_symbol [String, nil] Only cancel orders for this underlying
+      # @yieldparam order [LiveOrder] Cancellable order, return true to cancel it
+      # @return [Array<CancelResult>] One result per order a cancel was attempted for
+      #
+      # @example Cancel only SPY orders and report failures
+      #   results = account.cancel_all_orders(session) { |order| order.underlying_symbol == "SPY" }
+      #   results.reject(&:success?).each { |r| puts "#{r.order_id}: #{r.error.message}" }
+      def cancel_all_orders(session, underlying_symbol: nil)
+        orders = get_live_orders(session, underlying_symbol: underlying_symbol).select(&:cancellable?)
+        orders = orders.select { |order| yield order } if block_given?
+
+        orders.map do |order|
+          cancel_order(session, order.id)
+          CancelResult.new(order: order, error: nil)
+        rescue Tastytrade::Error => e
+          CancelResult.new(order: order, error: e)
+        end
       end
 
       # Replace an existing order
@@ -201,11 +870,64 @@ module Tastytrade
       def replace_order(session, order_id, new_order)
         response = session.put("/accounts/#{account_number}/orders/#{order_id}/",
                                 new_order.to_api_params)
-        OrderResponse.new(response["data"])
+        data = response&.dig("data") || {}
+        OrderResponse.new(data["order"].is_a?(Hash) ? data["order"] : data)
       rescue Tastytrade::Error => e
         handle_replace_error(e)
       end
 
+      # Change only the price of a working order
+      #
+      # The order is fetched and replaced with a copy that keeps its type, time
+      # in force and legs, so the legs need not be rebuilt. Legs that have
+      # partially filled are resubmitted for their remaining quantity. The new
+      # price is the limit price of a limit order and the trigger of a stop order.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to reprice
+      # @param price [BigDecimal, Numeric, String] New limit price or stop trigger
+      # @param price_effect [String, nil] PriceEffect constant, defaults to the order's current effect
+      # @return [OrderResponse] Response from order replacement
+      # @raise [OrderNotEditableError] if order cannot be edited
+      # @raise [ArgumentError] if the order is neither a limit nor a stop order
+      #
+      # @example Lower the credit asked on a working order
+      #   account.reprice_order(session, "12345", BigDecimal("1.05"))
+      def reprice_order(session, order_id, price, price_effect: nil)
+        order = get_order(session, order_id)
+        raise OrderNotEditableError, "Order is not in an editable state" unless order.editable?
+
+        replace_order(session, order_id, repriced_order(order, price, price_effect || order.price_effect))
+      end
+
+      # Replace an existing order and report how the new order ID was found
+      #
+      # The ID is read from the PUT response, either the order itself or
+      # nested under "order". When the response carries no ID and
+      # match_live_orders is true, the newest working live order with the
+      # replacement's symbols and price is taken as a best guess.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param order_id [String] Order ID to replace
+      # @param new_order [Tastytrade::Order] New order to replace with
+      # @param match_live_orders [Boolean] Infer the new order ID from live orders when the response lacks it
+      # @return [ReplaceResult] New order ID and whether it can be trusted
+      # @raise [OrderNotEditableError] if order cannot be edited
+      # @raise [InsufficientQuantityError] if trying to replace more than remaining quantity
+      #
+      # @example
+      #   result = account.replace_order_with_result(session, "12345", order, match_live_orders: true)
+      #   track(result.order_id) if result.confident?
+      def replace_order_with_result(session, order_id, new_order, match_live_orders: false)
+        response = replace_order(session, order_id, new_order)
+        unless response.order_id.nil?
+          return ReplaceResult.new(order_id: response.order_id, response: response, match: :response)
+        end
+
+        guess = match_live_orders ? find_replacement_order(session, order_id, new_order) : nil
+        ReplaceResult.new(order_id: guess&.id, response: response, match: guess && :live_orders)
+      end
+
       def closed?
         @is_closed == true
       end
@@ -218,12 +940,84 @@ module Tastytrade
         @is_test_drive == true
       end
 
+      # @return [Boolean] true if the authenticated user owns the account
+      def owner?
+        @authority_level == "owner"
+      end
+
       def foreign?
         @is_foreign == true
       end
 
       private
 
+      def futures_multipliers(session, orders)
+        symbols = orders.flat_map(&:legs)
+                        .select { |leg| ContractMultiplier::FUTURES_INSTRUMENT_TYPES.include?(leg.instrument_type) }
+                        .map(&:symbol).uniq
+        return {} if symbols.empty?
+
+        get_positions(session, symbol: symbols, include_closed: true).to_h do |position|
+          [position.symbol, position.multiplier]
+        end
+      end
+
+      def default_time_in_force(session, time_in_force)
+        defaults = session.order_defaults if session.respond_to?(:order_defaults)
+        defaults ? defaults.resolve_time_in_force(time_in_force) : (time_in_force || OrderTimeInForce::DAY)
+      end
+
+      def default_order_source(session, source)
+        return source unless session.respond_to?(:order_defaults) && session.order_defaults
+
+        session.order_defaults.resolve_source(source)
+      end
+
+      # A single status is sent as "status", several as "status[]"
+      def add_status_filter(params, status)
+        if status.is_a?(Array)
+          statuses = status.select { |value| OrderStatus.valid?(value) }
+          params["status"] = statuses unless statuses.empty?
+        elsif status && OrderStatus.valid?(status)
+          params["status"] = status
+        end
+      end
+
+      def position_expiration(position)
+        OptionSymbol.parse(position.symbol).expiration
+      rescue ArgumentError
+        position.expires_at&.to_date
+      end
+
+      # A single value is sent as a plain parameter, several as an array parameter
+      def position_filter_value(value)
+        values = Array(value)
+        values.size == 1 ? values.first : values
+      end
+
+      # Sums the fees on an order's transactions, nil when none are found
+      def order_fee_calculation(session, order)
+        options = { underlying_symbol: order.underlying_symbol }
+        options[:start_date] = order.created_at.to_date if order.created_at
+        transactions = get_transactions(session, **options.compact)
+                       .select { |transaction| transaction.order_id.to_s == order.id.to_s }
+        return nil if transactions.empty?
+
+        fees = {
+          "commission" => :commission,
+          "clearing-fees" => :clearing_fees,
+          "regulatory-fees" => :regulatory_fees,
+          "proprietary-index-option-fees" => :proprietary_index_option_fees
+        }.transform_values { |fee| transactions.sum(BigDecimal("0")) { |t| t.public_send(fee)&.abs || 0 } }
+        FeeCalculation.new(fees.merge("total-fees" => fees.values.sum))
+      end
+
+      def delete_order(session, order_id)
+        session.delete("/accounts/#{account_number}/orders/#{order_id}/")
+      rescue Tastytrade::Error => e
+        handle_cancel_error(e)
+      end
+
       def handle_cancel_error(error)
         if error.message.include?("already filled") || error.message.include?("Filled")
           raise OrderAlreadyFilledError, "Order has already been filled and cannot be cancelled"
@@ -234,6 +1028,43 @@ module Tastytrade
         end
       end
 
+      def find_replacement_order(session, order_id, new_order)
+        symbols = new_order.legs.map(&:symbol).sort
+        candidates = get_live_orders(session).select do |order|
+          order.id.to_s != order_id.to_s && !order.terminal? && order.legs.map(&:symbol).sort == symbols &&
+            (new_order.price.nil? || order.price&.abs == new_order.price.abs)
+        end
+        candidates.max_by { |order| [order.created_at || Time.at(0), order.id.to_i] }
+      end
+
+      def repriced_order(order, price, price_effect)
+        unless [OrderType::LIMIT, OrderType::STOP].include?(order.order_type)
+          raise ArgumentError,
+                "Cannot reprice #{order.order_type} order #{order.id}; only limit and stop orders have a price"
+        end
+
+        legs = order.legs.map do |leg|
+          instrument_type = CurrentPosition::ORDER_INSTRUMENT_TYPES.fetch(leg.instrument_type, leg.instrument_type)
+          OrderLeg.new(
+            action: leg.action,
+            # Live orders pad option roots to six characters; order legs use a single space
+            symbol: instrument_type == "Option" ? leg.symbol.gsub(/\s+/, " ") : leg.symbol,
+            quantity: leg.remaining_quantity || leg.quantity,
+            instrument_type: instrument_type
+          )
+        end
+
+        Order.new(
+          type: order.order_type,
+          time_in_force: order.time_in_force,
+          legs: legs,
+          price: order.order_type == OrderType::LIMIT ? price : nil,
+          price_effect: price_effect,
+          gtc_date: order.time_in_force == OrderTimeInForce::GTD ? order.gtc_date : nil,
+          stop_trigger: order.order_type == OrderType::STOP ? price : nil
+        )
+      end
+
       def handle_replace_error(error)
         if error.message.include?("not editable") || error.message.include?("Cannot edit")
           raise OrderNotEditableError, "Order is not in an editable state"
@@ -269,6 +1100,7 @@ module Tastytrade
 
       def parse_optional_attributes
         @external_id = @data["external-id"]
+        @authority_level = @data["authority-level"]
         @closed_at = parse_time(@data["closed-at"])
         @funding_date = parse_date(@data["funding-date"])
         @investment_objective = @data["investment-objective"]
@@ -10,7 +10,11 @@ module Tastytrade
                   :long_derivative_value, :short_derivative_value, :net_liquidating_value,
                   :equity_buying_power, :derivative_buying_power, :day_trading_buying_power,
                   :available_trading_funds, :margin_equity, :pending_cash,
-                  :pending_margin_interest, :effective_trading_funds, :updated_at
+                  :pending_margin_interest, :effective_trading_funds, :updated_at,
+                  :maintenance_requirement, :maintenance_excess, :maintenance_call_value,
+                  :reg_t_call_value, :day_trading_call_value, :day_equity_call_value,
+                  :cash_available_to_withdraw, :long_margineable_value, :short_margineable_value,
+                  :buying_power_adjustment, :pending_cash_effect, :buying_power_adjustment_effect
 
       def initialize(data)
         super
@@ -31,6 +35,22 @@ module Tastytrade
         @pending_cash = parse_decimal(data["pending-cash"])
         @pending_margin_interest = parse_decimal(data["pending-margin-interest"])
         @effective_trading_funds = parse_decimal(data["effective-trading-funds"])
+        @cash_available_to_withdraw = parse_decimal(data["cash-available-to-withdraw"])
+        @long_margineable_value = parse_decimal(data["long-margineable-value"])
+        @short_margineable_value = parse_decimal(data["short-margineable-value"])
+        @buying_power_adjustment = parse_decimal(data["buying-power-adjustment"])
+
+        # Margin requirements and calls
+        @maintenance_requirement = parse_decimal(data["maintenance-requirement"])
+        @maintenance_excess = parse_decimal(data["maintenance-excess"])
+        @maintenance_call_value = parse_decimal(data["maintenance-call-value"])
+        @reg_t_call_value = parse_decimal(data["reg-t-call-value"])
+        @day_trading_call_value = parse_decimal(data["day-trading-call-value"])
+        @day_equity_call_value = parse_decimal(data["day-equity-call-value"])
+
+        # Effects are "Debit", "Credit" or "None" and give the sign of the paired amount
+        @pending_cash_effect = data["pending-cash-effect"]
+        @buying_power_adjustment_effect = data["buying-power-adjustment-effect"]
 
         @updated_at = parse_time(data["updated-at"])
       end
@@ -48,6 +68,16 @@ module Tastytrade
         buying_power_usage_percentage > threshold
       end
 
+      # Pending cash signed by its effect (negative for debits)
+      def signed_pending_cash
+        pending_cash_effect == "Debit" ? -pending_cash : pending_cash
+      end
+
+      # Check if the account has an outstanding margin call of any kind
+      def margin_call?
+        [maintenance_call_value, reg_t_call_value, day_trading_call_value, day_equity_call_value].any?(&:positive?)
+      end
+
       # Calculate total equity value (long + short)
       def total_equity_value
         long_equity_value + short_equity_value
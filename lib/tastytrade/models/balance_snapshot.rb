@@ -0,0 +1,88 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Models
+    # An account's balances as recorded at the start or end of a trading day
+    #
+    # Unlike {AccountBalance}, which is live, snapshots are fixed records, e.g.
+    # for reconciling daily P&L against the beginning-of-day values.
+    #
+    # @example Day's change in net liquidating value
+    #   bod = account.get_balance_snapshots(session, snapshot_date: Date.today, time_of_day: "BOD").first
+    #   eod = account.get_balance_snapshots(session, snapshot_date: Date.today, time_of_day: "EOD").first
+    #   eod.net_liquidating_value - bod.net_liquidating_value
+    class BalanceSnapshot < Base
+      # Accepted time-of-day values: beginning and end of day
+      TIMES_OF_DAY = %w[BOD EOD].freeze
+
+      attr_reader :account_number, :snapshot_date, :time_of_day, :cash_balance, :net_liquidating_value,
+                  :long_equity_value, :short_equity_value, :long_derivative_value, :short_derivative_value,
+                  :equity_buying_power, :derivative_buying_power, :maintenance_requirement, :pending_cash,
+                  :currency, :updated_at
+
+      # Get balance snapshots for an account
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param account_number [String] Account number
+      # @param snapshot_date [Date, String, nil] Day to fetch, e.g. "2024-03-15"; all recorded days when nil
+      # @param time_of_day [String, nil] "BOD" or "EOD", both when nil
+      # @return [Array<BalanceSnapshot>] Snapshots, oldest first
+      # @raise [ArgumentError] if time_of_day is not an accepted value
+      def self.get_all(session, account_number, snapshot_date: nil, time_of_day: nil)
+        if time_of_day && !TIMES_OF_DAY.include?(time_of_day)
+          raise ArgumentError, "Invalid time of day: #{time_of_day}. Must be one of: #{TIMES_OF_DAY.join(", ")}"
+        end
+
+        params = {}
+        params["snapshot-date"] = snapshot_date.to_s if snapshot_date
+        params["time-of-day"] = time_of_day if time_of_day
+
+        response = session.get("/accounts/#{account_number}/balance-snapshots", params)
+        snapshots = (response.dig("data", "items") || []).map { |item| new(item) }
+        snapshots.sort_by { |snapshot| [snapshot.snapshot_date || Date.new(0), snapshot.end_of_day? ? 1 : 0] }
+      end
+
+      def beginning_of_day?
+        @time_of_day == "BOD"
+      end
+
+      def end_of_day?
+        @time_of_day == "EOD"
+      end
+
+      private
+
+      def parse_attributes
+        @account_number = @data["account-number"]
+        @snapshot_date = parse_date(@data["snapshot-date"])
+        @time_of_day = @data["time-of-day"]
+        @cash_balance = parse_decimal(@data["cash-balance"])
+        @net_liquidating_value = parse_decimal(@data["net-liquidating-value"])
+        @long_equity_value = parse_decimal(@data["long-equity-value"])
+        @short_equity_value = parse_decimal(@data["short-equity-value"])
+        @long_derivative_value = parse_decimal(@data["long-derivative-value"])
+        @short_derivative_value = parse_decimal(@data["short-derivative-value"])
+        @equity_buying_power = parse_decimal(@data["equity-buying-power"])
+        @derivative_buying_power = parse_decimal(@data["derivative-buying-power"])
+        @maintenance_requirement = parse_decimal(@data["maintenance-requirement"])
+        @pending_cash = parse_decimal(@data["pending-cash"])
+        @currency = @data["currency"]
+        @updated_at = parse_time(@data["updated-at"])
+      end
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+
+      def parse_date(value)
+        return nil if value.nil? || value.to_s.empty?
+
+        Date.parse(value.to_s)
+      end
+    end
+  end
+end
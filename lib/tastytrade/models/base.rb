@@ -7,6 +7,7 @@ module Tastytrade
     # Base class for all Tastytrade data models
     class Base
       def initialize(data = {})
+        @strict_time_parsing = data.is_a?(StrictTimeHash)
         @data = stringify_keys(data)
         parse_attributes
       end
@@ -34,13 +35,10 @@ module Tastytrade
         # Implemented by subclasses
       end
 
-      # Helper method to parse datetime strings
+      # Helper method to parse datetime strings, see {Tastytrade.parse_time}.
+      # Strict when the data came from a client in strict time parsing mode
       def parse_time(value)
-        return nil if value.nil? || value.empty?
-
-        Time.parse(value)
-      rescue ArgumentError
-        nil
+        Tastytrade.parse_time(value, strict: @strict_time_parsing)
       end
     end
   end
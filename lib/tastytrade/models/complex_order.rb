@@ -0,0 +1,194 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "time"
+
+module Tastytrade
+  module Models
+    # Represents a complex order (OTO, OCO, OTOCO) with its child orders
+    class ComplexOrder < Base
+      # One-Triggers-Other: trigger order plus one contingent order
+      OTO = "OTO"
+      # One-Cancels-Other: two working orders, a fill on one cancels the other
+      OCO = "OCO"
+      # One-Triggers-One-Cancels-Other: trigger order plus an OCO pair (bracket)
+      OTOCO = "OTOCO"
+
+      TYPES = [OTO, OCO, OTOCO].freeze
+
+      attr_reader :id, :account_number, :type, :terminal_at, :ratio_price_threshold,
+                  :ratio_price_comparator, :ratio_price_is_threshold_based_on_notional,
+                  :trigger_order, :orders, :related_orders
+
+      class << self
+        # Search historical complex orders for an account.
+        #
+        # When no page_offset is given all pages are fetched. Pass page_offset to
+        # retrieve a single page.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param account_number [String] Account number
+        # @param status [String, nil] Filter by order status
+        # @param underlying_symbol [String, nil] Filter by underlying symbol
+        # @param from_time [Time, nil] Start time for order history
+        # @param to_time [Time, nil] End time for order history
+        # @param page_offset [Integer, nil] Fetch only this page
+        # @param page_limit [Integer, nil] Number of results per page
+        # @return [Array<ComplexOrder>] Complex orders with child orders populated
+        def get_history(session, account_number, **filters)
+          return get_history_page(session, account_number, **filters).first if filters[:page_offset]
+
+          complex_orders = []
+          page_offset = 0
+
+          loop do
+            items, pagination = get_history_page(session, account_number, **filters, page_offset: page_offset)
+            complex_orders.concat(items)
+            break if items.empty? || pagination.nil? || !pagination.next_page?
+
+            page_offset = pagination.next_page_offset
+          end
+
+          complex_orders
+        end
+
+        # Fetch a single page of complex order history
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param account_number [String] Account number
+        # @param filters [Hash] Same filters as {.get_history}
+        # @return [Array(Array<ComplexOrder>, Pagination)] Complex orders and pagination
+        #   metadata (nil when the response has none)
+        def get_history_page(session, account_number, status: nil, underlying_symbol: nil, from_time: nil,
+                             to_time: nil, page_offset: nil, page_limit: nil)
+          params = {}
+          params["status"] = status if status && OrderStatus.valid?(status)
+          params["underlying-symbol"] = underlying_symbol if underlying_symbol
+          params["from-time"] = from_time.iso8601 if from_time
+          params["to-time"] = to_time.iso8601 if to_time
+          params["page-offset"] = page_offset unless page_offset.to_i.zero?
+          params["page-limit"] = page_limit if page_limit
+
+          response = session.get("/accounts/#{account_number}/complex-orders/", params)
+          items = (response.dig("data", "items") || []).map { |item| new(item) }
+          pagination = response["pagination"] ? Pagination.new(response["pagination"]) : nil
+
+          [items, pagination]
+        end
+
+        # Get the account's working complex orders, including ones whose trigger
+        # order has filled while their child orders are still live
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param account_number [String] Account number
+        # @return [Array<ComplexOrder>] Live complex orders with child orders populated
+        def get_live(session, account_number)
+          response = session.get("/accounts/#{account_number}/complex-orders/live/")
+          (response.dig("data", "items") || []).map { |item| new(item) }
+        end
+      end
+
+      # @return [Boolean] true for One-Triggers-Other orders
+      def oto?
+        type == OTO
+      end
+
+      # @return [Boolean] true for One-Cancels-Other orders
+      def oco?
+        type == OCO
+      end
+
+      # @return [Boolean] true for One-Triggers-One-Cancels-Other orders
+      def otoco?
+        type == OTOCO
+      end
+
+      # @return [Boolean] true once every order in the group has finished
+      def terminal?
+        return true if @terminal_at
+
+        !all_orders.empty? && all_orders.all?(&:terminal?)
+      end
+
+      # Trigger order followed by its child orders
+      #
+      # @return [Array<LiveOrder>] Every order in the group
+      def all_orders
+        [@trigger_order, *@orders].compact
+      end
+
+      # Child orders that filled, e.g. the profit target or stop of a bracket
+      #
+      # @return [Array<LiveOrder>] Filled child orders
+      def filled_orders
+        @orders.select(&:filled?)
+      end
+
+      # Convert to hash for JSON serialization
+      def to_h
+        {
+          id: @id,
+          account_number: @account_number,
+          type: @type,
+          terminal_at: @terminal_at&.iso8601,
+          ratio_price_threshold: @ratio_price_threshold&.to_s("F"),
+          ratio_price_comparator: @ratio_price_comparator,
+          ratio_price_is_threshold_based_on_notional: @ratio_price_is_threshold_based_on_notional,
+          trigger_order: @trigger_order&.to_h,
+          orders: @orders.map(&:to_h),
+          related_orders: @related_orders.map(&:to_h)
+        }.compact
+      end
+
+      private
+
+      def parse_attributes
+        @id = @data["id"]
+        @account_number = @data["account-number"]
+        @type = @data["type"]
+        @terminal_at = parse_time(@data["terminal-at"])
+        @ratio_price_threshold = parse_financial_value(@data["ratio-price-threshold"])
+        @ratio_price_comparator = @data["ratio-price-comparator"]
+        @ratio_price_is_threshold_based_on_notional = @data["ratio-price-is-threshold-based-on-notional"]
+
+        @trigger_order = @data["trigger-order"] ? LiveOrder.new(@data["trigger-order"]) : nil
+        @orders = (@data["orders"] || []).map { |order| LiveOrder.new(order) }
+        @related_orders = (@data["related-orders"] || []).map { |order| RelatedOrder.new(order) }
+      end
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+    end
+
+    # Summary of an order that belongs to a complex order
+    class RelatedOrder < Base
+      attr_reader :id, :complex_order_id, :complex_order_tag, :replaces_order_id,
+                  :replacing_order_id, :status
+
+      # Convert to hash for JSON serialization
+      def to_h
+        {
+          id: @id,
+          complex_order_id: @complex_order_id,
+          complex_order_tag: @complex_order_tag,
+          replaces_order_id: @replaces_order_id,
+          replacing_order_id: @replacing_order_id,
+          status: @status
+        }.compact
+      end
+
+      private
+
+      def parse_attributes
+        @id = @data["id"]
+        @complex_order_id = @data["complex-order-id"]
+        @complex_order_tag = @data["complex-order-tag"]
+        @replaces_order_id = @data["replaces-order-id"]
+        @replacing_order_id = @data["replacing-order-id"]
+        @status = @data["status"]
+      end
+    end
+  end
+end
@@ -0,0 +1,40 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # Represents the response from placing a complex order
+    class ComplexOrderResponse < Base
+      attr_reader :complex_order, :buying_power_effect, :fee_calculations, :warnings, :errors
+
+      # @return [Integer, nil] ID of the placed complex order
+      def complex_order_id
+        @complex_order&.id
+      end
+
+      # Typed fee breakdown with decimal amounts
+      #
+      # @return [FeeCalculation, nil] Fees for the complex order, nil when not reported
+      def fee_calculation
+        @fee_calculations.is_a?(Hash) ? FeeCalculation.new(@fee_calculations) : nil
+      end
+
+      private
+
+      def parse_attributes
+        @complex_order = @data["complex-order"] ? ComplexOrder.new(@data["complex-order"]) : nil
+        @buying_power_effect = parse_buying_power_effect(@data["buying-power-effect"])
+        @fee_calculations = @data["fee-calculation"]
+        @warnings = @data["warnings"] || []
+        @errors = @data["errors"] || []
+      end
+
+      def parse_buying_power_effect(value)
+        return nil if value.nil?
+
+        value.is_a?(Hash) ? BuyingPowerEffect.new(value) : BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -1,11 +1,30 @@
 # frozen_string_literal: true
 
 require "bigdecimal"
+require_relative "../contract_multiplier"
 
 module Tastytrade
   module Models
     # Represents a current position in an account
+    #
+    # Values are scaled by the position's multiplier as reported by the API:
+    # 100 for a standard equity option, 1 for shares and the contract size of
+    # a futures product, e.g. 50 for /ES. Fractional multipliers, such as
+    # those of some micro futures, are kept as BigDecimal. See
+    # {ContractMultiplier}.
     class CurrentPosition < Base
+      # Order leg instrument types for position instrument types
+      ORDER_INSTRUMENT_TYPES = {
+        "Equity" => "Equity",
+        "Equity Option" => "Option",
+        "Future" => "Future",
+        "Future Option" => "Future Option",
+        "Cryptocurrency" => "Cryptocurrency"
+      }.freeze
+
+      # Instrument types of futures and futures option positions
+      FUTURES_INSTRUMENT_TYPES = ContractMultiplier::FUTURES_INSTRUMENT_TYPES
+
       attr_reader :account_number, :symbol, :instrument_type, :underlying_symbol,
                   :quantity, :quantity_direction, :close_price, :average_open_price,
                   :average_yearly_market_close_price, :average_daily_market_close_price,
@@ -36,7 +55,7 @@ module Tastytrade
         @mark_price = parse_decimal(data["mark-price"])
 
         # Position details
-        @multiplier = data["multiplier"]&.to_i || 1
+        @multiplier = parse_multiplier(data["multiplier"], @instrument_type)
         @cost_effect = data["cost-effect"]
         @is_suppressed = data["is-suppressed"] || false
         @is_frozen = data["is-frozen"] || false
@@ -94,6 +113,11 @@ module Tastytrade
         instrument_type == "Future Option"
       end
 
+      # Check if this is a futures or futures option position
+      def futures_related?
+        FUTURES_INSTRUMENT_TYPES.include?(instrument_type)
+      end
+
       # Calculate position value (quantity * price * multiplier)
       def position_value
         return BigDecimal("0") if closed?
@@ -128,6 +152,42 @@ module Tastytrade
         realized_today + unrealized_pnl
       end
 
+      # Builds the order that closes this position
+      #
+      # Long positions are closed with Sell to Close and short positions with
+      # Buy to Close, for the full quantity.
+      #
+      # @param order_type [String, nil] OrderType constant (default: limit when priced, otherwise market)
+      # @param price [BigDecimal, Numeric, nil] Limit price
+      # @param time_in_force [String] OrderTimeInForce constant
+      # @return [Order] Closing order
+      # @raise [ArgumentError] if the position is closed or its instrument type cannot be traded
+      def closing_order(order_type: nil, price: nil, time_in_force: OrderTimeInForce::DAY)
+        Order.new(
+          type: order_type || (price ? OrderType::LIMIT : OrderType::MARKET),
+          time_in_force: time_in_force,
+          legs: closing_leg,
+          price: price
+        )
+      end
+
+      # @return [OrderLeg] Leg that closes this position
+      # @raise [ArgumentError] if the position is closed or its instrument type cannot be traded
+      def closing_leg
+        raise ArgumentError, "Position #{symbol} is already closed" if closed?
+
+        leg_type = ORDER_INSTRUMENT_TYPES[instrument_type]
+        raise ArgumentError, "Cannot close #{instrument_type} position #{symbol}" unless leg_type
+
+        OrderLeg.new(
+          action: long? ? OrderAction::SELL_TO_CLOSE : OrderAction::BUY_TO_CLOSE,
+          # Positions pad option roots to six characters; order legs use a single space
+          symbol: option? ? symbol.gsub(/\s+/, " ") : symbol,
+          quantity: leg_type == "Cryptocurrency" ? quantity.abs : quantity.abs.to_i,
+          instrument_type: leg_type
+        )
+      end
+
       # Get display symbol (simplified for options)
       def display_symbol
         if option?
@@ -150,6 +210,12 @@ module Tastytrade
         return BigDecimal("0") if value.nil? || value.to_s.empty?
         BigDecimal(value.to_s)
       end
+
+      # Positions report their multiplier; one without falls back to the
+      # standard multiplier, or 1 for a futures position
+      def parse_multiplier(value, instrument_type)
+        ContractMultiplier.for(instrument_type, value) || 1
+      end
     end
   end
 end
@@ -0,0 +1,49 @@
+# frozen_string_literal: true
+
+module Tastytrade
+  module Models
+    # Represents the customer behind the authenticated user
+    class Customer < Base
+      attr_reader :id, :first_name, :middle_name, :last_name, :email, :mobile_phone_number,
+                  :usa_citizenship_type, :has_industry_affiliation, :is_professional,
+                  :permitted_account_types, :created_at, :identifiable_type
+
+      class << self
+        # Get the customer for the authenticated session
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @return [Customer] Customer for the session's user
+        def get_current(session)
+          response = session.get("/customers/me")
+          new(response["data"])
+        end
+      end
+
+      # @return [String] First and last name
+      def full_name
+        [@first_name, @last_name].compact.join(" ")
+      end
+
+      def professional?
+        @is_professional == true
+      end
+
+      private
+
+      def parse_attributes
+        @id = @data["id"]
+        @first_name = @data["first-name"]
+        @middle_name = @data["middle-name"]
+        @last_name = @data["last-name"]
+        @email = @data["email"]
+        @mobile_phone_number = @data["mobile-phone-number"]
+        @usa_citizenship_type = @data["usa-citizenship-type"]
+        @has_industry_affiliation = @data["has-industry-affiliation"]
+        @is_professional = @data["is-professional"]
+        @permitted_account_types = @data["permitted-account-types"] || []
+        @created_at = parse_time(@data["created-at"])
+        @identifiable_type = @data["identifiable-type"]
+      end
+    end
+  end
+end
@@ -0,0 +1,54 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Models
+    # A dividend paid on a symbol
+    #
+    # @example
+    #   DividendEvent.get(session, "AAPL").last.amount  # => BigDecimal("0.24")
+    class DividendEvent < Base
+      attr_reader :symbol, :occurred_date, :amount
+
+      # Get dividend events for a symbol
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity symbol
+      # @return [Array<DividendEvent>] Events, oldest first
+      def self.get(session, symbol)
+        response = session.get("/market-metrics/historic-corporate-events/dividends/#{symbol}")
+        events = (response.dig("data", "items") || []).map { |item| new(item.merge("symbol" => symbol)) }
+        events.sort_by { |event| event.occurred_date || Date.new(0) }
+      end
+
+      # @param start_date [Date] First day of the window
+      # @param end_date [Date] Last day of the window
+      # @return [Boolean] true if the event falls within the window, inclusive
+      def within?(start_date, end_date)
+        !@occurred_date.nil? && @occurred_date.between?(start_date, end_date)
+      end
+
+      private
+
+      def parse_attributes
+        @symbol = @data["symbol"]
+        @occurred_date = parse_date(@data["occurred-date"])
+        @amount = parse_financial_value(@data["amount"])
+      end
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+
+      def parse_date(value)
+        return nil if value.nil? || value.to_s.empty?
+        Date.parse(value.to_s)
+      rescue ArgumentError
+        nil
+      end
+    end
+  end
+end
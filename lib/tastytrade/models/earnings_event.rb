@@ -0,0 +1,78 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Models
+    # An earnings report for a symbol, past or upcoming
+    #
+    # Reported events carry the actual EPS; upcoming events are marked
+    # estimated and carry the consensus EPS estimate instead.
+    #
+    # @example Flag earnings inside a holding window
+    #   events = EarningsEvent.get(session, "AAPL", start_date: Date.today)
+    #   events.any? { |event| event.within?(Date.today, Date.today + 30) }
+    class EarningsEvent < Base
+      attr_reader :symbol, :occurred_date, :eps, :estimated_eps, :is_estimated, :time_of_day
+
+      # Get earnings events for a symbol
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity symbol
+      # @param start_date [Date, String, nil] Earliest event date to include
+      # @return [Array<EarningsEvent>] Events, oldest first
+      def self.get(session, symbol, start_date: nil)
+        params = start_date ? { "start-date" => start_date.to_s } : {}
+        response = session.get("/market-metrics/historic-corporate-events/earnings-reports/#{symbol}", params)
+        events = (response.dig("data", "items") || []).map { |item| new(item.merge("symbol" => symbol)) }
+        events.sort_by { |event| event.occurred_date || Date.new(0) }
+      end
+
+      # @return [Boolean] true if the date and EPS are estimates for an upcoming report
+      def estimated?
+        @is_estimated == true
+      end
+
+      # @return [Boolean] true if reported before the market open
+      def before_open?
+        @time_of_day == "BMO"
+      end
+
+      # @return [Boolean] true if reported after the market close
+      def after_close?
+        @time_of_day == "AMC"
+      end
+
+      # @param start_date [Date] First day of the window
+      # @param end_date [Date] Last day of the window
+      # @return [Boolean] true if the event falls within the window, inclusive
+      def within?(start_date, end_date)
+        !@occurred_date.nil? && @occurred_date.between?(start_date, end_date)
+      end
+
+      private
+
+      def parse_attributes
+        @symbol = @data["symbol"]
+        @occurred_date = parse_date(@data["occurred-date"])
+        @eps = parse_financial_value(@data["eps"])
+        @estimated_eps = parse_financial_value(@data["estimated-eps"])
+        @is_estimated = @data["is-estimated"]
+        @time_of_day = @data["time-of-day"]
+      end
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+
+      def parse_date(value)
+        return nil if value.nil? || value.to_s.empty?
+        Date.parse(value.to_s)
+      rescue ArgumentError
+        nil
+      end
+    end
+  end
+end
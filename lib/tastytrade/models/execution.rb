@@ -0,0 +1,91 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # A single fill flattened out of its order and leg
+    #
+    # Orders nest fills under legs. An Execution carries the order and leg context
+    # alongside the fill so a day's trades can be listed and sorted directly.
+    # Orders do not report multipliers, so futures fills need theirs passed in
+    # to have a cost.
+    class Execution
+      attr_reader :order_id, :fill_id, :underlying_symbol, :symbol, :instrument_type,
+                  :action, :quantity, :price, :filled_at, :destination_venue
+
+      # Flatten every fill of an order into executions
+      #
+      # @param order [LiveOrder] Order with legs and fills
+      # @param multipliers [Hash{String => Numeric}] Multipliers by symbol, e.g. from positions
+      # @return [Array<Execution>] One execution per fill
+      def self.from_order(order, multipliers: {})
+        order.legs.flat_map do |leg|
+          leg.fills.map { |fill| new(order: order, leg: leg, fill: fill, multiplier: multipliers[leg.symbol]) }
+        end
+      end
+
+      # @param order [LiveOrder] Parent order
+      # @param leg [LiveOrderLeg] Leg the fill belongs to
+      # @param fill [Fill] Fill execution
+      # @param multiplier [Numeric, nil] Multiplier of the leg's instrument, if known
+      def initialize(order:, leg:, fill:, multiplier: nil)
+        @order_id = order.id
+        @underlying_symbol = order.underlying_symbol
+        @symbol = leg.symbol
+        @instrument_type = leg.instrument_type
+        @action = leg.action
+        @fill_id = fill.fill_id
+        @quantity = fill.quantity
+        @price = fill.fill_price
+        @filled_at = fill.filled_at
+        @destination_venue = fill.destination_venue
+        @multiplier = ContractMultiplier.for(@instrument_type, multiplier)
+      end
+
+      # @return [String] "Buy" or "Sell"
+      def side
+        buy? ? "Buy" : "Sell"
+      end
+
+      def buy?
+        @action.to_s.start_with?("Buy")
+      end
+
+      def sell?
+        !buy?
+      end
+
+      # @return [Integer, BigDecimal, nil] Contract multiplier, nil for futures when not given
+      attr_reader :multiplier
+
+      # Cash effect of the fill before fees: positive for buys, negative for sells
+      #
+      # @return [BigDecimal, nil] Signed cost, nil if the fill has no price or the multiplier is unknown
+      def cost
+        return nil unless @price && @quantity && multiplier
+
+        value = @price * @quantity * multiplier
+        buy? ? value : -value
+      end
+
+      # Convert to hash for JSON serialization
+      def to_h
+        {
+          order_id: @order_id,
+          fill_id: @fill_id,
+          underlying_symbol: @underlying_symbol,
+          symbol: @symbol,
+          instrument_type: @instrument_type,
+          action: @action,
+          side: side,
+          quantity: @quantity,
+          price: @price&.to_s("F"),
+          cost: cost&.to_s("F"),
+          filled_at: @filled_at&.iso8601,
+          destination_venue: @destination_venue
+        }.compact
+      end
+    end
+  end
+end
@@ -0,0 +1,64 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # Represents the fee breakdown returned for a dry-run or placed order
+    class FeeCalculation < Base
+      attr_reader :regulatory_fees, :regulatory_fees_effect,
+                  :clearing_fees, :clearing_fees_effect,
+                  :commission, :commission_effect,
+                  :proprietary_index_option_fees, :proprietary_index_option_fees_effect,
+                  :total_fees, :total_fees_effect
+
+      # Total fees for the order
+      #
+      # Uses total-fees when the API provides it, otherwise sums the individual fees.
+      #
+      # @return [BigDecimal] Total fees as a positive amount
+      def total
+        return total_fees.abs if total_fees
+
+        [regulatory_fees, clearing_fees, commission, proprietary_index_option_fees]
+          .compact.sum(BigDecimal("0"), &:abs)
+      end
+
+      # Convert to hash for JSON serialization, keeping the API's decimal strings
+      def to_h
+        {
+          regulatory_fees: @regulatory_fees&.to_s("F"),
+          regulatory_fees_effect: @regulatory_fees_effect,
+          clearing_fees: @clearing_fees&.to_s("F"),
+          clearing_fees_effect: @clearing_fees_effect,
+          commission: @commission&.to_s("F"),
+          commission_effect: @commission_effect,
+          proprietary_index_option_fees: @proprietary_index_option_fees&.to_s("F"),
+          proprietary_index_option_fees_effect: @proprietary_index_option_fees_effect,
+          total_fees: @total_fees&.to_s("F"),
+          total_fees_effect: @total_fees_effect
+        }.compact
+      end
+
+      private
+
+      def parse_attributes
+        @regulatory_fees = parse_decimal(@data["regulatory-fees"])
+        @regulatory_fees_effect = @data["regulatory-fees-effect"]
+        @clearing_fees = parse_decimal(@data["clearing-fees"])
+        @clearing_fees_effect = @data["clearing-fees-effect"]
+        @commission = parse_decimal(@data["commission"])
+        @commission_effect = @data["commission-effect"]
+        @proprietary_index_option_fees = parse_decimal(@data["proprietary-index-option-fees"])
+        @proprietary_index_option_fees_effect = @data["proprietary-index-option-fees-effect"]
+        @total_fees = parse_decimal(@data["total-fees"])
+        @total_fees_effect = @data["total-fees-effect"]
+      end
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -0,0 +1,127 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Models
+    # Fundamental data for an equity (valuation, earnings and dividends)
+    #
+    # Sourced from the market metrics endpoint, which also carries volatility and
+    # liquidity data. This model keeps only the fields used for fundamental
+    # analysis. Fields the API does not return for a symbol are nil.
+    #
+    # @example
+    #   fundamentals = Fundamentals.get(session, "AAPL")
+    #   fundamentals.price_earnings_ratio  # => BigDecimal("29.5")
+    #   fundamentals.next_earnings_date    # => Date<2024-04-25>
+    class Fundamentals < Base
+      attr_reader :symbol, :market_cap, :price_earnings_ratio, :earnings_per_share,
+                  :dividend_yield, :dividend_rate_per_share, :beta, :shares_outstanding,
+                  :sector, :industry, :listed_market, :next_earnings_date,
+                  :earnings_time_of_day, :updated_at
+
+      class << self
+        # Get fundamentals for a symbol
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Equity symbol
+        # @return [Fundamentals, nil] Fundamentals or nil if the symbol is unknown
+        def get(session, symbol)
+          get_all(session, [symbol]).first
+        end
+
+        # Get the dividend yield for a symbol, e.g. for a screener
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Equity symbol
+        # @return [BigDecimal, nil] Yield as a fraction (0.0052 for 0.52%), nil if unknown
+        def dividend_yield(session, symbol)
+          get(session, symbol)&.dividend_yield
+        end
+
+        # Get fundamentals for several symbols in one request
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbols [Array<String>] Equity symbols
+        # @return [Array<Fundamentals>] Fundamentals for the symbols the API returned
+        def get_all(session, symbols)
+          return [] if symbols.empty?
+
+          symbols = symbols.map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+          response = session.get("/market-metrics", { "symbols" => symbols.join(",") })
+          (response.dig("data", "items") || []).map { |item| new(item) }
+        end
+      end
+
+      # @return [Boolean] true if the company pays a dividend
+      def dividend?
+        !@dividend_yield.nil? && @dividend_yield.positive?
+      end
+
+      # Days until the next expected earnings report
+      #
+      # @param today [Date] Reference date
+      # @return [Integer, nil] Days until earnings or nil if unknown
+      def days_to_earnings(today = Date.today)
+        return nil unless @next_earnings_date
+
+        (@next_earnings_date - today).to_i
+      end
+
+      # Convert to hash for JSON serialization
+      def to_h
+        {
+          symbol: @symbol,
+          market_cap: @market_cap&.to_s("F"),
+          price_earnings_ratio: @price_earnings_ratio&.to_s("F"),
+          earnings_per_share: @earnings_per_share&.to_s("F"),
+          dividend_yield: @dividend_yield&.to_s("F"),
+          dividend_rate_per_share: @dividend_rate_per_share&.to_s("F"),
+          beta: @beta&.to_s("F"),
+          shares_outstanding: @shares_outstanding,
+          sector: @sector,
+          industry: @industry,
+          listed_market: @listed_market,
+          next_earnings_date: @next_earnings_date&.to_s,
+          earnings_time_of_day: @earnings_time_of_day,
+          updated_at: @updated_at&.iso8601
+        }.compact
+      end
+
+      private
+
+      def parse_attributes
+        @symbol = @data["symbol"]
+        @market_cap = parse_financial_value(@data["market-cap"])
+        @price_earnings_ratio = parse_financial_value(@data["price-earnings-ratio"])
+        @earnings_per_share = parse_financial_value(@data["earnings-per-share"])
+        @dividend_yield = parse_financial_value(@data["dividend-yield"])
+        @dividend_rate_per_share = parse_financial_value(@data["dividend-rate-per-share"])
+        @beta = parse_financial_value(@data["beta"])
+        # Sent as a numeric string, which may be a decimal or in scientific notation
+        @shares_outstanding = parse_financial_value(@data["shares-outstanding"])&.to_i
+        @sector = @data["sector"]
+        @industry = @data["industry"]
+        @listed_market = @data["listed-market"]
+        @updated_at = parse_time(@data["updated-at"])
+
+        earnings = @data["earnings"] || {}
+        @next_earnings_date = parse_date(earnings["expected-report-date"])
+        @earnings_time_of_day = earnings["time-of-day"]
+      end
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+
+      def parse_date(value)
+        return nil if value.nil? || value.to_s.empty?
+        Date.parse(value.to_s)
+      rescue ArgumentError
+        nil
+      end
+    end
+  end
+end
@@ -0,0 +1,82 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # Option greeks and implied volatility from the DXLink Greeks feed
+    #
+    # Values are parsed as BigDecimal; fields the feed reports as NaN are nil.
+    #
+    # @example Greeks for one expiration of a chain
+    #   chain = NestedOptionChain.get(session, "SPY")
+    #   greeks = Greeks.get_all(session, chain.expirations.first.streamer_symbols)
+    #   greeks[".SPY240315C450"].delta  # => BigDecimal("0.5213")
+    class Greeks < Base
+      EVENT_TYPE = "Greeks"
+      FIELDS = %w[eventType eventSymbol time price volatility delta gamma theta rho vega].freeze
+      SNAPSHOT_TIMEOUT = 10
+
+      attr_reader :event_symbol, :time, :price, :volatility, :delta, :gamma, :theta, :rho, :vega
+
+      # Get a snapshot of greeks for option streamer symbols
+      #
+      # Subscribes to the Greeks feed and returns once every symbol has an
+      # event or the timeout passes. Symbols without an event are left out.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param streamer_symbols [Array<String>] Streamer symbols, e.g. from {NestedOptionChain::Strike#streamer_symbols}
+      # @param timeout [Numeric] Seconds to wait for the connection and for events
+      # @param stream_options [Hash] Options for {DXLinkStream}
+      # @return [Hash{String => Greeks}] Greeks keyed by streamer symbol
+      # @raise [StreamError] if the streamer cannot be reached
+      def self.get_all(session, streamer_symbols, timeout: SNAPSHOT_TIMEOUT, **stream_options)
+        symbols = Array(streamer_symbols).uniq
+        return {} if symbols.empty?
+
+        token = session.quote_token
+        events = Queue.new
+        stream = DXLinkStream.new(token.token, token.dxlink_url, keepalive_interval: nil, **stream_options)
+        stream.connect(timeout: timeout)
+        stream.subscribe(EVENT_TYPE, symbols, FIELDS) { |event| events << new(event) }
+
+        collect(events, symbols, timeout)
+      ensure
+        stream&.close
+      end
+
+      def self.collect(events, symbols, timeout)
+        greeks = {}
+        deadline = Process.clock_gettime(Process::CLOCK_MONOTONIC) + timeout
+        while greeks.size < symbols.size
+          remaining = deadline - Process.clock_gettime(Process::CLOCK_MONOTONIC)
+          break unless remaining.positive? && (event = events.pop(timeout: remaining))
+
+          greeks[event.event_symbol] = event if symbols.include?(event.event_symbol)
+        end
+        greeks
+      end
+      private_class_method :collect
+
+      private
+
+      def parse_attributes
+        @event_symbol = @data["eventSymbol"]
+        @time = @data["time"].is_a?(Numeric) && @data["time"].positive? ? Time.at(@data["time"] / 1000.0) : nil
+        @price = parse_decimal(@data["price"])
+        @volatility = parse_decimal(@data["volatility"])
+        @delta = parse_decimal(@data["delta"])
+        @gamma = parse_decimal(@data["gamma"])
+        @theta = parse_decimal(@data["theta"])
+        @rho = parse_decimal(@data["rho"])
+        @vega = parse_decimal(@data["vega"])
+      end
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty? || value.to_s.casecmp?("NaN")
+
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -7,7 +7,7 @@ module Tastytrade
   module Models
     # Represents a live order (open or recently closed) from the API
     class LiveOrder < Base
-      attr_reader :id, :account_number, :status, :cancellable, :editable,
+      attr_reader :id, :account_number, :ext_exchange_order_number, :status, :cancellable, :editable,
                   :edited, :time_in_force, :order_type, :size, :price,
                   :price_effect, :underlying_symbol, :underlying_instrument_type,
                   :stop_trigger, :legs, :gtc_date, :created_at, :updated_at,
@@ -46,6 +46,16 @@ module Tastytrade
         status == "Cancelled"
       end
 
+      # Check if order is waiting on a trigger order to fill (OTO/OTOCO child)
+      def contingent?
+        status == OrderStatus::CONTINGENT || OrderContingentStatus.pending?(contingent_status)
+      end
+
+      # Check if a contingent order has been released to the market
+      def triggered?
+        OrderContingentStatus.triggered?(contingent_status)
+      end
+
       # Get remaining quantity across all legs
       def remaining_quantity
         return 0 unless @legs
@@ -58,11 +68,44 @@ module Tastytrade
         @legs.sum { |leg| leg.filled_quantity || 0 }
       end
 
+      # Every fill execution across all legs
+      #
+      # @return [Array<Fill>]
+      def fills
+        return [] unless @legs
+        @legs.flat_map(&:fills)
+      end
+
+      # Total quantity executed across all legs' fills
+      #
+      # @return [Integer]
+      def total_filled
+        fills.sum { |fill| fill.quantity || 0 }
+      end
+
+      # Quantity-weighted average price across all legs' fills
+      #
+      # @return [BigDecimal, nil] nil when nothing has filled
+      def average_fill_price
+        priced = fills.select { |fill| fill.quantity.to_i.positive? && fill.fill_price }
+        quantity = priced.sum(&:quantity)
+        return nil if quantity.zero?
+
+        priced.sum { |fill| fill.fill_price * fill.quantity } / quantity
+      end
+
+      # Check if no quantity remains on any leg
+      def complete?
+        return false if @legs.nil? || @legs.empty?
+        @legs.all?(&:filled?)
+      end
+
       # Convert to hash for JSON serialization
       def to_h
         {
           id: @id,
           account_number: @account_number,
+          ext_exchange_order_number: @ext_exchange_order_number,
           status: @status,
           cancellable: @cancellable,
           editable: @editable,
@@ -111,6 +154,7 @@ module Tastytrade
       def parse_basic_attributes
         @id = @data["id"]
         @account_number = @data["account-number"]
+        @ext_exchange_order_number = @data["ext-exchange-order-number"]
         @status = @data["status"]
         @cancellable = @data["cancellable"]
         @editable = @data["editable"]
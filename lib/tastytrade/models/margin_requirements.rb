@@ -0,0 +1,156 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  module Models
+    # Margin requirement breakdown for an account
+    #
+    # The report holds account-level totals and one group per underlying. Each
+    # group carries its own requirements, the expected price range used to
+    # compute them, the position entries it covers, and possibly nested groups
+    # for strategies within the underlying.
+    #
+    # @example
+    #   report = account.get_margin_requirements(session)
+    #   report.total_maintenance_requirement
+    #   report.group_for("SPY").entries.map(&:instrument_symbol)
+    class MarginRequirements < Base
+      # A position contributing to a margin group
+      class Entry < Base
+        attr_reader :instrument_symbol, :instrument_type, :quantity, :close_price,
+                    :fixed_strike_price, :strike_price, :option_type, :expiration_date,
+                    :amount_per_unit
+
+        private
+
+        def parse_attributes
+          @instrument_symbol = @data["instrument-symbol"]
+          @instrument_type = @data["instrument-type"]
+          @quantity = parse_decimal(@data["quantity"])
+          @close_price = parse_decimal(@data["close-price"])
+          @fixed_strike_price = parse_decimal(@data["fixed-strike-price"])
+          @strike_price = parse_decimal(@data["strike-price"])
+          @option_type = @data["option-type"]
+          @expiration_date = parse_date(@data["expiration-date"])
+          @amount_per_unit = parse_decimal(@data["amount-per-unit"])
+        end
+
+        def parse_decimal(value)
+          return nil if value.nil? || value.to_s.empty?
+          BigDecimal(value.to_s)
+        end
+
+        def parse_date(value)
+          return nil if value.nil? || value.to_s.empty?
+          Date.parse(value.to_s)
+        rescue Date::Error
+          nil
+        end
+      end
+
+      # Margin requirements for one underlying or strategy
+      class Group < Base
+        attr_reader :description, :code, :underlying_symbol, :underlying_type, :margin_calculation_type,
+                    :expected_price_range_up_adjustment, :expected_price_range_down_adjustment,
+                    :point_of_no_return_percent, :margin_requirement, :margin_requirement_effect,
+                    :initial_requirement, :initial_requirement_effect,
+                    :maintenance_requirement, :maintenance_requirement_effect,
+                    :buying_power, :buying_power_effect, :entries, :groups
+
+        private
+
+        def parse_attributes
+          @description = @data["description"]
+          @code = @data["code"]
+          @underlying_symbol = @data["underlying-symbol"]
+          @underlying_type = @data["underlying-type"]
+          @margin_calculation_type = @data["margin-calculation-type"]
+          @expected_price_range_up_adjustment = parse_decimal(@data["expected-price-range-up-adjustment"])
+          @expected_price_range_down_adjustment = parse_decimal(@data["expected-price-range-down-adjustment"])
+          @point_of_no_return_percent = parse_decimal(@data["point-of-no-return-percent"])
+          @margin_requirement = parse_decimal(@data["margin-requirement"])
+          @margin_requirement_effect = @data["margin-requirement-effect"]
+          @initial_requirement = parse_decimal(@data["initial-requirement"])
+          @initial_requirement_effect = @data["initial-requirement-effect"]
+          @maintenance_requirement = parse_decimal(@data["maintenance-requirement"])
+          @maintenance_requirement_effect = @data["maintenance-requirement-effect"]
+          @buying_power = parse_decimal(@data["buying-power"])
+          @buying_power_effect = @data["buying-power-effect"]
+          @entries = Array(@data["position-entries"]).map { |entry| Entry.new(entry) }
+          @groups = Array(@data["groups"]).map { |group| Group.new(group) }
+        end
+
+        def parse_decimal(value)
+          return nil if value.nil? || value.to_s.empty?
+          BigDecimal(value.to_s)
+        end
+      end
+
+      attr_reader :account_number, :description, :margin_calculation_type, :option_level,
+                  :margin_requirement, :margin_requirement_effect,
+                  :initial_requirement, :initial_requirement_effect,
+                  :maintenance_requirement, :maintenance_requirement_effect,
+                  :margin_excess, :margin_excess_effect, :buying_power, :buying_power_effect,
+                  :last_state_timestamp, :groups
+
+      # Get the margin requirements for an account
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param account_number [String] Account number
+      # @return [MarginRequirements] Margin report
+      def self.get(session, account_number)
+        response = session.get("/margin/accounts/#{account_number}/requirements")
+        new(response["data"])
+      end
+
+      # Sum of the maintenance requirement across the top-level groups
+      #
+      # @return [BigDecimal] Total maintenance requirement
+      def total_maintenance_requirement
+        @groups.sum(BigDecimal("0")) { |group| group.maintenance_requirement || BigDecimal("0") }
+      end
+
+      # @param underlying_symbol [String] Underlying symbol
+      # @return [Group, nil] The top-level group for the underlying
+      def group_for(underlying_symbol)
+        @groups.find { |group| group.underlying_symbol == underlying_symbol }
+      end
+
+      private
+
+      def parse_attributes
+        @account_number = @data["account-number"]
+        @description = @data["description"]
+        @margin_calculation_type = @data["margin-calculation-type"]
+        @option_level = @data["option-level"]
+        @margin_requirement = parse_decimal(@data["margin-requirement"])
+        @margin_requirement_effect = @data["margin-requirement-effect"]
+        @initial_requirement = parse_decimal(@data["initial-requirement"])
+        @initial_requirement_effect = @data["initial-requirement-effect"]
+        @maintenance_requirement = parse_decimal(@data["maintenance-requirement"])
+        @maintenance_requirement_effect = @data["maintenance-requirement-effect"]
+        @margin_excess = parse_decimal(@data["margin-excess"])
+        @margin_excess_effect = @data["margin-excess-effect"]
+        @buying_power = parse_decimal(@data["buying-power"])
+        @buying_power_effect = @data["buying-power-effect"]
+        @last_state_timestamp = parse_timestamp(@data["last-state-timestamp"])
+        @groups = Array(@data["groups"]).map { |group| Group.new(group) }
+      end
+
+      # Last state timestamps are epoch milliseconds
+      def parse_timestamp(value)
+        return nil if value.nil? || value.to_s.empty?
+        return Time.at(value / 1000.0).utc if value.is_a?(Numeric)
+
+        parse_time(value.to_s)
+      end
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
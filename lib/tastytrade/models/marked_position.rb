@@ -0,0 +1,103 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "forwardable"
+
+module Tastytrade
+  module Models
+    # A position valued at a current quote
+    #
+    # {CurrentPosition} carries the previous close and the average open price;
+    # this adds the live mark, market value and unrealized P&L. Values are in
+    # dollars, so an option contract counts its multiplier (usually 100) and a
+    # share counts once. Market value is negative for short positions. When no
+    # quote is available the computed values are nil.
+    #
+    # @example
+    #   account.get_marked_positions(session).each do |position|
+    #     puts "#{position.symbol}: #{position.market_value&.to_s("F")} (#{position.unrealized_pnl&.to_s("F")})"
+    #   end
+    class MarkedPosition
+      extend Forwardable
+
+      # Quote instrument types for position instrument types
+      QUOTE_INSTRUMENT_TYPES = {
+        "Equity" => :equity,
+        "Equity Option" => :option,
+        "Future" => :future,
+        "Future Option" => :future_option,
+        "Cryptocurrency" => :cryptocurrency
+      }.freeze
+
+      def_delegators :@position, :account_number, :symbol, :instrument_type, :underlying_symbol,
+                     :quantity, :quantity_direction, :average_open_price, :close_price, :multiplier,
+                     :long?, :short?, :option?, :equity?, :display_symbol
+
+      # @return [CurrentPosition] Position as returned by the API
+      attr_reader :position
+
+      # @return [Quote, nil] Quote the position is valued at
+      attr_reader :quote
+
+      # Value positions at current quotes
+      #
+      # Quotes are fetched once per instrument type. A symbol the API cannot
+      # quote leaves only its own position unvalued.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param positions [Array<CurrentPosition>] Positions to value
+      # @return [Array<MarkedPosition>] Open positions with their quotes
+      def self.mark(session, positions)
+        positions = positions.reject(&:closed?)
+        quotes = {}
+        positions.group_by { |position| QUOTE_INSTRUMENT_TYPES[position.instrument_type] }.each do |type, group|
+          next unless type
+
+          found, _errors = Quote.get_all_resilient(session, group.map(&:symbol), instrument_type: type)
+          quotes.merge!(found)
+        end
+
+        positions.map { |position| new(position, quotes[position.symbol]) }
+      end
+
+      # @param position [CurrentPosition] Position as returned by the API
+      # @param quote [Quote, nil] Current quote for the position's symbol
+      def initialize(position, quote)
+        @position = position
+        @quote = quote
+      end
+
+      # @return [BigDecimal, nil] Quote mark, falling back to the midpoint and then the last trade
+      def mark
+        return nil unless @quote
+
+        @quote.mark || @quote.mid || @quote.last
+      end
+
+      # @return [BigDecimal, nil] Current value, negative for short positions
+      def market_value
+        return nil unless mark
+
+        direction * mark * quantity.abs * multiplier
+      end
+
+      # @return [BigDecimal, nil] Current value less the cost of opening the position
+      def unrealized_pnl
+        return nil unless mark
+
+        direction * (mark - average_open_price) * quantity.abs * multiplier
+      end
+
+      # @return [Boolean] true if a quote was found for the position
+      def marked?
+        !mark.nil?
+      end
+
+      private
+
+      def direction
+        short? ? -1 : 1
+      end
+    end
+  end
+end
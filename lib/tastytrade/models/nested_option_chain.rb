@@ -34,6 +34,11 @@ module Tastytrade
           @put_streamer_symbol = data["put-streamer-symbol"] || data["put_streamer_symbol"]
         end
 
+        # @return [Array<String>] Market data symbols of the call and put, e.g. for {Greeks.get_all}
+        def streamer_symbols
+          [@call_streamer_symbol, @put_streamer_symbol].compact
+        end
+
         private
 
         def parse_financial_value(value)
@@ -67,11 +72,36 @@ module Tastytrade
           @expiration_type == "Regular"
         end
 
+        # @return [Boolean] true if the expiration settles at the opening prints
+        def am_settled?
+          @settlement_type == Option::AM_SETTLED
+        end
+
+        # @return [Boolean] true if the expiration settles at the close
+        def pm_settled?
+          @settlement_type == Option::PM_SETTLED
+        end
+
         # @return [Boolean] true if this is a quarterly expiration
         def quarterly?
           @expiration_type == "Quarterly"
         end
 
+        # @return [Array<String>] Market data symbols of every call and put in this expiration
+        def streamer_symbols
+          @strikes.flat_map(&:streamer_symbols)
+        end
+
+        # Returns a copy of this expiration limited to the given strikes
+        #
+        # @param strikes [Array<Strike>] Strikes to keep
+        # @return [Expiration]
+        def with_strikes(strikes)
+          copy = dup
+          copy.instance_variable_set(:@strikes, strikes)
+          copy
+        end
+
         private
 
         def parse_date(value)
@@ -86,6 +116,41 @@ module Tastytrade
         end
       end
 
+      # Open interest and volume for one strike, combining its call and put
+      StrikeLiquidity = Struct.new(:strike_price, :call, :put, :call_open_interest, :put_open_interest,
+                                   :call_volume, :put_volume, keyword_init: true) do
+        # @return [Integer] Combined call and put open interest
+        def open_interest
+          call_open_interest.to_i + put_open_interest.to_i
+        end
+
+        # @return [Integer] Combined call and put volume
+        def volume
+          call_volume.to_i + put_volume.to_i
+        end
+      end
+
+      # A strike with its call and put quotes and greeks, as a chain view shows it.
+      # Prices and greeks are nil for a contract the snapshot did not cover.
+      EnrichedStrike = Struct.new(:strike_price, :call, :put, :call_bid, :call_ask, :put_bid, :put_ask,
+                                  :call_greeks, :put_greeks, keyword_init: true) do
+        # @return [BigDecimal, nil] Call delta
+        def call_delta
+          call_greeks&.delta
+        end
+
+        # @return [BigDecimal, nil] Put delta, negative
+        def put_delta
+          put_greeks&.delta
+        end
+      end
+
+      # Sort keys accepted by {#strikes_by_liquidity}
+      LIQUIDITY_SORTS = %i[open_interest volume strike].freeze
+
+      # Expiration types accepted by {#filter} and {.active_expirations}
+      EXPIRATION_TYPES = %w[Regular Weekly Quarterly End-Of-Month].freeze
+
       def initialize(data)
         super
       end
@@ -102,8 +167,7 @@ module Tastytrade
         # @example
         #   chain = NestedOptionChain.get(session, "SPY")
         def get(session, symbol, **options)
-          params = options.merge(symbol: symbol)
-          response = session.get("/option-chains/#{symbol}/nested", params: params)
+          response = fetch(session, symbol, **options)
 
           # The API returns data.items array with a single item containing the full chain
           if response["data"] && response["data"]["items"] && response["data"]["items"].first
@@ -112,6 +176,108 @@ module Tastytrade
             new(response["data"] || {})
           end
         end
+
+        # Retrieves the chain for an underlying and enriches one expiration's
+        # strikes with quotes and greeks
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @param expiration_date [Date] The expiration date
+        # @param options [Hash] Options for {#enriched_strikes}
+        # @return [Array<EnrichedStrike>] Strikes in ascending order
+        #
+        # @example
+        #   NestedOptionChain.get_enriched_strikes(session, "SPY", Date.parse("2024-03-15")).each do |strike|
+        #     puts "#{strike.strike_price.to_s("F")}: #{strike.call_bid&.to_s("F")} / #{strike.call_delta&.to_s("F")}"
+        #   end
+        def get_enriched_strikes(session, symbol, expiration_date, **options)
+          get(session, symbol).enriched_strikes(session, expiration_date, **options)
+        end
+
+        # Retrieves one nested chain per option root for an underlying
+        #
+        # Index underlyings list several roots (SPX and SPXW, NDX and NDXP), each
+        # returned as a separate chain.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @param options [Hash] Additional query parameters
+        # @return [Array<NestedOptionChain>] One chain per root symbol
+        #
+        # @example
+        #   NestedOptionChain.get_all(session, "SPX").map(&:root_symbol)  # => ["SPX", "SPXW"]
+        def get_all(session, symbol, **options)
+          response = fetch(session, symbol, **options)
+          items = response.dig("data", "items")
+          return [new(response["data"] || {})] unless items
+
+          items.map { |item| new(item) }
+        end
+
+        # Retrieves the nested chain for a specific option root
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param root_symbol [String] Option root, e.g. "SPXW" for SPX weeklies
+        # @param underlying_symbol [String, nil] Underlying to query (defaults to the root)
+        # @param options [Hash] Additional query parameters
+        # @return [NestedOptionChain, nil] Chain for the root, or nil if the underlying has no such root
+        #
+        # @example Target SPX weeklies
+        #   chain = NestedOptionChain.get_by_root(session, "SPXW", underlying_symbol: "SPX")
+        def get_by_root(session, root_symbol, underlying_symbol: nil, **options)
+          chains = get_all(session, underlying_symbol || root_symbol, **options)
+          chains.find { |chain| chain.root_symbol == root_symbol }
+        end
+
+        # Retrieves the nested chain and narrows it with {#filter}
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @param filter [Hash] Options for {#filter}
+        # @return [NestedOptionChain] Filtered chain
+        #
+        # @example Five expirations within 45 days, ten strikes around $450
+        #   NestedOptionChain.get_filtered(session, "SPY", max_dte: 45, expirations_limit: 5,
+        #                                                  strikes_per_expiration: 10, reference_price: 450)
+        def get_filtered(session, symbol, **filter)
+          get(session, symbol).filter(**filter)
+        end
+
+        # Retrieves the expirations currently listed for an underlying
+        #
+        # Expirations from every option root are combined, so SPX returns both
+        # its monthly SPX and its SPXW expirations.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @param expiration_type [String, Array<String>, nil] Types to keep, e.g. "Weekly"; all when nil
+        # @param min_dte [Integer, nil] Minimum days to expiration
+        # @param max_dte [Integer, nil] Maximum days to expiration
+        # @return [Array<Expiration>] Matching expirations in chronological order
+        # @raise [ArgumentError] if an expiration type is not one of {EXPIRATION_TYPES}
+        #
+        # @example Monthly and quarterly expirations in the next 90 days
+        #   NestedOptionChain.active_expirations(session, "SPY", expiration_type: %w[Regular Quarterly], max_dte: 90)
+        def active_expirations(session, symbol, expiration_type: nil, min_dte: nil, max_dte: nil)
+          chains = get_all(session, symbol)
+          expirations = chains.flat_map do |chain|
+            chain.filter(expiration_type: expiration_type, min_dte: min_dte, max_dte: max_dte).expirations
+          end
+          expirations.sort_by { |exp| exp.expiration_date&.jd || Float::INFINITY }
+        end
+
+        private
+
+        def fetch(session, symbol, **options)
+          symbol = Tastytrade.normalize_symbol(symbol, session)
+          params = options.merge(symbol: symbol)
+          session.get("/option-chains/#{symbol}/nested", params: params)
+        end
+      end
+
+      # @return [Boolean] true if the chain's contracts settle in cash, e.g. SPX and SPXW
+      def cash_settled?
+        Option::CASH_SETTLED_ROOTS.include?(@root_symbol)
       end
 
       # Returns all expiration dates in chronological order
@@ -182,6 +348,47 @@ module Tastytrade
         create_filtered_chain(filtered_expirations)
       end
 
+      # Narrows the chain in memory to the expirations and strikes of interest
+      #
+      # Expirations are filtered by type and days to expiration, then the earliest
+      # expirations_limit are kept. Each keeps the strikes_per_expiration strikes
+      # closest to reference_price, or its middle strikes without one.
+      #
+      # @param expirations_limit [Integer, nil] Maximum number of expirations
+      # @param strikes_per_expiration [Integer, nil] Maximum strikes per expiration
+      # @param min_dte [Integer, nil] Minimum days to expiration
+      # @param max_dte [Integer, nil] Maximum days to expiration
+      # @param expiration_type [String, Array<String>, nil] Types to keep, e.g. "Regular"; all when nil
+      # @param reference_price [BigDecimal, Numeric, nil] Price to center strikes on,
+      #   typically the underlying's last price
+      # @return [NestedOptionChain] New chain; this chain is unchanged
+      # @raise [ArgumentError] if an expiration type is not one of {EXPIRATION_TYPES}
+      #
+      # @example
+      #   chain.filter(max_dte: 45, strikes_per_expiration: 10, reference_price: BigDecimal("450"))
+      # @example Weekly expirations only
+      #   chain.filter(expiration_type: "Weekly", max_dte: 14)
+      def filter(expirations_limit: nil, strikes_per_expiration: nil, min_dte: nil, max_dte: nil,
+                 expiration_type: nil, reference_price: nil)
+        expirations = @expirations
+        expirations = filter_by_dte(min_dte: min_dte, max_dte: max_dte).expirations if min_dte || max_dte
+        if expiration_type
+          types = expiration_types(expiration_type)
+          expirations = expirations.select { |exp| types.include?(exp.expiration_type) }
+        end
+        expirations = expirations.sort_by { |exp| exp.expiration_date&.jd || Float::INFINITY }
+        expirations = expirations.first(expirations_limit) if expirations_limit
+
+        if strikes_per_expiration
+          reference_price = BigDecimal(reference_price.to_s) if reference_price
+          expirations = expirations.map do |exp|
+            exp.with_strikes(select_strikes(exp.strikes, strikes_per_expiration, reference_price))
+          end
+        end
+
+        create_filtered_chain(expirations)
+      end
+
       # Returns the expiration closest to today's date
       #
       # @return [Expiration, nil] The nearest Expiration object
@@ -248,8 +455,173 @@ module Tastytrade
         { call: strike.call, put: strike.put }
       end
 
+      # Returns an expiration's strikes annotated with open interest and volume
+      #
+      # Per-contract data comes from the option instruments endpoint unless a
+      # market_data hash is supplied (for example from a quote snapshot).
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param expiration_date [Date] The expiration date
+      # @param sort_by [Symbol] :open_interest or :volume (most liquid first), or :strike
+      # @param option_type [Symbol, nil] :call or :put to rank by one side only
+      # @param market_data [Hash{String => #open_interest}, nil] Per-symbol open interest and volume
+      # @return [Array<StrikeLiquidity>] Strikes ranked by liquidity
+      # @raise [ArgumentError] if sort_by or option_type is invalid
+      #
+      # @example Most liquid puts for an expiration
+      #   chain.strikes_by_liquidity(session, Date.parse("2024-03-15"), option_type: :put).first(5)
+      def strikes_by_liquidity(session, expiration_date, sort_by: :open_interest, option_type: nil, market_data: nil)
+        unless LIQUIDITY_SORTS.include?(sort_by)
+          raise ArgumentError, "Invalid sort: #{sort_by}. Must be one of: #{LIQUIDITY_SORTS.join(", ")}"
+        end
+        unless option_type.nil? || %i[call put].include?(option_type)
+          raise ArgumentError, "Invalid option type: #{option_type}. Must be :call or :put"
+        end
+
+        strikes = strikes_for_expiration(expiration_date)
+        return [] if strikes.empty?
+
+        market_data ||= fetch_market_data(session, strikes)
+        ranked = strikes.map { |strike| build_strike_liquidity(strike, market_data) }
+
+        return ranked.sort_by(&:strike_price) if sort_by == :strike
+
+        ranked.sort_by { |strike| [-liquidity_value(strike, sort_by, option_type), strike.strike_price] }
+      end
+
+      # Strikes of an expiration with their call and put quotes and greeks
+      #
+      # Quotes come from the market data endpoint and greeks from a
+      # {Greeks.get_all} snapshot unless they are supplied. A contract missing
+      # from either leaves its fields nil rather than failing the strike.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param expiration_date [Date] The expiration date
+      # @param quotes [Hash{String => Quote}, nil] Quotes keyed by option symbol
+      # @param greeks [Hash{String => Greeks}, nil] Greeks keyed by streamer symbol
+      # @param greeks_options [Hash] Options for {Greeks.get_all}, e.g. timeout:
+      # @return [Array<EnrichedStrike>] Strikes in ascending order, empty for an unknown expiration
+      # @raise [StreamError] if the streamer cannot be reached for greeks
+      def enriched_strikes(session, expiration_date, quotes: nil, greeks: nil, **greeks_options)
+        strikes = strikes_for_expiration(expiration_date).select(&:strike_price).sort_by(&:strike_price)
+        return [] if strikes.empty?
+
+        symbols = strikes.flat_map { |strike| [strike.call, strike.put] }.compact
+        quotes ||= Quote.get_all_resilient(session, symbols, instrument_type: :option).first
+        greeks ||= Greeks.get_all(session, strikes.flat_map(&:streamer_symbols), **greeks_options)
+
+        strikes.map { |strike| build_enriched_strike(strike, quotes, greeks) }
+      end
+
+      # Finds the contract whose delta is closest to a target
+      #
+      # Greeks come from a {Greeks.get_all} snapshot of the expiration unless a
+      # greeks hash is supplied. Put deltas are negative; the target is compared
+      # by magnitude, so -0.30 and 0.30 both select the 30 delta put.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param expiration_date [Date] The expiration date
+      # @param option_type [Symbol] :call or :put
+      # @param target_delta [BigDecimal, Numeric] Delta to match, between -1 and 1
+      # @param greeks [Hash{String => #delta}, nil] Greeks keyed by streamer symbol
+      # @param greeks_options [Hash] Options for {Greeks.get_all}, e.g. timeout:
+      # @return [Option, nil] The closest contract, or nil if no strike has a delta
+      # @raise [ArgumentError] if option_type or target_delta is invalid
+      #
+      # @example 30 delta put
+      #   chain.find_option_by_delta(session, Date.parse("2024-03-15"), :put, -0.30)
+      def find_option_by_delta(session, expiration_date, option_type, target_delta, greeks: nil, **greeks_options)
+        unless %i[call put].include?(option_type)
+          raise ArgumentError, "Invalid option type: #{option_type}. Must be :call or :put"
+        end
+
+        target = BigDecimal(target_delta.to_s).abs
+        raise ArgumentError, "Target delta must be between -1 and 1" if target > 1
+
+        strikes = strikes_for_expiration(expiration_date).select { |strike| strike.public_send(option_type) }
+        return nil if strikes.empty?
+
+        streamer_symbol = :"#{option_type}_streamer_symbol"
+        greeks ||= Greeks.get_all(session, strikes.filter_map(&streamer_symbol), **greeks_options)
+        deltas = strikes.to_h { |strike| [strike, greeks[strike.public_send(streamer_symbol)]&.delta] }
+        best = deltas.reject { |_, delta| delta.nil? }.min_by do |strike, delta|
+          [(delta.abs - target).abs, strike.strike_price]
+        end
+        return nil unless best
+
+        Option.get(session, best.first.public_send(option_type)).first
+      end
+
       private
 
+      def expiration_types(expiration_type)
+        types = Array(expiration_type)
+        invalid = types - EXPIRATION_TYPES
+        unless invalid.empty?
+          raise ArgumentError,
+                "Invalid expiration type: #{invalid.join(", ")}. Must be one of: #{EXPIRATION_TYPES.join(", ")}"
+        end
+
+        types
+      end
+
+      def select_strikes(strikes, count, reference_price)
+        sorted = strikes.select(&:strike_price).sort_by(&:strike_price)
+        return sorted if sorted.length <= count
+
+        if reference_price
+          sorted.min_by(count) { |strike| [(strike.strike_price - reference_price).abs, strike.strike_price] }
+                .sort_by(&:strike_price)
+        else
+          sorted[(sorted.length - count) / 2, count]
+        end
+      end
+
+      def fetch_market_data(session, strikes)
+        symbols = strikes.flat_map { |strike| [strike.call, strike.put] }.compact
+        Option.get(session, symbols).to_h { |option| [option.symbol, option] }
+      end
+
+      def build_strike_liquidity(strike, market_data)
+        call_data = market_data[strike.call]
+        put_data = market_data[strike.put]
+
+        StrikeLiquidity.new(
+          strike_price: strike.strike_price,
+          call: strike.call,
+          put: strike.put,
+          call_open_interest: call_data&.open_interest,
+          put_open_interest: put_data&.open_interest,
+          call_volume: call_data&.volume,
+          put_volume: put_data&.volume
+        )
+      end
+
+      def build_enriched_strike(strike, quotes, greeks)
+        call_quote = quotes[strike.call]
+        put_quote = quotes[strike.put]
+
+        EnrichedStrike.new(
+          strike_price: strike.strike_price,
+          call: strike.call,
+          put: strike.put,
+          call_bid: call_quote&.bid,
+          call_ask: call_quote&.ask,
+          put_bid: put_quote&.bid,
+          put_ask: put_quote&.ask,
+          call_greeks: greeks[strike.call_streamer_symbol],
+          put_greeks: greeks[strike.put_streamer_symbol]
+        )
+      end
+
+      def liquidity_value(strike, sort_by, option_type)
+        case option_type
+        when :call then strike.public_send("call_#{sort_by}").to_i
+        when :put then strike.public_send("put_#{sort_by}").to_i
+        else strike.public_send(sort_by)
+        end
+      end
+
       def create_filtered_chain(filtered_expirations)
         # Create a new chain with filtered expirations
         filtered_data = @data.dup
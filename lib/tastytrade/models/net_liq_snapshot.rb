@@ -0,0 +1,60 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # A point on an account's net liquidating value history
+    #
+    # Each snapshot holds open, high, low and close values for its period, both
+    # for the total net liquidating value and for pending cash.
+    class NetLiqSnapshot < Base
+      # Accepted time-back values for the history request
+      TIME_BACK_VALUES = %w[1d 1m 3m 6m 1y all].freeze
+
+      attr_reader :open, :high, :low, :close,
+                  :pending_cash_open, :pending_cash_high, :pending_cash_low, :pending_cash_close,
+                  :total_open, :total_high, :total_low, :total_close, :time
+
+      # Get the net liquidating value history for an account
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param account_number [String] Account number
+      # @param time_back [String] Period to fetch, one of TIME_BACK_VALUES
+      # @return [Array<NetLiqSnapshot>] Snapshots, oldest first
+      # @raise [ArgumentError] if time_back is not an accepted value
+      def self.get_history(session, account_number, time_back: "1y")
+        unless TIME_BACK_VALUES.include?(time_back)
+          raise ArgumentError, "Invalid time back: #{time_back}. Must be one of: #{TIME_BACK_VALUES.join(", ")}"
+        end
+
+        response = session.get("/accounts/#{account_number}/net-liq/history", { "time-back" => time_back })
+        items = response["data"].is_a?(Hash) ? response.dig("data", "items") : response["data"]
+        (items || []).map { |item| new(item) }.sort_by { |snapshot| snapshot.time || Time.at(0) }
+      end
+
+      private
+
+      def parse_attributes
+        @open = parse_decimal(@data["open"])
+        @high = parse_decimal(@data["high"])
+        @low = parse_decimal(@data["low"])
+        @close = parse_decimal(@data["close"])
+        @pending_cash_open = parse_decimal(@data["pending-cash-open"])
+        @pending_cash_high = parse_decimal(@data["pending-cash-high"])
+        @pending_cash_low = parse_decimal(@data["pending-cash-low"])
+        @pending_cash_close = parse_decimal(@data["pending-cash-close"])
+        @total_open = parse_decimal(@data["total-open"])
+        @total_high = parse_decimal(@data["total-high"])
+        @total_low = parse_decimal(@data["total-low"])
+        @total_close = parse_decimal(@data["total-close"])
+        @time = parse_time(@data["time"])
+      end
+
+      def parse_decimal(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -56,6 +56,18 @@ module Tastytrade
       # @return [Array<String>] Valid exercise styles
       EXERCISE_STYLES = [AMERICAN, EUROPEAN].freeze
 
+      # Settlement times
+      # @return [String] Settled at the opening prints on expiration day
+      AM_SETTLED = "AM"
+      # @return [String] Settled at the close on expiration day
+      PM_SETTLED = "PM"
+      # @return [String] Settled in cash
+      CASH_SETTLED = "Cash"
+
+      # Index option roots settled in cash rather than by delivering shares
+      # @return [Array<String>] Cash-settled option roots
+      CASH_SETTLED_ROOTS = %w[SPX SPXW XSP NDX NDXP XND RUT RUTW MRUT VIX VIXW OEX XEO DJX].freeze
+
       # Class methods for API integration
       class << self
         # Search for specific option contracts by symbols
@@ -113,12 +125,41 @@ module Tastytrade
         #   options = Option.get(session, "SPY240315C00450000")
         #   multiple = Option.get(session, ["SPY240315C00450000", "SPY240315P00450000"])
         def get(session, symbols, **options)
-          symbols = Array(symbols)
+          symbols = Array(symbols).map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
           params = options.merge(symbols: symbols.join(","))
           response = session.get("/instruments/options", params: params)
           response["data"]["items"].map { |item| new(item) }
         end
 
+        # Retrieves an option by its underlying, expiration, type and strike
+        #
+        # The OCC symbol is built with {OptionSymbol.format}, so callers don't
+        # pad roots and strikes by hand.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param underlying [String] Option root, e.g. "AAPL" or "SPXW"
+        # @param expiration [Date, String] Expiration date, a Date or "YYYY-MM-DD"
+        # @param option_type [String] "C" or "P"; "Call" and "Put" are also accepted
+        # @param strike_price [BigDecimal, Numeric, String] Strike price
+        # @return [Option, nil] The option, or nil if no such contract is listed
+        # @raise [ArgumentError] if a component is invalid
+        #
+        # @example
+        #   Option.get_by_components(session, underlying: "AAPL", expiration: "2024-03-15",
+        #                                     option_type: "C", strike_price: 172.5)
+        def get_by_components(session, underlying:, expiration:, option_type:, strike_price:)
+          unless %w[C P CALL PUT].include?(option_type.to_s.upcase)
+            raise ArgumentError, "Option type must be C or P: #{option_type.inspect}"
+          end
+
+          strike = BigDecimal(strike_price.to_s, exception: false)
+          raise ArgumentError, "Strike price must be positive: #{strike_price.inspect}" unless strike&.positive?
+
+          symbol = OptionSymbol.format(underlying: underlying, expiration: parse_expiration(expiration),
+                                       option_type: option_type, strike_price: strike)
+          get(session, symbol).first
+        end
+
         # Convert OCC symbol to streamer format
         #
         # @param occ_symbol [String] OCC format symbol (e.g., "SPY240315C00450000")
@@ -171,6 +212,16 @@ module Tastytrade
           # Pad strike to 8 digits
           "#{root}#{date}#{type}#{strike.to_s.rjust(8, "0")}"
         end
+
+        private
+
+        def parse_expiration(expiration)
+          return expiration if expiration.is_a?(Date)
+
+          Date.strptime(expiration.to_s, "%Y-%m-%d")
+        rescue Date::Error
+          raise ArgumentError, "Expiration must be a Date or YYYY-MM-DD: #{expiration.inspect}"
+        end
       end
 
       # Instance methods
@@ -185,23 +236,74 @@ module Tastytrade
         @option_type == PUT
       end
 
+      # Index options such as SPX and NDX settle in cash; equity and ETF options
+      # deliver shares. The settlement type usually gives the settlement time
+      # rather than the method, so the root is checked as well.
+      #
+      # @return [Boolean] true if the contract is settled in cash
+      def cash_settled?
+        @settlement_type == CASH_SETTLED || CASH_SETTLED_ROOTS.include?(@root_symbol)
+      end
+
+      # @return [Boolean] true if the contract settles at the opening prints, e.g. monthly SPX
+      def am_settled?
+        @settlement_type == AM_SETTLED
+      end
+
+      # @return [Boolean] true if the contract settles at the close, e.g. SPXW
+      def pm_settled?
+        @settlement_type == PM_SETTLED
+      end
+
+      # Builds an order leg for this contract
+      #
+      # Index options trade under the same instrument type as equity options.
+      #
+      # @param action [String] OrderAction constant
+      # @param quantity [Integer] Number of contracts
+      # @return [OrderLeg] Order leg
+      #
+      # @example
+      #   leg = option.to_order_leg(action: OrderAction::SELL_TO_OPEN, quantity: 1)
+      def to_order_leg(action:, quantity:)
+        # Instruments pad option roots to six characters; order legs use a single space
+        OrderLeg.new(action: action, symbol: @symbol.gsub(/\s+/, " "), quantity: quantity, instrument_type: "Option")
+      end
+
       # Checks if the option has expired
       #
+      # @param today [Date, Time] Current date, or a time taken as its US/Eastern date
       # @return [Boolean] true if expiration date is in the past, false otherwise
-      def expired?
+      def expired?(today = Date.today)
         return false if @expiration_date.nil?
 
-        @expiration_date < Date.today
+        @expiration_date < eastern_date(today)
       end
 
-      # Calculates days remaining until expiration
+      # Calculates calendar days remaining until expiration
       #
+      # @param today [Date, Time] Current date, or a time taken as its US/Eastern date
       # @return [Integer, nil] Number of days until expiration, 0 if expired, nil if no expiration date
-      def days_until_expiration
-        return 0 if expired?
+      #
+      # @example Friday expiration checked on Saturday at 01:00 UTC, still Friday evening in New York
+      #   option.days_until_expiration(Time.utc(2024, 3, 16, 1))  # => 0
+      def days_until_expiration(today = Date.today)
+        return 0 if expired?(today)
         return nil if @expiration_date.nil?
 
-        (@expiration_date - Date.today).to_i
+        (@expiration_date - eastern_date(today)).to_i
+      end
+
+      # Counts the trading sessions left before expiration, skipping weekends and
+      # market holidays; today is not counted, the expiration day is
+      #
+      # @param today [Date, Time] Current date, or a time taken as its US/Eastern date
+      # @return [Integer, nil] Remaining trading days, 0 if expired, nil if no expiration date
+      def trading_days_until_expiration(today = Date.today)
+        return nil if @expiration_date.nil?
+        return 0 if expired?(today)
+
+        ((eastern_date(today) + 1)..@expiration_date).count { |date| MarketHours.trading_day?(date) }
       end
 
       # Checks if option is in-the-money
@@ -367,6 +469,10 @@ module Tastytrade
         BigDecimal(value.to_s)
       end
 
+      def eastern_date(today)
+        today.is_a?(Time) ? MarketHours.eastern_time(today).to_date : today
+      end
+
       def parse_date(value)
         return nil if value.nil? || value.to_s.empty?
 
@@ -389,6 +495,7 @@ module Tastytrade
           option_type: option_type,
           strike_price: strike_price,
           expiration_date: expiration_date,
+          settlement_type: settlement_type,
           days_to_expiration: days_to_expiration,
           bid: bid,
           ask: ask,
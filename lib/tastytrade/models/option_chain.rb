@@ -22,6 +22,9 @@ module Tastytrade
     #   # Filter by strikes around ATM
     #   focused = chain.filter_by_strikes(5, current_price)  # 5 strikes centered on ATM
     class OptionChain < Base
+      # Option symbols requested per instruments call, keeping the query string short
+      SYMBOLS_PER_REQUEST = 100
+
       attr_reader :underlying_symbol, :root_symbol, :option_chain_type,
                   :shares_per_contract, :tick_sizes, :deliverables
 
@@ -45,6 +48,7 @@ module Tastytrade
         #   chain = OptionChain.get_chain(session, "SPY")
         #   chain = OptionChain.get_chain(session, "AAPL", strikes: 10)
         def get_chain(session, symbol, **options)
+          symbol = Tastytrade.normalize_symbol(symbol, session)
           params = options.merge(symbol: symbol)
           response = session.get("/option-chains/#{symbol}/compact", params: params)
 
@@ -78,6 +82,48 @@ module Tastytrade
             new(response["data"] || {})
           end
         end
+
+        # Yields every contract in an underlying's chain as it is read
+        #
+        # Unlike {.get_chain}, the response is parsed while it streams in and
+        # no chain is built, so memory stays flat even for the thousands of
+        # contracts of an underlying like SPY.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @yieldparam option [Option] Each contract
+        # @return [Enumerator<Option>] if no block is given
+        #
+        # @example Count contracts without loading the chain
+        #   OptionChain.each_option(session, "SPY").count
+        def each_option(session, symbol)
+          return enum_for(:each_option, session, symbol) unless block_given?
+
+          session.get_items("/option-chains/#{symbol}") { |item| yield Option.new(item) }
+        end
+
+        # Retrieves the options for a single expiration
+        #
+        # The full chain for an underlying like SPY holds thousands of contracts.
+        # This looks up the expiration's symbols in the nested chain, which has no
+        # per-contract details, and fetches only those contracts.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] Underlying symbol
+        # @param expiration_date [Date, String] Expiration date, a Date or "YYYY-MM-DD"
+        # @return [Array<Option>] Calls and puts for the expiration, empty if the
+        #   underlying has no options expiring that day
+        #
+        # @example
+        #   options = OptionChain.get_for_expiration(session, "SPY", "2024-03-15")
+        def get_for_expiration(session, symbol, expiration_date)
+          date = expiration_date.is_a?(Date) ? expiration_date : Date.parse(expiration_date.to_s)
+          expiration = NestedOptionChain.get(session, symbol).find_expiration(date)
+          return [] unless expiration
+
+          symbols = expiration.strikes.flat_map { |strike| [strike.call, strike.put] }.compact
+          symbols.each_slice(SYMBOLS_PER_REQUEST).flat_map { |batch| Option.get(session, batch) }
+        end
       end
 
       # Returns all expiration dates in chronological order
@@ -323,38 +369,24 @@ module Tastytrade
             next unless symbol.is_a?(String)
 
             # Parse the OCC symbol format: SPY   250811C00400000
-            # Format: ROOT YYMMDD[C/P]SSSSSCCC
-            if symbol =~ /^(\S+)\s+(\d{6})([CP])(\d{8})$/
-              root = $1
-              date_str = $2
-              option_type = $3 == "C" ? "Call" : "Put"
-              strike_str = $4
-
-              # Parse date (YYMMDD format)
-              year = "20" + date_str[0..1]
-              month = date_str[2..3]
-              day = date_str[4..5]
-              exp_date = Date.parse("#{year}-#{month}-#{day}") rescue nil
-
-              # Parse strike (format: SSSSSCCC where last 3 are decimals)
-              strike_price = (strike_str.to_i / 1000.0).to_s
-
-              if exp_date
-                # Create minimal option data
-                option_data = {
-                  "symbol" => symbol.strip,
-                  "root-symbol" => root,
-                  "underlying-symbol" => @underlying_symbol,
-                  "option-type" => option_type,
-                  "expiration-date" => exp_date.to_s,
-                  "strike-price" => strike_price
-                }
-
-                option = Option.new(option_data)
-                @expirations[exp_date] ||= []
-                @expirations[exp_date] << option
-              end
-            end
+            next unless OptionSymbol.valid?(symbol)
+
+            parsed = OptionSymbol.parse(symbol)
+            exp_date = parsed.expiration
+
+            # Create minimal option data
+            option_data = {
+              "symbol" => symbol.strip,
+              "root-symbol" => parsed.underlying,
+              "underlying-symbol" => @underlying_symbol,
+              "option-type" => parsed.call? ? "Call" : "Put",
+              "expiration-date" => exp_date.to_s,
+              "strike-price" => parsed.strike_price.to_s("F")
+            }
+
+            option = Option.new(option_data)
+            @expirations[exp_date] ||= []
+            @expirations[exp_date] << option
           end
         else
           # Original logic for full option data
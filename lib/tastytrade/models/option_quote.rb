@@ -0,0 +1,107 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "forwardable"
+
+module Tastytrade
+  module Models
+    # An option contract combined with a one-shot quote and greeks snapshot
+    #
+    # Contract details (strike, expiration, type) come from the instrument; bid,
+    # ask and greeks come from a market data snapshot taken at fetch time. When
+    # the contract has no live market the snapshot fields are nil.
+    #
+    # @example
+    #   quote = OptionQuote.get(session, "SPY 240315C00450000")
+    #   puts "#{quote.display_symbol} #{quote.bid}/#{quote.ask} delta #{quote.delta}" if quote.live?
+    class OptionQuote
+      extend Forwardable
+
+      SNAPSHOT_FIELDS = %i[bid ask mark last delta gamma theta vega rho implied_volatility].freeze
+
+      def_delegators :@option, :symbol, :streamer_symbol, :underlying_symbol, :root_symbol,
+                     :option_type, :call?, :put?, :strike_price, :expiration_date,
+                     :days_until_expiration, :trading_days_until_expiration, :expired?, :display_symbol
+
+      # @return [Option] Static contract data
+      attr_reader :option
+
+      attr_reader(*SNAPSHOT_FIELDS)
+
+      class << self
+        # Fetch an option instrument and take a quote and greeks snapshot for it
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbol [String] OCC option symbol
+        # @param snapshot_source [#call, nil] Callable taking an Option and returning a
+        #   Hash of snapshot fields (defaults to the market data endpoint)
+        # @return [OptionQuote, nil] Enriched option or nil if the contract is unknown
+        def get(session, symbol, snapshot_source: nil)
+          option = Option.get(session, symbol).first
+          return nil unless option
+
+          snapshot = snapshot_source ? snapshot_source.call(option) : fetch_snapshot(session, option)
+          new(option, snapshot || {})
+        end
+
+        # Request a market data snapshot for an option
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param option [Option] Option contract
+        # @return [Hash, nil] Snapshot data or nil if the contract has no market data
+        def fetch_snapshot(session, option)
+          response = session.get("/market-data/by-type", { "equity-option" => option.symbol })
+          response&.dig("data", "items")&.first
+        rescue Tastytrade::Error
+          nil
+        end
+      end
+
+      # @param option [Option] Static contract data
+      # @param snapshot [Hash] Quote and greeks data with dash-case or symbol keys
+      def initialize(option, snapshot = {})
+        @option = option
+        snapshot = snapshot.transform_keys { |key| key.to_s.tr("-", "_") }
+        SNAPSHOT_FIELDS.each do |field|
+          instance_variable_set("@#{field}", parse_financial_value(snapshot[field.to_s]))
+        end
+        @implied_volatility ||= parse_financial_value(snapshot["volatility"])
+      end
+
+      # @return [Boolean] true if the snapshot has a bid or ask
+      def live?
+        !@bid.nil? || !@ask.nil?
+      end
+
+      # @return [BigDecimal, nil] Midpoint of bid and ask
+      def mid
+        return nil unless @bid && @ask
+
+        (@bid + @ask) / 2
+      end
+
+      # @return [BigDecimal, nil] Ask minus bid
+      def spread
+        return nil unless @bid && @ask
+
+        @ask - @bid
+      end
+
+      # Convert to hash for JSON serialization
+      def to_h
+        snapshot = SNAPSHOT_FIELDS.to_h { |field| [field, public_send(field)&.to_s("F")] }
+        { symbol: symbol, streamer_symbol: streamer_symbol, option_type: option_type,
+          strike_price: strike_price&.to_s("F"), expiration_date: expiration_date&.to_s }
+          .merge(snapshot).compact
+      end
+
+      private
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty? || value.to_s == "NaN"
+
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -0,0 +1,37 @@
+# frozen_string_literal: true
+
+module Tastytrade
+  module Models
+    # Contingent status constants and helpers
+    #
+    # Child orders of OTO and OTOCO complex orders wait in a contingent state until
+    # their trigger order fills. The contingent-status field reports whether the
+    # child is still waiting or has been released to the market.
+    module OrderContingentStatus
+      # Waiting for the trigger order to fill
+      PENDING = "Pending"
+      # Trigger condition met, order released to the market
+      TRIGGERED = "Triggered"
+
+      ALL_STATUSES = [
+        PENDING,
+        TRIGGERED
+      ].freeze
+
+      # Check if the order is still waiting on its trigger
+      def self.pending?(status)
+        status == PENDING
+      end
+
+      # Check if the order has been triggered
+      def self.triggered?(status)
+        status == TRIGGERED
+      end
+
+      # Validate contingent status value
+      def self.valid?(status)
+        ALL_STATUSES.include?(status)
+      end
+    end
+  end
+end
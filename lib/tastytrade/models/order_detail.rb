@@ -0,0 +1,89 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # A retrieved order with its net execution price and, optionally, its fees
+    #
+    # The net execution price is derived from the legs' fills: each leg's
+    # quantity-weighted average fill price times its ratio, added for sells
+    # and subtracted for buys. Partial fills at different prices are averaged.
+    # The result is per share or contract, positive for a credit and negative
+    # for a debit, matching how order prices are quoted.
+    #
+    # @example
+    #   detail = account.get_order_detail(session, "12345", include_fees: true)
+    #   detail.net_execution_price  # => BigDecimal("2.15")
+    #   detail.effective_price      # => BigDecimal("2.137"), after $3.90 fees on three contracts
+    class OrderDetail
+      attr_reader :order, :fee_calculation
+
+      # @param order [LiveOrder] Order with leg fills
+      # @param fee_calculation [FeeCalculation, nil] Fees charged for the order
+      # @param multipliers [Hash{String => Numeric}] Multipliers by symbol for futures legs
+      def initialize(order, fee_calculation: nil, multipliers: {})
+        @order = order
+        @fee_calculation = fee_calculation
+        @multipliers = multipliers
+      end
+
+      # @return [BigDecimal, nil] Net price per order unit, nil until every leg has a fill
+      def net_execution_price
+        legs = @order.legs || []
+        return nil if legs.empty? || legs.any? { |leg| leg_fill_quantity(leg).zero? }
+
+        legs.sum(BigDecimal("0")) do |leg|
+          price = leg_average_price(leg) * ratio(leg)
+          leg.action.to_s.start_with?("Sell") ? price : -price
+        end
+      end
+
+      # Net execution price less fees spread over the filled units
+      #
+      # @return [BigDecimal, nil] nil when no complete unit has filled, fees were not fetched
+      #   or a futures leg's multiplier is unknown
+      def effective_price
+        price = net_execution_price
+        units = filled_units
+        return nil if price.nil? || @fee_calculation.nil? || units.zero? || multiplier.nil?
+
+        price - (@fee_calculation.total / (units * multiplier))
+      end
+
+      # @return [Integer] Complete order units filled, limited by the least filled leg
+      def filled_units
+        legs = @order.legs || []
+        return 0 if legs.empty?
+
+        legs.map { |leg| leg_fill_quantity(leg) / ratio(leg) }.min
+      end
+
+      # @return [Integer, BigDecimal, nil] Largest multiplier of the order's legs, nil when a
+      #   futures leg's multiplier is unknown
+      def multiplier
+        multipliers = (@order.legs || []).map do |leg|
+          ContractMultiplier.for(leg.instrument_type, @multipliers[leg.symbol])
+        end
+        return nil if multipliers.include?(nil)
+
+        multipliers.max || 1
+      end
+
+      private
+
+      def leg_average_price(leg)
+        value = leg.fills.sum(BigDecimal("0")) { |fill| (fill.fill_price || 0) * fill.quantity.to_i }
+        value / leg_fill_quantity(leg)
+      end
+
+      def ratio(leg)
+        [leg.ratio_quantity.to_i, 1].max
+      end
+
+      def leg_fill_quantity(leg)
+        leg.fills.sum { |fill| fill.quantity.to_i }
+      end
+    end
+  end
+end
@@ -13,6 +13,13 @@ module Tastytrade
                   :stop_trigger, :legs, :cancellable, :editable,
                   :edited, :updated_at, :created_at
 
+      # Typed fee breakdown with decimal amounts
+      #
+      # @return [FeeCalculation, nil] Fees for the order, nil when not reported
+      def fee_calculation
+        @fee_calculations.is_a?(Hash) ? FeeCalculation.new(@fee_calculations) : nil
+      end
+
       private
 
       def parse_attributes
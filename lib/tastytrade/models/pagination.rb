@@ -0,0 +1,36 @@
+# frozen_string_literal: true
+
+module Tastytrade
+  module Models
+    # Pagination metadata returned alongside paginated list responses
+    class Pagination < Base
+      attr_reader :per_page, :page_offset, :item_offset, :total_items, :total_pages,
+                  :current_item_count, :previous_link, :next_link
+
+      # @return [Boolean] true if there is a page after this one
+      def next_page?
+        return !@next_link.nil? if @total_pages.nil?
+
+        @page_offset.to_i + 1 < @total_pages
+      end
+
+      # @return [Integer, nil] Offset of the next page, or nil on the last page
+      def next_page_offset
+        next_page? ? @page_offset.to_i + 1 : nil
+      end
+
+      private
+
+      def parse_attributes
+        @per_page = @data["per-page"]
+        @page_offset = @data["page-offset"]
+        @item_offset = @data["item-offset"]
+        @total_items = @data["total-items"]
+        @total_pages = @data["total-pages"]
+        @current_item_count = @data["current-item-count"]
+        @previous_link = @data["previous-link"]
+        @next_link = @data["next-link"]
+      end
+    end
+  end
+end
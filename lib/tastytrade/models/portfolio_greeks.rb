@@ -0,0 +1,112 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # Net greeks across an account's positions
+    #
+    # Each option position contributes its per-contract greeks multiplied by
+    # quantity and contract multiplier, positive when long and negative when
+    # short. Equity positions contribute one delta per share.
+    #
+    # @example
+    #   greeks = account.get_portfolio_greeks(session)
+    #   puts "Net delta: #{greeks.delta.round(2)}"
+    #   greeks.by_underlying["SPY"][:theta]
+    class PortfolioGreeks
+      GREEKS = %i[delta gamma theta vega].freeze
+
+      # Greeks contributed by a single position
+      PositionGreeks = Struct.new(:symbol, :underlying_symbol, :quantity, :multiplier,
+                                  :delta, :gamma, :theta, :vega, keyword_init: true)
+
+      attr_reader :delta, :gamma, :theta, :vega, :positions, :missing_symbols
+
+      # Aggregate greeks for positions
+      #
+      # @param positions [Array<CurrentPosition>] Account positions
+      # @param greeks [Hash{String => #delta}] Per-contract greeks keyed by option symbol
+      # @param include_equities [Boolean] Count share delta from equity positions
+      # @return [PortfolioGreeks] Aggregated greeks
+      def self.calculate(positions, greeks, include_equities: true)
+        contributions = []
+        missing = []
+
+        positions.reject(&:closed?).each do |position|
+          if position.option?
+            source = greeks[position.symbol]
+            if source.nil? || source.delta.nil?
+              missing << position.symbol
+              next
+            end
+
+            contributions << option_contribution(position, source)
+          elsif position.equity? && include_equities
+            contributions << equity_contribution(position)
+          end
+        end
+
+        new(contributions, missing)
+      end
+
+      # @param positions [Array<PositionGreeks>] Per-position contributions
+      # @param missing_symbols [Array<String>] Option symbols without greeks data
+      def initialize(positions, missing_symbols = [])
+        @positions = positions
+        @missing_symbols = missing_symbols
+        GREEKS.each do |greek|
+          instance_variable_set("@#{greek}", positions.sum(BigDecimal("0")) { |p| p[greek] })
+        end
+      end
+
+      # @return [Boolean] true if some option positions had no greeks data
+      def incomplete?
+        !@missing_symbols.empty?
+      end
+
+      # Net greeks grouped by underlying symbol
+      #
+      # @return [Hash{String => Hash{Symbol => BigDecimal}}] Greeks per underlying
+      def by_underlying
+        @positions.group_by(&:underlying_symbol).transform_values do |group|
+          GREEKS.to_h { |greek| [greek, group.sum(BigDecimal("0")) { |p| p[greek] }] }
+        end
+      end
+
+      # Convert to hash for JSON serialization
+      def to_h
+        {
+          delta: @delta.to_s("F"),
+          gamma: @gamma.to_s("F"),
+          theta: @theta.to_s("F"),
+          vega: @vega.to_s("F"),
+          missing_symbols: @missing_symbols
+        }
+      end
+
+      class << self
+        private
+
+        def option_contribution(position, source)
+          factor = signed_quantity(position) * position.multiplier
+          values = GREEKS.to_h { |greek| [greek, BigDecimal((source.public_send(greek) || 0).to_s) * factor] }
+
+          PositionGreeks.new(symbol: position.symbol, underlying_symbol: position.underlying_symbol,
+                             quantity: signed_quantity(position), multiplier: position.multiplier, **values)
+        end
+
+        def equity_contribution(position)
+          zero = BigDecimal("0")
+          PositionGreeks.new(symbol: position.symbol, underlying_symbol: position.underlying_symbol || position.symbol,
+                             quantity: signed_quantity(position), multiplier: 1,
+                             delta: signed_quantity(position), gamma: zero, theta: zero, vega: zero)
+        end
+
+        def signed_quantity(position)
+          position.short? ? -position.quantity.abs : position.quantity.abs
+        end
+      end
+    end
+  end
+end
@@ -0,0 +1,153 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  module Models
+    # A market data snapshot for an instrument
+    #
+    # @example
+    #   quotes = Quote.get_all(session, %w[AAPL MSFT])
+    #   quotes.first.mid  # => BigDecimal("189.525")
+    #
+    # @example One bad symbol does not fail the rest
+    #   quotes, errors = Quote.get_all_resilient(session, symbols, batch_size: 50)
+    #   errors.each { |symbol, error| warn "#{symbol}: #{error.message}" }
+    class Quote < Base
+      # Market data query parameter for each instrument type
+      INSTRUMENT_TYPES = {
+        equity: "equity",
+        option: "equity-option",
+        index: "index",
+        future: "future",
+        future_option: "future-option",
+        cryptocurrency: "cryptocurrency"
+      }.freeze
+
+      DEFAULT_BATCH_SIZE = 50
+      DEFAULT_CONCURRENCY = 4
+
+      attr_reader :symbol, :instrument_type, :bid, :ask, :mid, :mark, :last, :open, :close,
+                  :prev_close, :day_high_price, :day_low_price, :volume, :bid_size, :ask_size,
+                  :updated_at
+
+      class << self
+        # Get quotes for symbols of one instrument type in a single request
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbols [Array<String>] Symbols to quote
+        # @param instrument_type [Symbol] Key of INSTRUMENT_TYPES
+        # @return [Array<Quote>] Quotes the API returned
+        # @raise [ArgumentError] if the instrument type is unknown
+        # @raise [Tastytrade::Error] if the request fails, e.g. because a symbol is malformed
+        def get_all(session, symbols, instrument_type: :equity)
+          key = market_data_key(instrument_type)
+          return [] if symbols.empty?
+
+          symbols = symbols.map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+          response = session.get("/market-data/by-type", { key => symbols.join(",") })
+          (response.dig("data", "items") || []).map { |item| new(item) }
+        end
+
+        # Get quotes in concurrent batches, isolating failures to single symbols
+        #
+        # Symbols are split into batches fetched by up to concurrency threads. When a
+        # batch fails, its symbols are fetched one at a time so only the bad ones
+        # fail. Symbols the API returns no quote for are reported as errors too.
+        #
+        # @param session [Tastytrade::Session] Active session
+        # @param symbols [Array<String>] Symbols to quote
+        # @param batch_size [Integer] Symbols per request
+        # @param concurrency [Integer] Maximum requests in flight
+        # @param instrument_type [Symbol] Key of INSTRUMENT_TYPES
+        # @return [Array(Hash{String => Quote}, Hash{String => Exception})] Quotes and errors by symbol
+        # @raise [ArgumentError] if batch_size or concurrency is not positive
+        def get_all_resilient(session, symbols, batch_size: DEFAULT_BATCH_SIZE, concurrency: DEFAULT_CONCURRENCY,
+                              instrument_type: :equity)
+          unless [batch_size, concurrency].all? { |value| value.is_a?(Integer) && value.positive? }
+            raise ArgumentError, "Batch size and concurrency must be positive integers"
+          end
+          market_data_key(instrument_type)
+
+          quotes = {}
+          errors = {}
+          mutex = Mutex.new
+          batches = Queue.new
+          symbols = symbols.map { |symbol| Tastytrade.normalize_symbol(symbol, session) }
+          symbols.uniq.each_slice(batch_size) { |batch| batches << batch }
+          batches.close
+
+          workers = Array.new([concurrency, batches.size].min) do
+            Thread.new do
+              while (batch = batches.pop)
+                batch_quotes, batch_errors = fetch_batch(session, batch, instrument_type)
+                mutex.synchronize do
+                  quotes.merge!(batch_quotes)
+                  errors.merge!(batch_errors)
+                end
+              end
+            end
+          end
+          workers.each(&:join)
+
+          [quotes, errors]
+        end
+
+        private
+
+        def market_data_key(instrument_type)
+          INSTRUMENT_TYPES.fetch(instrument_type) do
+            raise ArgumentError, "Unknown instrument type: #{instrument_type}. " \
+                                 "Must be one of: #{INSTRUMENT_TYPES.keys.join(", ")}"
+          end
+        end
+
+        def fetch_batch(session, batch, instrument_type)
+          quotes = get_all(session, batch, instrument_type: instrument_type).to_h { |quote| [quote.symbol, quote] }
+          missing = batch - quotes.keys
+          errors = missing.to_h { |symbol| [symbol, Tastytrade::Error.new("No quote returned for #{symbol}")] }
+          [quotes, errors]
+        rescue Tastytrade::Error => e
+          return [{}, { batch.first => e }] if batch.size == 1
+
+          batch.each_with_object([{}, {}]) do |symbol, (symbol_quotes, symbol_errors)|
+            single_quotes, single_errors = fetch_batch(session, [symbol], instrument_type)
+            symbol_quotes.merge!(single_quotes)
+            symbol_errors.merge!(single_errors)
+          end
+        end
+      end
+
+      # @return [Boolean] true if the quote has a bid or ask
+      def live?
+        !@bid.nil? || !@ask.nil?
+      end
+
+      private
+
+      def parse_attributes
+        @symbol = @data["symbol"]
+        @instrument_type = @data["instrument-type"]
+        @bid = parse_financial_value(@data["bid"])
+        @ask = parse_financial_value(@data["ask"])
+        @mid = parse_financial_value(@data["mid"])
+        @mark = parse_financial_value(@data["mark"])
+        @last = parse_financial_value(@data["last"])
+        @open = parse_financial_value(@data["open"])
+        @close = parse_financial_value(@data["close"])
+        @prev_close = parse_financial_value(@data["prev-close"])
+        @day_high_price = parse_financial_value(@data["day-high-price"])
+        @day_low_price = parse_financial_value(@data["day-low-price"])
+        @volume = parse_financial_value(@data["volume"])
+        @bid_size = parse_financial_value(@data["bid-size"])
+        @ask_size = parse_financial_value(@data["ask-size"])
+        @updated_at = parse_time(@data["updated-at"])
+      end
+
+      def parse_financial_value(value)
+        return nil if value.nil? || value.to_s.empty?
+        BigDecimal(value.to_s)
+      end
+    end
+  end
+end
@@ -0,0 +1,49 @@
+# frozen_string_literal: true
+
+module Tastytrade
+  module Models
+    # Token authorizing a connection to the DXLink market data streamer
+    #
+    # Tokens stay valid until expires_at, about a day; {Session#quote_token}
+    # caches one so reconnecting streamers don't request a new token each time.
+    #
+    # @example
+    #   token = QuoteToken.get(session)
+    #   token.dxlink_url  # => "wss://tasty-openapi-ws.dxfeed.com/realtime"
+    class QuoteToken < Base
+      attr_reader :token, :dxlink_url, :level, :issued_at, :expires_at
+
+      # Get a quote streamer token for the session's user
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @return [QuoteToken] Streamer token and URL
+      def self.get(session)
+        response = session.get("/api-quote-tokens")
+        new(response["data"])
+      end
+
+      # @param seconds [Numeric] Margin before the expiration time
+      # @return [Boolean] True if the token expires within the margin, false if no expiration was given
+      def expires_within?(seconds)
+        return false unless @expires_at
+
+        Time.now + seconds >= @expires_at
+      end
+
+      # @return [Boolean] True if the token has expired
+      def expired?
+        expires_within?(0)
+      end
+
+      private
+
+      def parse_attributes
+        @token = @data["token"]
+        @dxlink_url = @data["dxlink-url"]
+        @level = @data["level"]
+        @issued_at = parse_time(@data["issued-at"])
+        @expires_at = parse_time(@data["expires-at"])
+      end
+    end
+  end
+end
@@ -0,0 +1,54 @@
+# frozen_string_literal: true
+
+require "uri"
+
+module Tastytrade
+  module Models
+    # A match from the fuzzy symbol search, e.g. for type-ahead lookup
+    #
+    # Unlike {Instruments::Equity.get}, which needs the exact symbol, the search
+    # matches partial symbols and descriptions across instrument types.
+    #
+    # @example
+    #   SymbolSearchResult.search(session, "SP").map(&:symbol)  # => ["SPY", "SPX", "SPOT"]
+    class SymbolSearchResult < Base
+      attr_reader :symbol, :description, :instrument_type, :listed_market, :options
+
+      # Search symbols by partial symbol or description
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param query [String] Text to search for
+      # @return [Array<SymbolSearchResult>] Matches in the API's relevance order
+      def self.search(session, query)
+        query = query.to_s.strip
+        return [] if query.empty?
+
+        response = session.get("/symbols/search/#{URI.encode_uri_component(query)}")
+        (response.dig("data", "items") || []).map { |item| new(item) }
+      end
+
+      def equity?
+        @instrument_type == "Equity"
+      end
+
+      def index?
+        @instrument_type == "Index"
+      end
+
+      # @return [Boolean] true if the symbol has listed options
+      def options?
+        @options == true
+      end
+
+      private
+
+      def parse_attributes
+        @symbol = @data["symbol"]
+        @description = @data["description"]
+        @instrument_type = @data["instrument-type"]
+        @listed_market = @data["listed-market"]
+        @options = @data["options"]
+      end
+    end
+  end
+end
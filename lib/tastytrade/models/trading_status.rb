@@ -65,6 +65,16 @@ module Tastytrade
                   :clearing_aggregation_identifier, :is_cryptocurrency_closing_only,
                   :pdt_reset_on, :cmta_override, :enhanced_fraud_safeguards_enabled_at
 
+      # Get the trading status for an account by number, without loading the account
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param account_number [String] Account number
+      # @return [TradingStatus] Trading status object
+      def self.get(session, account_number)
+        response = session.get("/accounts/#{account_number}/trading-status/")
+        new(response["data"])
+      end
+
       # Check if account can trade options at any level
       #
       # @return [Boolean] true if options trading is enabled
@@ -32,6 +32,11 @@ module Tastytrade
       ].freeze
 
       # Fetch transaction history for an account
+      #
+      # All pages are fetched until the history is exhausted. Pass page_offset to
+      # retrieve a single page, or per_page to stop once that many transactions
+      # have been collected.
+      #
       # @param session [Tastytrade::Session] Active session
       # @param account_number [String] Account number
       # @param options [Hash] Optional filters
@@ -41,26 +46,23 @@ module Tastytrade
       # @option options [String] :underlying_symbol Filter by underlying symbol
       # @option options [String] :instrument_type Filter by instrument type
       # @option options [Array<String>] :transaction_types Filter by transaction types
-      # @option options [Integer] :per_page Number of results per page (default: 250)
+      # @option options [Integer] :per_page Number of results per page
       # @option options [Integer] :page_offset Page offset for pagination
       # @return [Array<Transaction>] Array of transactions
       def self.get_all(session, account_number, **options)
-        params = build_params(options)
+        return get_page(session, account_number, **options).first if options[:page_offset]
+
         transactions = []
-        page_offset = options[:page_offset] || 0
+        page_offset = 0
 
         loop do
-          current_params = params.dup
-          current_params["page-offset"] = page_offset unless page_offset.zero?
-          response = session.get("/accounts/#{account_number}/transactions", current_params)
-
-          items = response.dig("data", "items") || []
+          items, pagination = get_page(session, account_number, **options, page_offset: page_offset)
           break if items.empty?
 
-          transactions.concat(items.map { |item| new(item) })
+          transactions.concat(items)
 
-          # Break if we've reached the requested limit or if pagination is manual
-          break if options[:page_offset] || transactions.size >= (options[:per_page] || 250)
+          break if options[:per_page] && transactions.size >= options[:per_page]
+          break if pagination && !pagination.next_page?
 
           page_offset += 1
         end
@@ -68,6 +70,35 @@ module Tastytrade
         transactions
       end
 
+      # Fetch a single page of transaction history
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param account_number [String] Account number
+      # @param options [Hash] Same filters as {.get_all}
+      # @return [Array(Array<Transaction>, Pagination)] Transactions and pagination
+      #   metadata (nil when the response has none)
+      #
+      # @example Page through transactions manually
+      #   offset = 0
+      #   loop do
+      #     transactions, pagination = Transaction.get_page(session, "5WT0001", page_offset: offset)
+      #     process(transactions)
+      #     break unless pagination&.next_page?
+      #
+      #     offset = pagination.next_page_offset
+      #   end
+      def self.get_page(session, account_number, **options)
+        params = build_params(options)
+        page_offset = options[:page_offset].to_i
+        params["page-offset"] = page_offset unless page_offset.zero?
+
+        response = session.get("/accounts/#{account_number}/transactions", params)
+        items = (response.dig("data", "items") || []).map { |item| new(item) }
+        pagination = response["pagination"] ? Pagination.new(response["pagination"]) : nil
+
+        [items, pagination]
+      end
+
       private
 
       def parse_attributes
@@ -134,10 +165,7 @@ module Tastytrade
       end
 
       def parse_datetime(value)
-        return nil if value.nil? || value.to_s.empty?
-        Time.parse(value.to_s)
-      rescue ArgumentError
-        nil
+        parse_time(value)
       end
 
       def parse_date(value)
@@ -2,6 +2,7 @@
 
 require_relative "order"
 require_relative "models/option"
+require_relative "option_symbol"
 require "bigdecimal"
 
 module Tastytrade
@@ -26,29 +27,46 @@ module Tastytrade
     # Raised when an invalid option is provided
     class InvalidOptionError < StandardError; end
 
+    # A leg of a custom multi-leg order, identified by its OCC symbol
+    #
+    # instrument_type defaults to "Option"; an "Equity" leg uses the underlying symbol.
+    OptionLeg = Struct.new(:symbol, :quantity, :action, :instrument_type, keyword_init: true)
+
+    # The API accepts at most four legs on a single order
+    MAX_LEGS = 4
+
     POSITION_EFFECTS = {
       opening: "Opening",
       closing: "Closing",
       auto: "Auto"
     }.freeze
 
-    attr_reader :session, :account
+    attr_reader :session
 
-    def initialize(session, account)
+    # @param session [Tastytrade::Session] Active session
+    # @param account [Models::Account, nil] Account for the orders (default: session's default account)
+    def initialize(session, account = nil)
       @session = session
       @account = account
     end
 
+    # The account orders are built for, falling back to the session's default account number
+    #
+    # @return [Models::Account, nil] The configured account
+    def account
+      @account ||= default_account
+    end
+
     # Creates a buy call order
     #
     # @param option [Models::Option] The call option to buy
     # @param quantity [Integer] Number of contracts to buy
     # @param price [BigDecimal, nil] Limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @param position_effect [Symbol] Position effect (:opening, :closing, :auto)
     # @return [Order] The constructed buy call order
     # @raise [InvalidOptionError] if option is invalid or expired
-    def buy_call(option, quantity, price: nil, time_in_force: OrderTimeInForce::DAY, position_effect: :auto)
+    def buy_call(option, quantity, price: nil, time_in_force: nil, position_effect: :auto)
       validate_option!(option)
       create_single_leg_order(
         option: option,
@@ -65,11 +83,11 @@ module Tastytrade
     # @param option [Models::Option] The call option to sell
     # @param quantity [Integer] Number of contracts to sell
     # @param price [BigDecimal, nil] Limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @param position_effect [Symbol] Position effect (:opening, :closing, :auto)
     # @return [Order] The constructed sell call order
     # @raise [InvalidOptionError] if option is invalid or expired
-    def sell_call(option, quantity, price: nil, time_in_force: OrderTimeInForce::DAY, position_effect: :auto)
+    def sell_call(option, quantity, price: nil, time_in_force: nil, position_effect: :auto)
       validate_option!(option)
       create_single_leg_order(
         option: option,
@@ -86,11 +104,11 @@ module Tastytrade
     # @param option [Models::Option] The put option to buy
     # @param quantity [Integer] Number of contracts to buy
     # @param price [BigDecimal, nil] Limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @param position_effect [Symbol] Position effect (:opening, :closing, :auto)
     # @return [Order] The constructed buy put order
     # @raise [InvalidOptionError] if option is invalid or expired
-    def buy_put(option, quantity, price: nil, time_in_force: OrderTimeInForce::DAY, position_effect: :auto)
+    def buy_put(option, quantity, price: nil, time_in_force: nil, position_effect: :auto)
       validate_option!(option)
       create_single_leg_order(
         option: option,
@@ -107,11 +125,11 @@ module Tastytrade
     # @param option [Models::Option] The put option to sell
     # @param quantity [Integer] Number of contracts to sell
     # @param price [BigDecimal, nil] Limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @param position_effect [Symbol] Position effect (:opening, :closing, :auto)
     # @return [Order] The constructed sell put order
     # @raise [InvalidOptionError] if option is invalid or expired
-    def sell_put(option, quantity, price: nil, time_in_force: OrderTimeInForce::DAY, position_effect: :auto)
+    def sell_put(option, quantity, price: nil, time_in_force: nil, position_effect: :auto)
       validate_option!(option)
       create_single_leg_order(
         option: option,
@@ -128,10 +146,10 @@ module Tastytrade
     # @param option [Models::Option] The option position to close
     # @param quantity [Integer] Number of contracts to close (positive or negative)
     # @param price [BigDecimal, nil] Limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @return [Order] The constructed closing order
     # @raise [InvalidOptionError] if option is invalid or expired
-    def close_position(option, quantity, price: nil, time_in_force: OrderTimeInForce::DAY)
+    def close_position(option, quantity, price: nil, time_in_force: nil)
       validate_option!(option)
 
       action = determine_closing_action(option, quantity)
@@ -152,7 +170,7 @@ module Tastytrade
     # @param short_option [Models::Option] The short option leg
     # @param quantity [Integer] Number of spreads to create
     # @param price [BigDecimal, nil] Net debit/credit limit price (nil for market)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @return [Order] The constructed vertical spread order
     # @raise [InvalidStrategyError] if options don't meet spread requirements
     def vertical_spread(
@@ -160,7 +178,7 @@ module Tastytrade
       short_option,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_vertical_spread!(long_option, short_option)
 
@@ -173,7 +191,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -187,7 +205,7 @@ module Tastytrade
     # @param call_long [Models::Option] Long call at even higher strike
     # @param quantity [Integer] Number of iron condors to create
     # @param price [BigDecimal, nil] Net credit limit price (nil for market)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @return [Order] The constructed iron condor order
     # @raise [InvalidStrategyError] if options don't meet iron condor requirements
     def iron_condor(
@@ -197,7 +215,7 @@ module Tastytrade
       call_long,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_iron_condor!(put_short, put_long, call_short, call_long)
 
@@ -212,7 +230,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -234,7 +252,7 @@ module Tastytrade
     # @param long_put [Models::Option] Long put option at lower strike (wing)
     # @param quantity [Integer] Number of iron butterflies to create
     # @param price [BigDecimal, nil] Net credit limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     #
     # @return [Order] The constructed iron butterfly order
     #
@@ -257,7 +275,7 @@ module Tastytrade
       long_put,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_iron_butterfly!(short_call, long_call, short_put, long_put)
 
@@ -272,7 +290,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -293,7 +311,7 @@ module Tastytrade
     # @param long_high [Models::Option] Long option at higher strike
     # @param quantity [Integer] Number of butterflies (middle leg gets 2x quantity)
     # @param price [BigDecimal, nil] Net debit limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     #
     # @return [Order] The constructed butterfly spread order
     #
@@ -314,7 +332,7 @@ module Tastytrade
       long_high,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_butterfly_spread!(long_low, short_middle, long_high)
 
@@ -328,7 +346,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -348,7 +366,7 @@ module Tastytrade
     # @param long_option [Models::Option] Long option with farther expiration
     # @param quantity [Integer] Number of calendar spreads to create
     # @param price [BigDecimal, nil] Net debit limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     #
     # @return [Order] The constructed calendar spread order
     #
@@ -368,7 +386,7 @@ module Tastytrade
       long_option,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_calendar_spread!(short_option, long_option)
 
@@ -381,7 +399,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -400,7 +418,7 @@ module Tastytrade
     # @param long_option [Models::Option] Long option with farther expiration and different strike
     # @param quantity [Integer] Number of diagonal spreads to create
     # @param price [BigDecimal, nil] Net debit limit price (nil for market order)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     #
     # @return [Order] The constructed diagonal spread order
     #
@@ -420,7 +438,7 @@ module Tastytrade
       long_option,
       quantity,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_diagonal_spread!(short_option, long_option)
 
@@ -433,7 +451,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -446,7 +464,7 @@ module Tastytrade
     # @param quantity [Integer] Number of strangles to create
     # @param action [OrderAction] BUY_TO_OPEN or SELL_TO_OPEN
     # @param price [BigDecimal, nil] Net debit/credit limit price (nil for market)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @return [Order] The constructed strangle order
     # @raise [InvalidStrategyError] if options don't meet strangle requirements
     def strangle(
@@ -455,7 +473,7 @@ module Tastytrade
       quantity,
       action: OrderAction::BUY_TO_OPEN,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_strangle!(put_option, call_option)
 
@@ -468,7 +486,7 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
@@ -481,7 +499,7 @@ module Tastytrade
     # @param quantity [Integer] Number of straddles to create
     # @param action [OrderAction] BUY_TO_OPEN or SELL_TO_OPEN
     # @param price [BigDecimal, nil] Net debit/credit limit price (nil for market)
-    # @param time_in_force [OrderTimeInForce] Order time in force (default: DAY)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
     # @return [Order] The constructed straddle order
     # @raise [InvalidStrategyError] if options don't meet straddle requirements
     def straddle(
@@ -490,7 +508,7 @@ module Tastytrade
       quantity,
       action: OrderAction::BUY_TO_OPEN,
       price: nil,
-      time_in_force: OrderTimeInForce::DAY
+      time_in_force: nil
     )
       validate_option!(put_option)
       validate_option!(call_option)
@@ -513,12 +531,48 @@ module Tastytrade
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: legs,
         price: price
       )
     end
 
+    # Creates an order from arbitrary legs given by OCC symbol
+    #
+    # Covers strategies without a dedicated helper, such as ratio spreads, broken
+    # wing butterflies, or iron condors built from symbols rather than options.
+    # Legs must share one underlying and each contract may appear only once;
+    # buying and selling the same contract on one order would net out.
+    #
+    # @param legs [Array<OptionLeg, Hash>] Legs with symbol, quantity, action and
+    #   optionally instrument_type
+    # @param price [BigDecimal, nil] Net limit price (nil for market order)
+    # @param price_effect [String, nil] PriceEffect::DEBIT or PriceEffect::CREDIT
+    #   (default: inferred from the first leg)
+    # @param time_in_force [OrderTimeInForce, nil] Order time in force (default: session default or DAY)
+    # @return [Order] The constructed order
+    # @raise [InvalidStrategyError] if the legs do not form a valid order
+    #
+    # @example Ratio call spread
+    #   order = builder.multi_leg([
+    #     { symbol: "AAPL  240119C00150000", quantity: 1, action: OrderAction::BUY_TO_OPEN },
+    #     { symbol: "AAPL  240119C00160000", quantity: 2, action: OrderAction::SELL_TO_OPEN }
+    #   ], price: 0.50)
+    def multi_leg(legs, price: nil, price_effect: nil, time_in_force: nil)
+      legs = Array(legs).map { |leg| leg.is_a?(OptionLeg) ? leg : OptionLeg.new(**leg.to_h.transform_keys(&:to_sym)) }
+      validate_multi_leg!(legs)
+
+      order_type = price ? OrderType::LIMIT : OrderType::MARKET
+
+      Order.new(
+        type: order_type,
+        time_in_force: resolve_time_in_force(time_in_force),
+        legs: legs.map { |leg| build_custom_leg(leg) },
+        price: price,
+        price_effect: price_effect
+      )
+    end
+
     # Calculates the net premium for a multi-leg option order
     #
     # @param order [Order] The order to calculate premium for
@@ -549,6 +603,24 @@ module Tastytrade
 
     private
 
+    def order_defaults
+      return nil unless session.respond_to?(:order_defaults)
+
+      session.order_defaults
+    end
+
+    def resolve_time_in_force(time_in_force)
+      defaults = order_defaults
+      defaults ? defaults.resolve_time_in_force(time_in_force) : (time_in_force || OrderTimeInForce::DAY)
+    end
+
+    def default_account
+      account_number = order_defaults&.account_number
+      return nil unless account_number
+
+      Models::Account.get(session, account_number)
+    end
+
     def validate_option!(option)
       raise InvalidOptionError, "Option cannot be nil" if option.nil?
       # Allow test doubles, real Option objects, or objects with option-like attributes
@@ -747,13 +819,67 @@ module Tastytrade
       end
     end
 
+    def validate_multi_leg!(legs)
+      raise InvalidStrategyError, "At least one leg is required" if legs.empty?
+      raise InvalidStrategyError, "Orders support at most #{MAX_LEGS} legs" if legs.size > MAX_LEGS
+
+      underlyings = legs.map { |leg| leg_underlying(leg) }.uniq
+      raise InvalidStrategyError, "All legs must have the same underlying" if underlyings.size > 1
+
+      legs.each do |leg|
+        unless leg.quantity.is_a?(Integer) && leg.quantity.positive?
+          raise InvalidStrategyError, "Leg quantity must be a positive integer: #{leg.symbol}"
+        end
+        unless OrderLeg::VALID_ACTIONS.include?(leg.action)
+          raise InvalidStrategyError, "Invalid action for #{leg.symbol}: #{leg.action}"
+        end
+      end
+
+      duplicates = legs.group_by { |leg| normalize_leg_symbol(leg) }.select { |_, group| group.size > 1 }
+      duplicates.each do |symbol, group|
+        sides = group.map { |leg| leg.action.start_with?("Buy") }.uniq
+        raise InvalidStrategyError, "Legs buy and sell the same contract: #{symbol}" if sides.size > 1
+
+        raise InvalidStrategyError, "Duplicate leg for #{symbol}; combine the quantities instead"
+      end
+    end
+
+    def leg_underlying(leg)
+      return leg.symbol.to_s.strip.upcase unless option_leg?(leg)
+
+      OptionSymbol.parse(leg.symbol).underlying
+    rescue ArgumentError => e
+      raise InvalidStrategyError, e.message
+    end
+
+    def option_leg?(leg)
+      (leg.instrument_type || "Option") == "Option"
+    end
+
+    # OrderLeg expects a single space between the root and the expiration
+    def normalize_leg_symbol(leg)
+      return leg.symbol.to_s.strip.upcase unless option_leg?(leg)
+
+      parsed = OptionSymbol.parse(leg.symbol)
+      "#{parsed.underlying} #{parsed.to_s[OptionSymbol::ROOT_WIDTH..]}"
+    end
+
+    def build_custom_leg(leg)
+      OrderLeg.new(
+        action: leg.action,
+        symbol: normalize_leg_symbol(leg),
+        quantity: leg.quantity,
+        instrument_type: leg.instrument_type || "Option"
+      )
+    end
+
     def create_single_leg_order(option:, quantity:, action:, price:, time_in_force:, position_effect:)
       leg = build_option_leg(option, quantity, action, position_effect)
       order_type = price ? OrderType::LIMIT : OrderType::MARKET
 
       Order.new(
         type: order_type,
-        time_in_force: time_in_force,
+        time_in_force: resolve_time_in_force(time_in_force),
         legs: [leg],
         price: price
       )
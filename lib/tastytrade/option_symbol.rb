@@ -0,0 +1,116 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+require "date"
+
+module Tastytrade
+  # Components of an OCC option symbol such as "AAPL  230721C00190000"
+  #
+  # OCC symbols are the root padded with spaces to six characters, the
+  # expiration as YYMMDD, C or P, and the strike times 1000 as eight digits.
+  #
+  # @example
+  #   parsed = OptionSymbol.parse("AAPL  230721C00190000")
+  #   parsed.underlying   # => "AAPL"
+  #   parsed.expiration   # => #<Date: 2023-07-21>
+  #   parsed.strike_price # => 0.19e3
+  #   parsed.to_s         # => "AAPL  230721C00190000"
+  class OptionSymbol
+    # Roots are at most six characters, e.g. "BRK.B" or "SPXW"; the padding may be
+    # collapsed to a single space or omitted
+    PATTERN = /\A([A-Z0-9.]{1,6})\s*(\d{6})([CP])(\d{8})\z/
+    ROOT_WIDTH = 6
+    STRIKE_MULTIPLIER = 1000
+
+    attr_reader :underlying, :expiration, :option_type, :strike_price
+
+    # @param symbol [String] OCC option symbol
+    # @return [OptionSymbol]
+    # @raise [ArgumentError] if the symbol is not a valid OCC symbol
+    def self.parse(symbol)
+      match = symbol.to_s.strip.match(PATTERN)
+      raise ArgumentError, "Invalid OCC option symbol: #{symbol.inspect}" unless match
+
+      new(
+        underlying: match[1],
+        expiration: Date.strptime(match[2], "%y%m%d"),
+        option_type: match[3],
+        strike_price: BigDecimal(match[4]) / STRIKE_MULTIPLIER
+      )
+    rescue Date::Error
+      raise ArgumentError, "Invalid expiration date in OCC option symbol: #{symbol.inspect}"
+    end
+
+    # @param symbol [String] Candidate OCC option symbol
+    # @return [Boolean] true if the symbol parses
+    def self.valid?(symbol)
+      parse(symbol)
+      true
+    rescue ArgumentError
+      false
+    end
+
+    # Builds the OCC symbol for the given components
+    #
+    # @param underlying [String] Root symbol, up to six characters
+    # @param expiration [Date] Expiration date
+    # @param option_type [String] "C" or "P"; "Call" and "Put" are also accepted
+    # @param strike_price [BigDecimal, Numeric, String] Strike price, to three decimal places
+    # @return [String] Padded OCC symbol
+    def self.format(underlying:, expiration:, option_type:, strike_price:)
+      new(underlying: underlying, expiration: expiration, option_type: option_type,
+          strike_price: strike_price).to_s
+    end
+
+    # @param underlying [String] Root symbol
+    # @param expiration [Date] Expiration date
+    # @param option_type [String] "C" or "P"
+    # @param strike_price [BigDecimal] Strike price
+    def initialize(underlying:, expiration:, option_type:, strike_price:)
+      @underlying = underlying
+      @expiration = expiration
+      @option_type = option_type
+      @strike_price = strike_price
+    end
+
+    def call?
+      option_type.to_s.upcase.start_with?("C")
+    end
+
+    def put?
+      option_type.to_s.upcase.start_with?("P")
+    end
+
+    # @return [String] Padded OCC symbol
+    # @raise [ArgumentError] if a component cannot be represented in OCC format
+    def to_s
+      root = underlying.to_s.upcase
+      unless root.length.between?(1, ROOT_WIDTH)
+        raise ArgumentError, "Underlying must be 1 to #{ROOT_WIDTH} characters: #{underlying.inspect}"
+      end
+
+      raise ArgumentError, "Option type must be C or P: #{option_type.inspect}" unless call? || put?
+
+      strike = BigDecimal(strike_price.to_s) * STRIKE_MULTIPLIER
+      unless strike.frac.zero? && strike.between?(0, 99_999_999)
+        raise ArgumentError, "Strike price cannot be represented in OCC format: #{strike_price}"
+      end
+
+      "#{root.ljust(ROOT_WIDTH)}#{expiration.strftime("%y%m%d")}#{call? ? "C" : "P"}" \
+        "#{strike.to_i.to_s.rjust(8, "0")}"
+    end
+
+    def ==(other)
+      other.is_a?(OptionSymbol) && to_h == other.to_h
+    end
+    alias eql? ==
+
+    def hash
+      to_h.hash
+    end
+
+    def to_h
+      { underlying: @underlying, expiration: @expiration, option_type: @option_type, strike_price: @strike_price }
+    end
+  end
+end
@@ -1,6 +1,7 @@
 # frozen_string_literal: true
 
 require "bigdecimal"
+require "date"
 
 module Tastytrade
   # Order action constants
@@ -16,12 +17,36 @@ module Tastytrade
     MARKET = "Market"
     LIMIT = "Limit"
     STOP = "Stop"
+    TRAILING_STOP = "Trailing Stop"
+  end
+
+  # How a trailing stop's trailing value is measured
+  module TrailingValueType
+    # Percent of the market price, e.g. 5 for 5%
+    PERCENTAGE = "percentage"
+    # Fixed distance in price, e.g. 2.50
+    AMOUNT = "amount"
+
+    ALL = [PERCENTAGE, AMOUNT].freeze
   end
 
   # Order time in force constants
   module OrderTimeInForce
     DAY = "Day"
     GTC = "GTC"
+    # Day order that can also fill in the pre-market and after-hours sessions
+    EXT = "Ext"
+    # GTC order that can also fill in the pre-market and after-hours sessions
+    GTC_EXT = "GTC Ext"
+    # Good till date: the order works until the close of its gtc_date
+    GTD = "GTD"
+    # Immediate or cancel: fills what it can at once and cancels the rest
+    IOC = "IOC"
+    # Fill or kill: fills in full at once or is cancelled
+    FOK = "FOK"
+
+    # Time in force values for orders that must fill on arrival
+    IMMEDIATE = [IOC, FOK].freeze
   end
 
   # Price effect constants
@@ -31,9 +56,21 @@ module Tastytrade
   end
 
   # Represents a single leg of an order
+  #
+  # Quantities are whole numbers except for cryptocurrency legs, which keep a
+  # fractional BigDecimal quantity. Legs of notional orders have no quantity.
   class OrderLeg
     attr_reader :action, :symbol, :quantity, :instrument_type, :position_effect
 
+    VALID_ACTIONS = [
+      OrderAction::BUY_TO_OPEN,
+      OrderAction::SELL_TO_CLOSE,
+      OrderAction::SELL_TO_OPEN,
+      OrderAction::BUY_TO_CLOSE
+    ].freeze
+
+    INSTRUMENT_TYPES = ["Equity", "Option", "Future", "Future Option", "Cryptocurrency"].freeze
+
     OCC_SYMBOL_PATTERN = /\A[A-Z0-9]+\s\d{6}[CP]\d{8}\z/
 
     def initialize(action:, symbol:, quantity:, instrument_type: "Equity", position_effect: nil)
@@ -43,7 +80,13 @@ module Tastytrade
 
       @action = action
       @symbol = symbol
-      @quantity = quantity.to_i
+      @quantity = if quantity.nil?
+                    nil
+                  elsif fractional_quantity?(instrument_type)
+                    BigDecimal(quantity.to_s)
+                  else
+                    quantity.to_i
+      end
       @instrument_type = instrument_type
       @position_effect = position_effect || auto_detect_position_effect(action)
     end
@@ -52,9 +95,9 @@ module Tastytrade
       params = {
         "action" => @action,
         "symbol" => @symbol,
-        "quantity" => @quantity,
+        "quantity" => @quantity.is_a?(BigDecimal) ? @quantity.to_s("F") : @quantity,
         "instrument-type" => @instrument_type
-      }
+      }.compact
 
       params["position-effect"] = @position_effect if @position_effect && @instrument_type == "Option"
 
@@ -63,16 +106,13 @@ module Tastytrade
 
     private
 
-    def validate_action!(action)
-      valid_actions = [
-        OrderAction::BUY_TO_OPEN,
-        OrderAction::SELL_TO_CLOSE,
-        OrderAction::SELL_TO_OPEN,
-        OrderAction::BUY_TO_CLOSE
-      ]
+    def fractional_quantity?(instrument_type)
+      instrument_type == "Cryptocurrency"
+    end
 
-      unless valid_actions.include?(action)
-        raise ArgumentError, "Invalid action: #{action}. Must be one of: #{valid_actions.join(", ")}"
+    def validate_action!(action)
+      unless VALID_ACTIONS.include?(action)
+        raise ArgumentError, "Invalid action: #{action}. Must be one of: #{VALID_ACTIONS.join(", ")}"
       end
     end
 
@@ -104,18 +144,82 @@ module Tastytrade
   end
 
   # Represents an order to be placed
+  #
+  # The API prices multi-leg orders as a single net amount: the order carries one
+  # price and one price effect (Debit or Credit) while each leg only carries its
+  # action, which encodes the buy/sell side and open/close intent. Pass
+  # price_effect explicitly for mixed buy/sell legs where the first leg does not
+  # reflect the net direction, such as a credit vertical listed long leg first.
+  #
+  # GTD orders require a gtc_date, a Date or "YYYY-MM-DD" string after today.
+  # Stop orders trigger at stop_trigger. Trailing stop orders trigger once the
+  # price moves trailing_value against the position from its best level, where
+  # trailing_value_type says whether the value is a percentage or an amount.
+  #
+  # Notional market orders buy or sell a dollar value instead of a quantity:
+  # pass value: and legs without a quantity.
+  #
+  # A client_order_id is sent as ext-client-order-id so a retried submission
+  # can be recognized instead of placing the order twice.
+  #
+  # A source string, sent as source, tags the order for attribution in
+  # tastytrade's reporting, e.g. the name of the application that placed it.
   class Order
-    attr_reader :type, :time_in_force, :legs, :price
+    GTC_DATE_FORMAT = /\A\d{4}-\d{2}-\d{2}\z/
+
+    attr_reader :type, :time_in_force, :legs, :price, :gtc_date, :stop_trigger, :value,
+                :trailing_value, :trailing_value_type, :client_order_id, :source
 
-    def initialize(type:, time_in_force: OrderTimeInForce::DAY, legs:, price: nil)
+    def initialize(type:, time_in_force: OrderTimeInForce::DAY, legs:, price: nil, price_effect: nil,
+                   gtc_date: nil, stop_trigger: nil, value: nil, value_effect: nil, trailing_value: nil,
+                   trailing_value_type: nil, client_order_id: nil, source: nil)
       validate_type!(type)
       validate_time_in_force!(time_in_force)
       validate_price!(type, price)
+      validate_price_effect!(price_effect) if price_effect
+      validate_value!(type, value)
+      validate_price_effect!(value_effect) if value_effect
+      validate_trailing_stop!(type, trailing_value, trailing_value_type)
+      validate_client_order_id!(client_order_id) if client_order_id
+      validate_source!(source) if source
 
       @type = type
       @time_in_force = time_in_force
       @legs = Array(legs)
       @price = price ? BigDecimal(price.to_s) : nil
+      @price_effect = price_effect
+      @gtc_date = parse_gtc_date(time_in_force, gtc_date)
+      @stop_trigger = stop_trigger ? BigDecimal(stop_trigger.to_s) : nil
+      @value = value ? BigDecimal(value.to_s) : nil
+      @value_effect = value_effect
+      @trailing_value = trailing_value ? BigDecimal(trailing_value.to_s) : nil
+      @trailing_value_type = trailing_value_type
+      @client_order_id = client_order_id
+      @source = source
+    end
+
+    # Net price effect of the order, explicit or inferred from the first leg
+    #
+    # @return [String] PriceEffect::DEBIT or PriceEffect::CREDIT
+    def price_effect
+      @price_effect || determine_price_effect
+    end
+
+    # Effect of a notional order's value, explicit or inferred from the first leg
+    #
+    # @return [String, nil] PriceEffect::DEBIT or PriceEffect::CREDIT, nil for quantity orders
+    def value_effect
+      return nil unless notional?
+
+      @value_effect || determine_price_effect
+    end
+
+    def debit?
+      price_effect == PriceEffect::DEBIT
+    end
+
+    def credit?
+      price_effect == PriceEffect::CREDIT
     end
 
     def market?
@@ -130,6 +234,85 @@ module Tastytrade
       @type == OrderType::STOP
     end
 
+    def trailing_stop?
+      @type == OrderType::TRAILING_STOP
+    end
+
+    def gtd?
+      @time_in_force == OrderTimeInForce::GTD
+    end
+
+    def notional?
+      !@value.nil?
+    end
+
+    # Checks the order itself without contacting the API.
+    #
+    # Unlike {#validate!}, which checks symbols, permissions and buying power
+    # against an account, this only inspects the order's own fields and reports
+    # every problem found rather than stopping at the first.
+    #
+    # @return [Array<String>] Problems found, empty for a well-formed order
+    def validation_errors
+      errors = []
+      errors << "Order must have at least one leg" if @legs.empty?
+      @legs.each.with_index(1) { |leg, number| errors.concat(leg_errors(leg, number)) }
+      errors << "Limit orders require a price" if limit? && @price.nil?
+      errors << "Price must be greater than 0" if @price && !@price.positive?
+      errors << "Stop orders require a stop trigger" if stop? && @stop_trigger.nil?
+      errors << "Stop trigger must be greater than 0" if @stop_trigger && !@stop_trigger.positive?
+      errors.concat(price_effect_errors)
+    end
+
+    # Checks an explicit price effect against the legs' directions.
+    #
+    # An order whose legs all buy cannot be filled for a credit, nor one whose
+    # legs all sell for a debit. Orders mixing buys and sells can go either
+    # way, so their price effect is not checked.
+    #
+    # @return [Array<String>] Contradictions found, empty when consistent
+    def price_effect_errors
+      return [] unless limit? && @price_effect
+
+      case [leg_sides, @price_effect]
+      when [[:buy], PriceEffect::CREDIT] then ["Credit price effect on an order that only buys"]
+      when [[:sell], PriceEffect::DEBIT] then ["Debit price effect on an order that only sells"]
+      else []
+      end
+    end
+
+    # Flags details that are allowed but may not be what was meant.
+    #
+    # A limit order mixing buys and sells without an explicit price effect
+    # takes its effect from the first leg, which is wrong for e.g. a credit
+    # vertical listed long leg first.
+    #
+    # @return [Array<String>] Warnings, empty when nothing looks off
+    def validation_warnings
+      warnings = []
+      if limit? && @price_effect.nil? && leg_sides.size > 1
+        warnings << "Price effect #{price_effect} was inferred from the first leg of an order that both buys " \
+                    "and sells; pass price_effect to confirm it"
+      end
+      warnings
+    end
+
+    # @return [Boolean] true if {#validation_errors} finds no problems
+    def valid?
+      validation_errors.empty?
+    end
+
+    # Raises with every problem {#validation_errors} finds
+    #
+    # @return [true]
+    # @raise [OrderValidationError] listing all problems
+    def validate_structure!
+      errors = validation_errors
+      raise OrderValidationError, errors if errors.any?
+
+      true
+    end
+
     # Validates this order for a specific account using the OrderValidator.
     # Performs comprehensive checks including symbol existence, quantity constraints,
     # price validation, account permissions, and optionally buying power.
@@ -175,7 +358,20 @@ module Tastytrade
       # API expects string representation without negative sign
       if limit? && @price
         params["price"] = @price.to_s("F")
-        params["price-effect"] = determine_price_effect
+        params["price-effect"] = price_effect
+      end
+
+      params["stop-trigger"] = @stop_trigger.to_s("F") if stop? && @stop_trigger
+      params["ext-client-order-id"] = @client_order_id if @client_order_id
+      params["source"] = @source if @source
+      if trailing_stop?
+        params["trailing-stop"] = { "value" => @trailing_value.to_s("F"), "value-type" => @trailing_value_type }
+      end
+      params["gtc-date"] = @gtc_date.strftime("%Y-%m-%d") if gtd?
+
+      if notional?
+        params["value"] = @value.to_s("F")
+        params["value-effect"] = value_effect
       end
 
       params
@@ -183,6 +379,30 @@ module Tastytrade
 
     private
 
+    def leg_errors(leg, number)
+      errors = []
+      errors << "Leg #{number}: symbol is required" if leg.symbol.nil? || leg.symbol.to_s.strip.empty?
+      unless leg.quantity&.positive? || (notional? && leg.quantity.nil?)
+        errors << "Leg #{number}: quantity must be greater than 0"
+      end
+      unless OrderLeg::VALID_ACTIONS.include?(leg.action)
+        errors << "Leg #{number}: invalid action #{leg.action.inspect}"
+      end
+      unless OrderLeg::INSTRUMENT_TYPES.include?(leg.instrument_type)
+        errors << "Leg #{number}: invalid instrument type #{leg.instrument_type.inspect}"
+      end
+      errors
+    end
+
+    def leg_sides
+      @legs.filter_map do |leg|
+        case leg.action
+        when OrderAction::BUY_TO_OPEN, OrderAction::BUY_TO_CLOSE then :buy
+        when OrderAction::SELL_TO_OPEN, OrderAction::SELL_TO_CLOSE then :sell
+        end
+      end.uniq
+    end
+
     def determine_price_effect
       # Determine price effect based on the first leg's action
       # Buy actions result in debit, sell actions result in credit
@@ -200,19 +420,94 @@ module Tastytrade
     end
 
     def validate_type!(type)
-      valid_types = [OrderType::MARKET, OrderType::LIMIT, OrderType::STOP]
+      valid_types = [OrderType::MARKET, OrderType::LIMIT, OrderType::STOP, OrderType::TRAILING_STOP]
       unless valid_types.include?(type)
         raise ArgumentError, "Invalid order type: #{type}. Must be one of: #{valid_types.join(", ")}"
       end
     end
 
     def validate_time_in_force!(time_in_force)
-      valid_tifs = [OrderTimeInForce::DAY, OrderTimeInForce::GTC]
+      valid_tifs = [OrderTimeInForce::DAY, OrderTimeInForce::GTC, OrderTimeInForce::EXT, OrderTimeInForce::GTC_EXT,
+                    OrderTimeInForce::GTD, *OrderTimeInForce::IMMEDIATE]
       unless valid_tifs.include?(time_in_force)
         raise ArgumentError, "Invalid time in force: #{time_in_force}. Must be one of: #{valid_tifs.join(", ")}"
       end
     end
 
+    def validate_price_effect!(price_effect)
+      valid_effects = [PriceEffect::DEBIT, PriceEffect::CREDIT]
+      unless valid_effects.include?(price_effect)
+        raise ArgumentError, "Invalid price effect: #{price_effect}. Must be one of: #{valid_effects.join(", ")}"
+      end
+    end
+
+    def parse_gtc_date(time_in_force, gtc_date)
+      unless time_in_force == OrderTimeInForce::GTD
+        raise ArgumentError, "GTC date is only allowed for GTD orders" if gtc_date
+
+        return nil
+      end
+
+      raise ArgumentError, "GTC date is required for GTD orders" if gtc_date.nil?
+
+      date = case gtc_date
+             when Date then gtc_date
+             when String
+               unless gtc_date.match?(GTC_DATE_FORMAT)
+                 raise ArgumentError, "Invalid GTC date: #{gtc_date}. Expected format: YYYY-MM-DD"
+               end
+
+               Date.strptime(gtc_date, "%Y-%m-%d")
+             else
+               raise ArgumentError, "GTC date must be a Date or YYYY-MM-DD string"
+      end
+
+      raise ArgumentError, "GTC date must be in the future" unless date > Date.today
+
+      date
+    rescue Date::Error
+      raise ArgumentError, "Invalid GTC date: #{gtc_date}. Expected format: YYYY-MM-DD"
+    end
+
+    def validate_value!(type, value)
+      return if value.nil?
+
+      raise ArgumentError, "Value is only allowed for market orders" unless type == OrderType::MARKET
+      raise ArgumentError, "Value must be greater than 0" if value.to_f <= 0
+    end
+
+    def validate_client_order_id!(client_order_id)
+      return if client_order_id.is_a?(String) && !client_order_id.strip.empty?
+
+      raise ArgumentError, "Client order ID must be a non-empty string"
+    end
+
+    def validate_source!(source)
+      return if source.is_a?(String) && !source.strip.empty?
+
+      raise ArgumentError, "Source must be a non-empty string"
+    end
+
+    def validate_trailing_stop!(type, trailing_value, trailing_value_type)
+      unless type == OrderType::TRAILING_STOP
+        if trailing_value || trailing_value_type
+          raise ArgumentError, "Trailing values are only allowed for trailing stop orders"
+        end
+
+        return
+      end
+
+      raise ArgumentError, "Trailing stop orders require a trailing value" if trailing_value.nil?
+      raise ArgumentError, "Trailing value must be greater than 0" if trailing_value.to_f <= 0
+      unless TrailingValueType::ALL.include?(trailing_value_type)
+        raise ArgumentError, "Invalid trailing value type: #{trailing_value_type.inspect}. " \
+                             "Must be one of: #{TrailingValueType::ALL.join(", ")}"
+      end
+      if trailing_value_type == TrailingValueType::PERCENTAGE && trailing_value.to_f >= 100
+        raise ArgumentError, "Trailing percentage must be less than 100"
+      end
+    end
+
     def validate_price!(type, price)
       if type == OrderType::LIMIT && price.nil?
         raise ArgumentError, "Price is required for limit orders"
@@ -0,0 +1,107 @@
+# frozen_string_literal: true
+
+require_relative "order"
+
+module Tastytrade
+  # Default values applied when building and placing orders.
+  #
+  # Single-account users who always trade the same time in force can configure
+  # these once on the session instead of repeating them on every call. Any value
+  # passed explicitly to a builder or helper method takes precedence.
+  #
+  # @example Configure defaults on a session
+  #   session.with_order_defaults(time_in_force: "GTC", account_number: "5WV12345")
+  #   builder = OptionOrderBuilder.new(session)
+  #   order = builder.buy_call(option, 1, price: 2.50) # GTC order
+  class OrderDefaults
+    VALID_TIME_IN_FORCE = [
+      OrderTimeInForce::DAY,
+      OrderTimeInForce::GTC,
+      OrderTimeInForce::EXT,
+      OrderTimeInForce::GTC_EXT
+    ].freeze
+    ACCOUNT_NUMBER_PATTERN = /\A[A-Z0-9]+\z/
+
+    # @return [String, nil] Default time in force
+    attr_reader :time_in_force
+
+    # @return [String, nil] Default account number
+    attr_reader :account_number
+
+    # @return [String, nil] Source tag sent with placed orders that have none
+    attr_reader :source
+
+    # @param time_in_force [String, nil] Default time in force (from OrderTimeInForce)
+    # @param account_number [String, nil] Default account number
+    # @param source [String, nil] Source tag for placed orders, e.g. the application name
+    # @raise [ArgumentError] if any default is invalid
+    def initialize(time_in_force: nil, account_number: nil, source: nil)
+      validate_time_in_force!(time_in_force) if time_in_force
+      validate_account_number!(account_number) if account_number
+      validate_source!(source) if source
+
+      @time_in_force = time_in_force
+      @account_number = account_number
+      @source = source
+      freeze
+    end
+
+    # Resolve the time in force for an order, preferring an explicit value
+    #
+    # @param override [String, nil] Explicit time in force for this call
+    # @return [String] Time in force to use
+    def resolve_time_in_force(override = nil)
+      override || @time_in_force || OrderTimeInForce::DAY
+    end
+
+    # Resolve the account number for an order, preferring an explicit value
+    #
+    # @param override [String, nil] Explicit account number for this call
+    # @return [String] Account number to use
+    # @raise [ArgumentError] if neither an override nor a default is available
+    def resolve_account_number(override = nil)
+      return override if override
+      return @account_number if @account_number
+
+      raise ArgumentError, "No account number given and no default account number configured"
+    end
+
+    # Resolve the source tag for an order, preferring the order's own
+    #
+    # @param override [String, nil] Source set on the order
+    # @return [String, nil] Source to send, nil for none
+    def resolve_source(override = nil)
+      override || @source
+    end
+
+    # @return [Boolean] true if no defaults are configured
+    def empty?
+      @time_in_force.nil? && @account_number.nil? && @source.nil?
+    end
+
+    def to_h
+      { time_in_force: @time_in_force, account_number: @account_number, source: @source }.compact
+    end
+
+    private
+
+    def validate_time_in_force!(time_in_force)
+      return if VALID_TIME_IN_FORCE.include?(time_in_force)
+
+      raise ArgumentError,
+            "Invalid default time in force: #{time_in_force}. Must be one of: #{VALID_TIME_IN_FORCE.join(", ")}"
+    end
+
+    def validate_account_number!(account_number)
+      return if account_number.is_a?(String) && account_number.match?(ACCOUNT_NUMBER_PATTERN)
+
+      raise ArgumentError, "Invalid default account number: #{account_number.inspect}"
+    end
+
+    def validate_source!(source)
+      return if source.is_a?(String) && !source.strip.empty?
+
+      raise ArgumentError, "Invalid default source: #{source.inspect}"
+    end
+  end
+end
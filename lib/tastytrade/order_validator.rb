@@ -2,6 +2,7 @@
 
 require "bigdecimal"
 require "time"
+require_relative "market_hours"
 
 module Tastytrade
   # Validates orders before submission to ensure they meet all requirements.
@@ -67,10 +68,12 @@ module Tastytrade
       validate_symbols!
       validate_quantities!
       validate_prices!
+      validate_price_effect!
       validate_market_hours!
       validate_buying_power! unless skip_dry_run
 
-      # Raise error if any validation failed
+      # Raise error if any validation failed, reporting problems found by several checks once
+      @errors.uniq!
       raise OrderValidationError, @errors if @errors.any?
 
       true
@@ -106,17 +109,9 @@ module Tastytrade
 
     private
 
-    # Validate basic order structure
+    # Validate basic order structure, see {Order#validation_errors}
     def validate_order_structure!
-      # Check for at least one leg
-      if @order.legs.nil? || @order.legs.empty?
-        @errors << "Order must have at least one leg"
-      end
-
-      # Validate order type and price consistency
-      if @order.limit? && @order.price.nil?
-        @errors << "Limit orders require a price"
-      end
+      @errors.concat(@order.validation_errors)
 
       # Validate time in force
       if @order.time_in_force.nil?
@@ -142,9 +137,13 @@ module Tastytrade
         validate_equity_symbol!(symbol)
       when "Option"
         validate_option_symbol!(symbol)
+      when "Cryptocurrency"
+        validate_cryptocurrency_symbol!(symbol)
       when "Future"
         # TODO: Implement futures symbol validation
         @warnings << "Futures symbol validation not yet implemented for #{symbol}"
+      when "Future Option"
+        validate_future_option_symbol!(symbol)
       else
         @errors << "Unknown instrument type: #{instrument_type}"
       end
@@ -158,6 +157,22 @@ module Tastytrade
       @errors << "Invalid equity symbol '#{symbol}': #{e.message}"
     end
 
+    # Validate future option symbol exists and is not closing only
+    def validate_future_option_symbol!(symbol)
+      option = Instruments::FutureOption.get(@session, symbol)
+      @warnings << "Future option '#{symbol}' is closing only" if option.closing_only?
+    rescue StandardError => e
+      @errors << "Invalid future option symbol '#{symbol}': #{e.message}"
+    end
+
+    # Validate cryptocurrency symbol exists and is tradeable
+    def validate_cryptocurrency_symbol!(symbol)
+      cryptocurrency = Instruments::Cryptocurrency.get(@session, symbol)
+      @errors << "Cryptocurrency '#{symbol}' is not active" unless cryptocurrency.active?
+    rescue StandardError => e
+      @errors << "Invalid cryptocurrency symbol '#{symbol}': #{e.message}"
+    end
+
     # Validate option symbol and its properties
     def validate_option_symbol!(symbol)
       # Parse OCC symbol format: AAPL 240119C00150000
@@ -201,7 +216,14 @@ module Tastytrade
       return if @order.legs.nil?
 
       @order.legs.each do |leg|
-        validate_quantity!(leg.quantity, leg.symbol)
+        # Notional orders size legs by the order's value instead
+        next if leg.quantity.nil? && @order.notional?
+
+        if leg.instrument_type == "Cryptocurrency"
+          validate_fractional_quantity!(leg.quantity, leg.symbol)
+        else
+          validate_quantity!(leg.quantity, leg.symbol)
+        end
       end
     end
 
@@ -223,6 +245,15 @@ module Tastytrade
       end
     end
 
+    # Validate a fractional quantity, such as for a cryptocurrency leg
+    def validate_fractional_quantity!(quantity, symbol)
+      if quantity.nil? || quantity <= 0
+        @errors << "Quantity for #{symbol} must be greater than 0"
+      elsif quantity > MAX_QUANTITY
+        @errors << "Quantity for #{symbol} exceeds maximum of #{MAX_QUANTITY}"
+      end
+    end
+
     # Validate order prices
     def validate_prices!
       return unless @order.limit?
@@ -230,6 +261,12 @@ module Tastytrade
       validate_price!(@order.price)
     end
 
+    # Validate the net price effect against the legs' directions
+    def validate_price_effect!
+      @errors.concat(@order.price_effect_errors)
+      @warnings.concat(@order.validation_warnings)
+    end
+
     # Validate a single price
     def validate_price!(price)
       return if price.nil?
@@ -296,6 +333,10 @@ module Tastytrade
         unless @trading_status.can_trade_futures?
           @errors << "Account does not have futures trading permissions"
         end
+      when "Future Option"
+        unless @trading_status.can_trade_futures? && @trading_status.can_trade_options?
+          @errors << "Account does not have futures options trading permissions"
+        end
       when "Cryptocurrency"
         unless @trading_status.can_trade_cryptocurrency?
           @errors << "Account does not have cryptocurrency trading permissions"
@@ -327,6 +368,10 @@ module Tastytrade
       if weekend?(now)
         @warnings << "Markets are closed on weekends"
       end
+
+      # Day orders outside regular hours sit idle until the next open
+      suggestion = MarketHours.suggest_time_in_force(@order.type, time_in_force: @order.time_in_force, now: now)
+      @warnings << suggestion.warning if suggestion.warning?
     end
 
     # Check if current time is during regular market hours (9:30 AM - 4:00 PM ET)
@@ -0,0 +1,122 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  # What-if preview of an account's positions after an order fills.
+  #
+  # Applies each order leg to the matching position: buys add and sells
+  # subtract, so a leg can open a new position, add to one, close part or all of
+  # one, or flip it from long to short. Closing legs may not exceed the quantity
+  # held in the opposite direction. Nothing is sent to the API.
+  #
+  # Positions and legs are matched with their whitespace collapsed, since the
+  # API pads OCC option roots on positions while order legs use one space.
+  #
+  # Notional orders, which give a dollar value instead of a quantity, cannot be
+  # simulated. A futures leg that opens a new position needs its multiplier
+  # passed in, since it varies by product.
+  #
+  # @example Preview closing half of a position
+  #   positions = account.get_positions(session)
+  #   after = Tastytrade::PositionSimulator.simulate_fill(positions, order)
+  #   after.each { |p| puts "#{p.symbol}: #{p.quantity_direction} #{p.quantity.to_s("F")}" }
+  module PositionSimulator
+    # Leg instrument types mapped to the instrument types used by positions
+    INSTRUMENT_TYPES = {
+      "Option" => "Equity Option"
+    }.freeze
+
+    class << self
+      # Compute positions as if the order filled completely
+      #
+      # @param positions [Array<Models::CurrentPosition>] Current positions
+      # @param order [Order] Order to simulate
+      # @param multipliers [Hash{String => Numeric}] Multipliers by symbol for futures legs
+      #   that open new positions, e.g. from {Instruments::FutureOption#multiplier}
+      # @return [Array<Models::CurrentPosition>] Resulting open positions
+      # @raise [InvalidOrderError] if a closing leg exceeds the position it closes, the order is
+      #   notional, or a new futures position's multiplier is unknown
+      def simulate_fill(positions, order, multipliers: {})
+        book = positions.reject(&:closed?).to_h { |position| [book_key(position.symbol), position_data(position)] }
+
+        order.legs.each do |leg|
+          raise InvalidOrderError, "Cannot simulate a notional order for #{leg.symbol}" if leg.quantity.nil?
+
+          data = book[book_key(leg.symbol)] ||= new_position_data(leg, multipliers[leg.symbol])
+          current = signed_quantity(data)
+          change = buy?(leg.action) ? leg.quantity : -leg.quantity
+
+          validate_close!(leg, current, change) if closing?(leg.action)
+
+          apply_quantity(data, current + change)
+        end
+
+        book.values
+            .reject { |data| data["quantity-direction"] == "Zero" }
+            .map { |data| Models::CurrentPosition.new(data) }
+      end
+
+      private
+
+      # "SPY   240119P00440000" and "SPY 240119P00440000" are the same option
+      def book_key(symbol)
+        symbol.to_s.gsub(/\s+/, " ")
+      end
+
+      def validate_close!(leg, current, change)
+        held_opposite = (current.positive? && change.negative?) || (current.negative? && change.positive?)
+        return if held_opposite && change.abs <= current.abs
+
+        held = current.zero? ? "no position" : "#{current.abs.to_s("F")} #{current.positive? ? "long" : "short"}"
+        raise InvalidOrderError,
+              "Cannot #{leg.action.downcase} #{leg.quantity} #{leg.symbol}: #{held} held"
+      end
+
+      def position_data(position)
+        position.data.merge(
+          "quantity" => position.quantity.abs.to_s("F"),
+          "quantity-direction" => position.quantity_direction
+        )
+      end
+
+      def new_position_data(leg, multiplier)
+        multiplier = ContractMultiplier.for(leg.instrument_type, multiplier)
+        raise InvalidOrderError, "Multiplier for #{leg.symbol} is unknown; pass it in multipliers:" unless multiplier
+
+        {
+          "symbol" => leg.symbol,
+          "instrument-type" => INSTRUMENT_TYPES.fetch(leg.instrument_type, leg.instrument_type),
+          "underlying-symbol" => leg.symbol.split.first,
+          "quantity" => "0",
+          "quantity-direction" => "Zero",
+          "multiplier" => multiplier
+        }
+      end
+
+      def signed_quantity(data)
+        quantity = BigDecimal(data["quantity"].to_s)
+        data["quantity-direction"] == "Short" ? -quantity : quantity
+      end
+
+      def apply_quantity(data, quantity)
+        data["quantity"] = quantity.abs.to_s("F")
+        data["quantity-direction"] = if quantity.positive?
+                                       "Long"
+                                     elsif quantity.negative?
+                                       "Short"
+                                     else
+                                       "Zero"
+                                     end
+      end
+
+      def buy?(action)
+        [OrderAction::BUY_TO_OPEN, OrderAction::BUY_TO_CLOSE].include?(action)
+      end
+
+      def closing?(action)
+        [OrderAction::BUY_TO_CLOSE, OrderAction::SELL_TO_CLOSE].include?(action)
+      end
+    end
+  end
+end
@@ -0,0 +1,96 @@
+# frozen_string_literal: true
+
+require "faraday"
+
+module Tastytrade
+  # Token-bucket limiter that keeps requests under a per-minute cap.
+  #
+  # The bucket starts full, holding one minute's worth of tokens, and refills
+  # continuously. {#acquire} takes a token, sleeping until one is available.
+  # Safe to share between threads.
+  #
+  # @example
+  #   limiter = RateLimiter.new(120)
+  #   limiter.acquire # returns immediately while tokens remain
+  class RateLimiter
+    # Faraday middleware that takes a token before each attempt, including retries
+    class Middleware < Faraday::Middleware
+      def initialize(app, limiter)
+        super(app)
+        @limiter = limiter
+      end
+
+      def on_request(_env)
+        @limiter.acquire
+      end
+    end
+
+    attr_reader :requests_per_minute
+
+    # @param requests_per_minute [Integer] Sustained request rate and bucket size
+    # @param clock [#call] Returns the current time in seconds; monotonic by default
+    # @param sleeper [#call] Sleeps for the given number of seconds
+    def initialize(requests_per_minute, clock: nil, sleeper: nil)
+      unless requests_per_minute.is_a?(Integer) && requests_per_minute.positive?
+        raise ArgumentError, "Requests per minute must be a positive integer"
+      end
+
+      @requests_per_minute = requests_per_minute
+      @clock = clock || -> { Process.clock_gettime(Process::CLOCK_MONOTONIC) }
+      @sleeper = sleeper || ->(seconds) { sleep(seconds) }
+      @tokens = requests_per_minute.to_f
+      @updated_at = @clock.call
+      @mutex = Mutex.new
+    end
+
+    # Takes a token, blocking until one is available
+    #
+    # @return [Float] Seconds spent waiting
+    def acquire
+      waited = 0.0
+      loop do
+        wait = @mutex.synchronize { take_token }
+        return waited if wait.zero?
+
+        @sleeper.call(wait)
+        waited += wait
+      end
+    end
+
+    # @return [Float] Tokens currently in the bucket
+    def available_tokens
+      @mutex.synchronize do
+        refill
+        @tokens
+      end
+    end
+
+    # @return [Hash] Current limiter state for logging or metrics
+    def state
+      { requests_per_minute: @requests_per_minute, available_tokens: available_tokens }
+    end
+
+    private
+
+    # Returns 0 when a token was taken, otherwise the seconds until one is due
+    def take_token
+      refill
+      if @tokens >= 1
+        @tokens -= 1
+        0
+      else
+        (1 - @tokens) / tokens_per_second
+      end
+    end
+
+    def refill
+      now = @clock.call
+      @tokens = [@tokens + ((now - @updated_at) * tokens_per_second), @requests_per_minute.to_f].min
+      @updated_at = now
+    end
+
+    def tokens_per_second
+      @requests_per_minute / 60.0
+    end
+  end
+end
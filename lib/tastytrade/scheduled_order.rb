@@ -0,0 +1,148 @@
+# frozen_string_literal: true
+
+require_relative "market_hours"
+
+module Tastytrade
+  # Client-side handle for an order submitted at a later time.
+  #
+  # The Tastytrade API has no scheduled orders, so scheduling runs in a
+  # background thread of the current process. The order is only placed if the
+  # process is still running at the scheduled time; nothing is stored on the
+  # server until then. Cancelling before submission stops the thread.
+  #
+  # @example Place an order at the next market open
+  #   scheduled = account.schedule_order(session, order, at: :open)
+  #   scheduled.scheduled_at # => 2024-01-16 09:30:00 -0500
+  #   scheduled.cancel       # changed our mind
+  #
+  # @example Wait for submission
+  #   scheduled = account.schedule_order(session, order, at: Time.now + 60)
+  #   scheduled.wait
+  #   puts scheduled.response.order_id if scheduled.submitted?
+  class ScheduledOrder
+    STATES = %i[pending submitting submitted cancelled failed].freeze
+
+    # @return [Order] Order to be placed
+    attr_reader :order
+
+    # @return [Time] Time the order will be submitted
+    attr_reader :scheduled_at
+
+    # Resolve a schedule target to a time
+    #
+    # @param at [Time, Symbol] Target time, or :open for the next regular session open
+    # @param now [Time] Reference time
+    # @return [Time] Submission time
+    # @raise [ArgumentError] if the target is not a Time or :open
+    def self.resolve_time(at, now: Time.now)
+      case at
+      when :open then MarketHours.next_open(now)
+      when Time then at
+      else
+        raise ArgumentError, "Scheduled time must be a Time or :open"
+      end
+    end
+
+    # @param order [Order] Order to place
+    # @param scheduled_at [Time] Time to submit the order
+    # @yield Submits the order and returns the API response
+    def initialize(order:, scheduled_at:, &submit)
+      raise ArgumentError, "A submit block is required" unless submit
+
+      @order = order
+      @scheduled_at = scheduled_at
+      @submit = submit
+      @state = :pending
+      @response = nil
+      @error = nil
+      @mutex = Mutex.new
+      @condition = ConditionVariable.new
+      @thread = nil
+    end
+
+    # Start the background thread that submits the order
+    #
+    # @return [ScheduledOrder] Self
+    def start
+      @thread ||= Thread.new { run }
+      self
+    end
+
+    # @return [Symbol] One of {STATES}
+    def state
+      @mutex.synchronize { @state }
+    end
+
+    STATES.each do |name|
+      define_method("#{name}?") { state == name }
+    end
+
+    # @return [OrderResponse, nil] Response once submitted
+    def response
+      @mutex.synchronize { @response }
+    end
+
+    # @return [Exception, nil] Error raised while submitting
+    def error
+      @mutex.synchronize { @error }
+    end
+
+    # Cancel the order if it has not been submitted yet
+    #
+    # @return [Boolean] true if the order was cancelled before submission
+    def cancel
+      @mutex.synchronize do
+        return false unless @state == :pending
+
+        @state = :cancelled
+        @condition.broadcast
+        true
+      end
+    end
+
+    # Block until the order is submitted, cancelled, or fails
+    #
+    # @param timeout [Numeric, nil] Maximum seconds to wait
+    # @return [ScheduledOrder] Self
+    def wait(timeout = nil)
+      @thread&.join(timeout)
+      self
+    end
+
+    # @return [Float] Seconds remaining until submission (0 when due)
+    def seconds_until_submission
+      [@scheduled_at - Time.now, 0].max.to_f
+    end
+
+    private
+
+    def run
+      @mutex.synchronize do
+        while @state == :pending
+          remaining = seconds_until_submission
+          break if remaining.zero?
+
+          @condition.wait(@mutex, remaining)
+        end
+        return unless @state == :pending
+
+        @state = :submitting
+      end
+
+      submit
+    end
+
+    def submit
+      result = @submit.call(@order)
+      @mutex.synchronize do
+        @response = result
+        @state = :submitted
+      end
+    rescue StandardError => e
+      @mutex.synchronize do
+        @error = e
+        @state = :failed
+      end
+    end
+  end
+end
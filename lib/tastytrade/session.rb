@@ -1,27 +1,72 @@
 # frozen_string_literal: true
 
 require_relative "models"
+require_relative "order_defaults"
+require_relative "shared_session"
 
 module Tastytrade
   # Manages authentication and session state for Tastytrade API
   class Session
-    attr_reader :user, :session_token, :remember_token, :is_test, :session_expiration
+    attr_reader :is_test, :order_defaults, :shared_session, :chain_cache_ttl
+
+    # Default environment variable prefixes, checked in order
+    ENV_PREFIXES = %w[TASTYTRADE TT].freeze
+
+    # Seconds before expiration at which requests refresh the session with the remember token
+    REFRESH_THRESHOLD = 300
+
+    # Seconds before expiration at which a cached quote token is replaced
+    QUOTE_TOKEN_REFRESH_THRESHOLD = 60
+
+    # Option chain endpoints whose responses are cached when chain_cache_ttl is set
+    CHAIN_CACHE_PATH = %r{\A/?option-chains/}
+
+    # Logout responses meaning the server no longer has the session
+    LOGGED_OUT_STATUSES = [401, 403, 404].freeze
+
+    # Create a session from state saved with {#export_state}, without logging in
+    #
+    # Requests refresh an expired or expiring token with the saved remember
+    # token as usual, see {#ensure_valid_token}. Remember me defaults to on when
+    # a remember token was saved, so each refresh yields the next one.
+    #
+    # @param state [Hash] Session state, with string or symbol keys
+    # @param options [Hash] Other {#initialize} options, e.g. timeout:
+    # @return [Session] Session with the saved tokens
+    #
+    # @example Resume from disk
+    #   session = Session.from_state(JSON.parse(File.read("session.json")))
+    def self.from_state(state, **options)
+      state = state.transform_keys(&:to_s)
+      options = { remember_me: !state["remember_token"].nil? }.merge(options)
+      new(username: state["username"], is_test: state["is_test"] == true, base_url: state["base_url"], **options)
+        .restore_state(state)
+    end
 
     # Create a session from environment variables
     #
+    # Reads {PREFIX}_USERNAME, {PREFIX}_PASSWORD, {PREFIX}_REMEMBER and
+    # {PREFIX}_ENVIRONMENT. Without a prefix, TASTYTRADE_ and TT_ are checked in
+    # that order. The session is constructed but not logged in.
+    #
+    # @param is_test [Boolean, nil] Use test environment (reads {PREFIX}_ENVIRONMENT when nil)
+    # @param prefix [String, nil] Custom variable prefix, e.g. "TT_TEST" for integration tests
     # @return [Session, nil] Session instance or nil if environment variables not set
-    def self.from_environment(is_test: nil)
-      username = ENV["TASTYTRADE_USERNAME"] || ENV["TT_USERNAME"]
-      password = ENV["TASTYTRADE_PASSWORD"] || ENV["TT_PASSWORD"]
+    #
+    # @example Load sandbox credentials for integration tests
+    #   session = Session.from_environment(prefix: "TT_TEST")&.login
+    def self.from_environment(is_test: nil, prefix: nil)
+      prefixes = prefix ? [prefix.to_s.chomp("_")] : ENV_PREFIXES
+      username = env_value("USERNAME", prefixes)
+      password = env_value("PASSWORD", prefixes)
 
       return nil unless username && password
 
-      remember = ENV["TASTYTRADE_REMEMBER"]&.downcase == "true" || ENV["TT_REMEMBER"]&.downcase == "true"
+      remember = prefixes.any? { |p| ENV["#{p}_REMEMBER"]&.downcase == "true" }
 
       # Use passed is_test value, or check environment variable as fallback
       if is_test.nil?
-        is_test = ENV["TASTYTRADE_ENVIRONMENT"]&.downcase == "sandbox" ||
-                  ENV["TT_ENVIRONMENT"]&.downcase == "sandbox"
+        is_test = prefixes.any? { |p| ENV["#{p}_ENVIRONMENT"]&.downcase == "sandbox" }
       end
 
       new(
@@ -32,39 +77,203 @@ module Tastytrade
       )
     end
 
+    # @return [String, nil] First value found for the variable across prefixes
+    def self.env_value(name, prefixes)
+      prefixes.each do |prefix|
+        value = ENV["#{prefix}_#{name}"]
+        return value if value
+      end
+      nil
+    end
+    private_class_method :env_value
+
     # Initialize a new session
     #
+    # A session built with a remember token and no password logs in
+    # automatically on its first authenticated request, so tools can stay logged
+    # in across runs without storing the password.
+    #
     # @param username [String] Tastytrade username
     # @param password [String] Tastytrade password (optional if remember_token provided)
     # @param remember_me [Boolean] Whether to save remember token
     # @param remember_token [String] Existing remember token for re-authentication
     # @param is_test [Boolean] Use test environment
-    def initialize(username:, password: nil, remember_me: false, remember_token: nil, is_test: false, timeout: Client::DEFAULT_TIMEOUT)
+    # @param base_url [String, nil] Override the production or sandbox API URL, e.g. for a
+    #   proxy or a mock server
+    # @param timeout [Integer] Request timeout in seconds
+    # @param order_defaults [OrderDefaults, Hash, nil] Defaults applied when building orders
+    # @param shared_session [SharedSession, nil] Authentication state shared with other sessions
+    # @param chain_cache_ttl [Numeric, nil] Seconds to reuse option chain responses for, e.g. in a
+    #   scanner that revisits the same underlyings; nil disables caching
+    # @param normalize_symbols [Boolean] Trim and upper-case symbols passed to instrument, option
+    #   chain and quote lookups, so "aapl " finds AAPL; false sends them exactly as given
+    # @param client_options [Hash] Settings passed to the HTTP client
+    # @option client_options [Integer] :max_retries Retries for 429 and 5xx responses, 0 to disable
+    # @option client_options [Numeric] :retry_interval Delay in seconds before the first retry,
+    #   doubled on each retry
+    # @option client_options [Boolean] :retry_non_idempotent Also retry POST requests, which may
+    #   place an order twice
+    # @option client_options [Logger] :logger Debug logger for requests and session activity,
+    #   with tokens and passwords redacted
+    # @option client_options [String] :user_agent User-Agent header, defaults to
+    #   Client::DEFAULT_USER_AGENT
+    # @option client_options [Symbol, Array] :adapter Faraday adapter, e.g. [:test, stubs] in tests
+    # @option client_options [#observe_request] :metrics Hook told the latency and status of every
+    #   request, see {Metrics}
+    # @option client_options [Integer] :pool_size Reuse up to this many open connections instead of
+    #   reconnecting per request; requires the faraday-net_http_persistent gem
+    # @option client_options [Numeric] :idle_timeout Seconds before an unused pooled connection is closed
+    # @option client_options [Boolean] :strict_time_parsing Raise {TimeParseError} for malformed
+    #   timestamps in responses and at login instead of reading them as nil
+    #
+    # @example Resume with a saved remember token
+    #   session = Session.new(username: "user", remember_token: saved_token)
+    #   session.get("/customers/me") # logs in with the remember token first
+    def initialize(username:, password: nil, remember_me: false, remember_token: nil, is_test: false,
+                   base_url: nil, timeout: Client::DEFAULT_TIMEOUT, order_defaults: nil, shared_session: nil,
+                   chain_cache_ttl: nil, normalize_symbols: true, **client_options)
       @username = username
       @password = password
       @remember_me = remember_me
       @remember_token = remember_token
       @is_test = is_test
-      @client = Client.new(base_url: api_url, timeout: timeout)
+      @base_url = base_url
+      @client = Client.new(base_url: api_url, timeout: timeout, **client_options)
+      @logger = client_options[:logger] || Client.default_logger
+      @strict_time_parsing = client_options.fetch(:strict_time_parsing, false)
+      @shared_session = shared_session
+      @chain_cache_ttl = chain_cache_ttl
+      @normalize_symbols = normalize_symbols
+      @chain_cache = {}
+      @chain_cache_mutex = Mutex.new
+      self.order_defaults = order_defaults
+    end
+
+    # @return [Boolean] true if lookup symbols are trimmed and upper-cased, see {Tastytrade.normalize_symbol}
+    def normalize_symbols?
+      @normalize_symbols != false
+    end
+
+    # @return [Models::User, nil] Authenticated user
+    def user
+      @shared_session ? @shared_session.user : @user
+    end
+
+    # Customer for the authenticated user, fetched on first use and cached
+    #
+    # @param refresh [Boolean] Fetch again instead of using the cached customer
+    # @return [Models::Customer]
+    def current_customer(refresh: false)
+      @current_customer = nil if refresh
+      @current_customer ||= Models::Customer.get_current(self)
+    end
+
+    # Quote streamer token, fetched on first use and cached until within a
+    # minute of its expiration
+    #
+    # @param refresh [Boolean] Fetch a new token instead of using the cached one
+    # @return [Models::QuoteToken]
+    def quote_token(refresh: false)
+      if refresh || @quote_token.nil? || @quote_token.expires_within?(QUOTE_TOKEN_REFRESH_THRESHOLD)
+        @quote_token = Models::QuoteToken.get(self)
+      end
+      @quote_token
+    end
+
+    # @return [String] ID of the authenticated customer, e.g. for customer-scoped endpoints
+    def customer_id
+      current_customer.id
+    end
+
+    # Accounts of the authenticated customer
+    #
+    # @param include_closed [Boolean] Include closed accounts
+    # @return [Array<Models::Account>]
+    def accounts(include_closed: false)
+      Models::Account.get_all(self, include_closed: include_closed)
+    end
+
+    # Account streamer for order and position updates, not yet connected
+    #
+    # @param options [Hash] Options for {AccountStream#initialize}
+    # @return [AccountStream]
+    def account_stream(**options)
+      AccountStream.new(self, logger: @logger, **options)
+    end
+
+    # @return [String, nil] Current session token
+    def session_token
+      @shared_session ? @shared_session.session_token : @session_token
+    end
+
+    # @return [String, nil] Remember token for re-authentication
+    def remember_token
+      @shared_session&.remember_token || @remember_token
+    end
+
+    # @return [Time, nil] Session expiration time
+    def session_expiration
+      @shared_session ? @shared_session.session_expiration : @session_expiration
+    end
+
+    # Configure defaults used when building orders with this session
+    #
+    # @param time_in_force [String, nil] Default time in force
+    # @param account_number [String, nil] Default account number
+    # @param source [String, nil] Source tag for placed orders that have none
+    # @return [Session] Self for method chaining
+    # @raise [ArgumentError] if any default is invalid
+    def with_order_defaults(time_in_force: nil, account_number: nil, source: nil)
+      self.order_defaults = OrderDefaults.new(time_in_force: time_in_force, account_number: account_number,
+                                              source: source)
+      self
+    end
+
+    # Replace the order defaults for this session
+    #
+    # @param defaults [OrderDefaults, Hash, nil] New defaults (nil clears them)
+    # @raise [ArgumentError] if the defaults are invalid
+    def order_defaults=(defaults)
+      @order_defaults = case defaults
+                        when nil then OrderDefaults.new
+                        when OrderDefaults then defaults
+                        when Hash then OrderDefaults.new(**defaults.transform_keys(&:to_sym))
+                        else
+                          raise ArgumentError, "Order defaults must be an OrderDefaults or Hash"
+      end
     end
 
     # Authenticate with Tastytrade API
     #
     # @return [Session] Self for method chaining
+    # @raise [Tastytrade::TokenRefreshError] If the remember token is expired or invalid
+    # @raise [Tastytrade::TimeParseError] If the session expiration is malformed, in strict time parsing mode
     # @raise [Tastytrade::Error] If authentication fails
     def login
-      response = @client.post("/sessions", login_credentials)
+      response = begin
+        @client.post("/sessions", login_credentials)
+      rescue Tastytrade::AuthenticationError => e
+        raise unless remember_token_login?
+
+        raise Tastytrade::TokenRefreshError,
+              "Remember token is expired or invalid, log in again with your password (#{e.message})"
+      end
       data = response["data"]
 
       @user = Models::User.new(data["user"])
+      @current_customer = nil
+      @quote_token = nil
       @session_token = data["session-token"]
       @remember_token = data["remember-token"] if @remember_me
 
       # Track session expiration if provided
       if data["session-expiration"]
-        @session_expiration = Time.parse(data["session-expiration"])
+        @session_expiration = Tastytrade.parse_time(data["session-expiration"], strict: @strict_time_parsing)
       end
 
+      @shared_session&.update(user: @user, session_token: @session_token,
+                              remember_token: @remember_token, session_expiration: @session_expiration)
+
       self
     end
 
@@ -72,25 +281,43 @@ module Tastytrade
     #
     # @return [Boolean] True if session is valid
     def validate
-      warn "DEBUG: Validating session, user=#{@user&.email}" if ENV["DEBUG_SESSION"]
+      @logger&.debug("Validating session, user=#{user&.email}")
       response = get("/sessions/validate")
-      if ENV["DEBUG_SESSION"]
-        warn "DEBUG: Validate response email=#{response["data"]["email"]}, user email=#{@user&.email}"
-      end
-      response["data"]["email"] == @user.email
+      @logger&.debug("Validate response email=#{response["data"]["email"]}, user email=#{user&.email}")
+      response["data"]["email"] == user.email
     rescue Tastytrade::Error => e
-      warn "DEBUG: Validate error: #{e.message}" if ENV["DEBUG_SESSION"]
+      @logger&.debug("Validate error: #{e.message}")
       false
     end
 
     # Destroy current session
     #
+    # The current token is sent as-is, never refreshed first. A session the
+    # server has already expired or removed counts as logged out, so this is
+    # safe to call during shutdown or to retry. Local state is kept when the
+    # request fails for another reason, e.g. a server error.
+    #
     # @return [nil]
+    # @raise [Tastytrade::Error] if the logout request fails other than with 401, 403 or 404
     def destroy
-      delete("/sessions") if @session_token
+      token = session_token
+      if token && expired?
+        @logger&.debug("Session already expired, skipping logout request")
+      elsif token
+        begin
+          @client.delete("/sessions", { "Authorization" => token })
+        rescue Tastytrade::Error => e
+          raise unless LOGGED_OUT_STATUSES.include?(e.status)
+
+          @logger&.debug("Session already logged out (#{e.status})")
+        end
+      end
       @session_token = nil
       @remember_token = nil
       @user = nil
+      @current_customer = nil
+      @quote_token = nil
+      @shared_session&.clear
     end
 
     # Make authenticated GET request
@@ -99,9 +326,26 @@ module Tastytrade
     # @param params [Hash] Query parameters
     # @return [Hash] Parsed response
     def get(path, params = {})
+      return cached_chain(path, params) { @client.get(path, params, auth_headers) } if cache_chain?(path)
+
       @client.get(path, params, auth_headers)
     end
 
+    # Drops every cached option chain, so the next request for each is fetched
+    def clear_chain_cache
+      @chain_cache_mutex.synchronize { @chain_cache.clear }
+    end
+
+    # Make authenticated GET request to a list endpoint, yielding items as they are read
+    #
+    # @param path [String] API endpoint path
+    # @param params [Hash] Query parameters
+    # @yieldparam item [Hash] Each item of the response
+    # @return [nil]
+    def get_items(path, params = {}, &block)
+      @client.get_items(path, params, auth_headers, &block)
+    end
+
     # Make authenticated POST request
     #
     # @param path [String] API endpoint path
@@ -132,31 +376,105 @@ module Tastytrade
     #
     # @return [Boolean] True if session has token
     def authenticated?
-      !@session_token.nil?
+      !session_token.nil?
+    end
+
+    # Snapshot of the session's tokens, for persisting between runs with
+    # {.from_state}. The hash has only string keys and values, so it can be
+    # written as JSON. It holds live credentials: store it like a password.
+    #
+    # @return [Hash] Session state
+    #
+    # @example Save to disk
+    #   File.write("session.json", JSON.generate(session.export_state))
+    def export_state
+      {
+        "username" => @username,
+        "session_token" => session_token,
+        "remember_token" => remember_token,
+        "session_expiration" => session_expiration&.iso8601,
+        "base_url" => api_url,
+        "is_test" => @is_test,
+        "user" => user && {
+          "email" => user.email,
+          "username" => user.username,
+          "external-id" => user.external_id,
+          "is-professional" => user.is_professional
+        }
+      }
+    end
+
+    # Restore tokens saved with {#export_state}
+    #
+    # @param state [Hash] Session state, with string or symbol keys
+    # @return [Session] Self
+    def restore_state(state)
+      state = state.transform_keys(&:to_s)
+
+      @session_token = state["session_token"]
+      @remember_token = state["remember_token"]
+      @session_expiration = state["session_expiration"] && Time.parse(state["session_expiration"].to_s)
+      @user = Models::User.new(state["user"]) if state["user"]
+      @shared_session&.update(user: @user, session_token: @session_token,
+                              remember_token: @remember_token, session_expiration: @session_expiration)
+      self
     end
 
     # Check if session is expired
     #
     # @return [Boolean] True if session is expired
     def expired?
-      return false unless @session_expiration
-      Time.now >= @session_expiration
+      return false unless session_expiration
+      Time.now >= session_expiration
     end
 
     # Time remaining until session expires
     #
     # @return [Float, nil] Seconds until expiration
     def time_until_expiry
-      return nil unless @session_expiration
-      @session_expiration - Time.now
+      return nil unless session_expiration
+      session_expiration - Time.now
+    end
+
+    # Check if the session expires within the given number of seconds
+    #
+    # @param seconds [Numeric] Window to check
+    # @return [Boolean] True if the session expires within the window
+    def expiring_soon?(seconds = REFRESH_THRESHOLD)
+      return false unless session_expiration
+      time_until_expiry <= seconds
+    end
+
+    # Refresh the session with the remember token if it expires within
+    # {REFRESH_THRESHOLD} seconds. Called before every authenticated request.
+    #
+    # A failed refresh is only raised once the session has actually expired;
+    # until then the current token keeps being used.
+    #
+    # @return [Session] Self
+    # @raise [Tastytrade::TokenRefreshError] If the session has expired and the remember token is rejected
+    def ensure_valid_token
+      return self unless remember_token && session_token && expiring_soon?
+
+      refresh_session
+    rescue Tastytrade::AuthenticationError => e
+      raise if expired?
+
+      @logger&.debug("Session refresh failed, using current token until it expires: #{e.message}")
+      self
     end
 
     # Refresh session using remember token
     #
+    # With a shared session, concurrent refreshes of the same token result in a
+    # single login; the other sessions pick up the new token.
+    #
     # @return [Session] Self
     # @raise [Tastytrade::Error] If refresh fails
     def refresh_session
-      raise Tastytrade::Error, "No remember token available" unless @remember_token
+      raise Tastytrade::Error, "No remember token available" unless remember_token
+
+      return refresh_shared_session if @shared_session
 
       # Clear password and re-login with remember token
       @password = nil
@@ -165,14 +483,61 @@ module Tastytrade
 
     private
 
+    def cache_chain?(path)
+      !@chain_cache_ttl.nil? && path.match?(CHAIN_CACHE_PATH)
+    end
+
+    # Responses are kept per path and parameters until the TTL passes. Expired
+    # entries are evicted whenever a response is stored, and each caller gets
+    # its own copy so changes to one response don't leak into the next.
+    def cached_chain(path, params)
+      key = [path, params]
+      now = Process.clock_gettime(Process::CLOCK_MONOTONIC)
+      cached = @chain_cache_mutex.synchronize { @chain_cache[key] }
+      return deep_copy(cached[:response]) if cached && cached[:expires_at] > now
+
+      response = yield
+      @chain_cache_mutex.synchronize do
+        @chain_cache.delete_if { |_, entry| entry[:expires_at] <= now }
+        @chain_cache[key] = { response: deep_copy(response), expires_at: now + @chain_cache_ttl }
+      end
+      response
+    end
+
+    def deep_copy(response)
+      Marshal.load(Marshal.dump(response))
+    end
+
+    def refresh_shared_session
+      @shared_session.refresh(session_token) do
+        @password = nil
+        @remember_token = remember_token
+        login
+      end
+      self
+    end
+
     def api_url
+      return @base_url if @base_url
+
       @is_test ? Tastytrade::CERT_URL : Tastytrade::API_URL
     end
 
     def auth_headers
-      raise Tastytrade::Error, "Not authenticated" unless @session_token
+      if session_token.nil?
+        login if remember_token_login?
+      else
+        ensure_valid_token
+      end
+
+      token = session_token
+      raise Tastytrade::Error, "Not authenticated" unless token
+
+      { "Authorization" => token }
+    end
 
-      { "Authorization" => @session_token }
+    def remember_token_login?
+      !remember_token.nil? && @password.nil?
     end
 
     def login_credentials
@@ -182,8 +547,8 @@ module Tastytrade
       }
 
       # Use remember token if available and no password
-      if @remember_token && !@password
-        credentials["remember-token"] = @remember_token
+      if remember_token_login?
+        credentials["remember-token"] = remember_token
       else
         credentials["password"] = @password
       end
@@ -104,6 +104,13 @@ module Tastytrade
       true
     end
 
+    # Saved remember token, e.g. to log in again without a password
+    #
+    # @return [String, nil] Token saved by a login with remember, or nil
+    def remember_token
+      load_remember_token
+    end
+
     # Check if we have stored credentials
     def saved_credentials?
       !load_password.nil? || !load_remember_token.nil?
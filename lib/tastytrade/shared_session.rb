@@ -0,0 +1,97 @@
+# frozen_string_literal: true
+
+require "monitor"
+
+module Tastytrade
+  # Authentication state shared by several Session instances.
+  #
+  # Applications with more than one component (for example a REST worker and a
+  # streaming worker) can attach their sessions to one SharedSession so that a
+  # login or refresh performed by any of them is immediately visible to all,
+  # avoiding duplicate logins and diverging tokens. All access is synchronized.
+  #
+  # @example Share authentication between two sessions
+  #   shared = Tastytrade::SharedSession.new
+  #   rest = Tastytrade::Session.new(username: "user", password: "pass", shared_session: shared).login
+  #   streaming = Tastytrade::Session.new(username: "user", shared_session: shared)
+  #   streaming.authenticated? # => true
+  class SharedSession
+    def initialize
+      @monitor = Monitor.new
+      @user = nil
+      @session_token = nil
+      @remember_token = nil
+      @session_expiration = nil
+    end
+
+    # @return [Models::User, nil] Authenticated user
+    def user
+      synchronize { @user }
+    end
+
+    # @return [String, nil] Current session token
+    def session_token
+      synchronize { @session_token }
+    end
+
+    # @return [String, nil] Current remember token
+    def remember_token
+      synchronize { @remember_token }
+    end
+
+    # @return [Time, nil] Session expiration time
+    def session_expiration
+      synchronize { @session_expiration }
+    end
+
+    # Store new authentication state
+    #
+    # @param user [Models::User, nil] Authenticated user
+    # @param session_token [String, nil] Session token
+    # @param remember_token [String, nil] Remember token (kept when nil)
+    # @param session_expiration [Time, nil] Session expiration time
+    # @return [void]
+    def update(user:, session_token:, remember_token: nil, session_expiration: nil)
+      synchronize do
+        @user = user
+        @session_token = session_token
+        @remember_token = remember_token if remember_token
+        @session_expiration = session_expiration
+      end
+    end
+
+    # Clear all authentication state
+    #
+    # @return [void]
+    def clear
+      synchronize do
+        @user = nil
+        @session_token = nil
+        @remember_token = nil
+        @session_expiration = nil
+      end
+    end
+
+    # Run a refresh unless another session already replaced the stale token.
+    #
+    # Concurrent callers that observed the same expired token are serialized: the
+    # first one refreshes, the others see the new token and skip the request.
+    #
+    # @param stale_token [String, nil] Token the caller observed before refreshing
+    # @yield Performs the refresh (re-entrant, may call {#update})
+    # @return [Boolean] true if the block ran
+    def refresh(stale_token)
+      synchronize do
+        return false if @session_token && @session_token != stale_token
+
+        yield
+        true
+      end
+    end
+
+    # Run a block while holding the lock. The lock is re-entrant.
+    def synchronize(&block)
+      @monitor.synchronize(&block)
+    end
+  end
+end
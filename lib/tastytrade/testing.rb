@@ -0,0 +1,64 @@
+# frozen_string_literal: true
+
+require "faraday"
+require "json"
+require_relative "../tastytrade"
+
+module Tastytrade
+  # In-memory sessions for testing code built on this gem without network access
+  #
+  # Requests are answered by stubs on Faraday's test adapter instead of the
+  # API. Stub paths are absolute API paths; a stub without a query string
+  # matches any query. Unmatched requests raise
+  # Faraday::Adapter::Test::Stubs::NotFound. This file is not loaded by
+  # `require "tastytrade"`; require it from your test helper.
+  #
+  # @example Stub an instrument lookup
+  #   require "tastytrade/testing"
+  #
+  #   session = Tastytrade::Testing.session do |stub|
+  #     stub.get("/instruments/equities/AAPL") { Tastytrade::Testing.response("symbol" => "AAPL") }
+  #   end
+  #   Tastytrade::Instruments::Equity.get(session, "AAPL").symbol  # => "AAPL"
+  #
+  # @example Check what was sent
+  #   stubs = Faraday::Adapter::Test::Stubs.new
+  #   stubs.post("/accounts/5WT0001/orders") do |env|
+  #     expect(JSON.parse(env.body)["order-type"]).to eq("Limit")
+  #     Tastytrade::Testing.response("order" => { "id" => 1, "status" => "Received" })
+  #   end
+  #   session = Tastytrade::Testing.session(stubs)
+  #   account.place_order(session, order, skip_validation: true)
+  #   stubs.verify_stubbed_calls
+  module Testing
+    SESSION_TOKEN = "test-session-token"
+    USERNAME = "test-user"
+
+    module_function
+
+    # Builds an authenticated session whose requests are answered by stubs
+    #
+    # Retries are disabled so stubbed error responses raise immediately.
+    #
+    # @param stubs [Faraday::Adapter::Test::Stubs] Stubs answering requests
+    # @param options [Hash] Other {Session#initialize} options, e.g. is_test:
+    # @yieldparam stubs [Faraday::Adapter::Test::Stubs] Stubs to add to
+    # @return [Session] Session with a fixed token
+    def session(stubs = Faraday::Adapter::Test::Stubs.new, **options)
+      yield stubs if block_given?
+
+      state = { "username" => USERNAME, "session_token" => SESSION_TOKEN, "is_test" => options.delete(:is_test) }
+      Session.from_state(state, max_retries: 0, **options, adapter: [:test, stubs])
+    end
+
+    # A JSON response in the API's envelope, for returning from a stub
+    #
+    # @param data [Hash, nil] Value of the "data" key
+    # @param status [Integer] HTTP status
+    # @param body [Hash, nil] Full response body, used instead of data, e.g. for errors
+    # @return [Array(Integer, Hash, String)] Status, headers and body
+    def response(data = nil, status: 200, body: nil)
+      [status, { "Content-Type" => "application/json" }, (body || { "data" => data }).to_json]
+    end
+  end
+end
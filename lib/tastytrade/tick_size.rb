@@ -0,0 +1,109 @@
+# frozen_string_literal: true
+
+require "bigdecimal"
+
+module Tastytrade
+  # Price increment helpers for tiered tick sizes.
+  #
+  # Instruments publish tick sizes as tiers, e.g. options trading in $0.01
+  # increments below $3.00 and $0.05 at or above it:
+  #
+  #   [{ "value" => "0.01", "threshold" => "3.0" }, { "value" => "0.05" }]
+  #
+  # A tier applies to prices below its threshold; the last tier has no threshold
+  # and covers everything above. Computed prices (mid-points, percentage offsets)
+  # rarely land on a valid increment, and the API rejects them.
+  #
+  # @example Round a mid-price before submitting
+  #   price = Tastytrade::TickSize.round_price(session, "SPY 240119C00450000", BigDecimal("3.1234"))
+  #   # => BigDecimal("3.10")
+  module TickSize
+    DIRECTIONS = %i[nearest up down].freeze
+
+    class << self
+      # Find the increment that applies to a price
+      #
+      # @param price [Numeric] Price to check
+      # @param tick_sizes [Array<Hash>] Tick size tiers with "value" and optional "threshold"
+      # @return [BigDecimal, nil] Increment or nil if no tiers are given
+      def increment_for(price, tick_sizes)
+        price = BigDecimal(price.to_s)
+        tier = tick_sizes.find do |tick|
+          threshold = fetch(tick, "threshold")
+          threshold.nil? || price < BigDecimal(threshold.to_s)
+        end
+        tier ||= tick_sizes.last
+        return nil unless tier
+
+        BigDecimal(fetch(tier, "value").to_s)
+      end
+
+      # Round a price to a valid increment
+      #
+      # @param price [Numeric] Price to round
+      # @param tick_sizes [Array<Hash>] Tick size tiers
+      # @param direction [Symbol] :nearest, :up or :down
+      # @return [BigDecimal] Rounded price (unchanged if no tiers are given)
+      # @raise [ArgumentError] if the direction is unknown
+      def round(price, tick_sizes, direction: :nearest)
+        unless DIRECTIONS.include?(direction)
+          raise ArgumentError, "Invalid rounding direction: #{direction}. Must be one of: #{DIRECTIONS.join(", ")}"
+        end
+
+        price = BigDecimal(price.to_s)
+        increment = increment_for(price, tick_sizes)
+        return price if increment.nil? || increment.zero?
+
+        steps = price / increment
+        steps = case direction
+                when :up then steps.ceil
+                when :down then steps.floor
+                else steps.round(0, BigDecimal::ROUND_HALF_UP)
+        end
+
+        steps * increment
+      end
+
+      # @param price [Numeric] Price to check
+      # @param tick_sizes [Array<Hash>] Tick size tiers
+      # @return [Boolean] true if the price is a whole number of increments
+      def valid?(price, tick_sizes)
+        increment = increment_for(price, tick_sizes)
+        return true if increment.nil? || increment.zero?
+
+        (BigDecimal(price.to_s) % increment).zero?
+      end
+
+      # Fetch the tick sizes for a symbol and round a price to them
+      #
+      # Option symbols use the underlying's option tick sizes; anything else uses
+      # the equity tick sizes.
+      #
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity or OCC option symbol
+      # @param price [Numeric] Price to round
+      # @param direction [Symbol] :nearest, :up or :down
+      # @return [BigDecimal] Rounded price
+      def round_price(session, symbol, price, direction: :nearest)
+        round(price, tick_sizes_for(session, symbol), direction: direction)
+      end
+
+      # @param session [Tastytrade::Session] Active session
+      # @param symbol [String] Equity or OCC option symbol, padded or not
+      # @return [Array<Hash>] Tick size tiers for the symbol
+      def tick_sizes_for(session, symbol)
+        if OptionSymbol.valid?(symbol)
+          Instruments::Equity.get(session, OptionSymbol.parse(symbol).underlying).option_tick_sizes
+        else
+          Instruments::Equity.get(session, symbol).tick_sizes
+        end
+      end
+
+      private
+
+      def fetch(tick, key)
+        tick[key] || tick[key.to_sym]
+      end
+    end
+  end
+end
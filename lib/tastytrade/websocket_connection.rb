@@ -0,0 +1,117 @@
+# frozen_string_literal: true
+
+require "openssl"
+require "socket"
+require "timeout"
+require "uri"
+require "websocket/driver"
+
+module Tastytrade
+  # Minimal websocket client over a TCP or TLS socket
+  #
+  # Frames are encoded and decoded by websocket-driver; a reader thread feeds
+  # it socket data and delivers text messages to the on_message callback.
+  # Streams take a connector so tests can substitute a fake connection with
+  # the same interface: {#connect}, {#send_text}, {#close} and {#open?}.
+  class WebSocketConnection
+    READ_SIZE = 16_384
+
+    attr_reader :url
+
+    # @param url [String] ws:// or wss:// URL
+    # @param open_timeout [Numeric] Seconds to wait for the connection and handshake
+    def initialize(url, open_timeout: 10)
+      @url = url
+      @open_timeout = open_timeout
+      @open = false
+      @write_mutex = Mutex.new
+    end
+
+    # Opens the connection and starts reading
+    #
+    # @param on_message [#call] Called with each text message
+    # @param on_close [#call] Called with the reason once the connection closes
+    # @return [self]
+    # @raise [StreamError] if the connection or handshake fails
+    def connect(on_message:, on_close:)
+      @on_close = on_close
+      @socket = open_socket
+      @driver = WebSocket::Driver.client(self)
+      opened = Queue.new
+      @driver.on(:open) { opened << true }
+      @driver.on(:message) { |event| on_message.call(event.data) }
+      @driver.on(:close) { |event| closed("#{event.code} #{event.reason}".strip) }
+      @driver.start
+
+      @reader = Thread.new { read_loop }
+      Timeout.timeout(@open_timeout) { opened.pop }
+      @open = true
+      self
+    rescue Timeout::Error, SystemCallError, SocketError, OpenSSL::SSL::SSLError => e
+      @socket&.close
+      raise StreamError, "Could not connect to #{url}: #{e.message}"
+    end
+
+    # @param text [String] Message to send as a text frame
+    # @return [Boolean] true if the frame was queued
+    def send_text(text)
+      return false unless open?
+
+      @driver.text(text)
+    end
+
+    def close
+      return unless @driver
+
+      @open = false
+      @driver.close
+      @socket&.close
+      @reader&.join(1) unless Thread.current == @reader
+    end
+
+    def open?
+      @open
+    end
+
+    # Called by the driver with encoded frames
+    #
+    # @api private
+    def write(data)
+      @write_mutex.synchronize { @socket.write(data) }
+    rescue IOError, SystemCallError => e
+      closed(e.message)
+    end
+
+    private
+
+    def open_socket
+      uri = URI.parse(url)
+      secure = uri.scheme == "wss"
+      tcp = Socket.tcp(uri.host, uri.port || (secure ? 443 : 80), connect_timeout: @open_timeout)
+      return tcp unless secure
+
+      context = OpenSSL::SSL::SSLContext.new
+      context.set_params(verify_mode: OpenSSL::SSL::VERIFY_PEER)
+      ssl = OpenSSL::SSL::SSLSocket.new(tcp, context)
+      ssl.hostname = uri.host
+      ssl.sync_close = true
+      ssl.connect
+      ssl
+    end
+
+    def read_loop
+      loop { @driver.parse(@socket.readpartial(READ_SIZE)) }
+    rescue EOFError, IOError, SystemCallError, OpenSSL::SSL::SSLError => e
+      closed(e.message)
+    end
+
+    def closed(reason)
+      was_open = @open
+      @open = false
+      @socket&.close
+      callback = @on_close
+      @on_close = nil
+      callback&.call(reason) if was_open
+    end
+  end
+end
@@ -0,0 +1,243 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::AccountStream do
+  # Stands in for the streamer: records sent frames and lets tests push
+  # server messages or drop the connection
+  let(:fake_connection_class) do
+    Class.new do
+      attr_reader :sent
+
+      def initialize
+        @sent = []
+        @open = false
+      end
+
+      def connect(on_message:, on_close:)
+        @on_message = on_message
+        @on_close = on_close
+        @open = true
+        self
+      end
+
+      def send_text(text)
+        return false unless @open
+
+        @sent << JSON.parse(text)
+        true
+      end
+
+      def open?
+        @open
+      end
+
+      def close
+        @open = false
+      end
+
+      def push(message)
+        @on_message.call(message.is_a?(String) ? message : message.to_json)
+      end
+
+      def drop(reason = "1006")
+        @open = false
+        @on_close.call(reason)
+      end
+    end
+  end
+
+  let(:session) do
+    instance_double(Tastytrade::Session, session_token: "session-token", is_test: false, ensure_valid_token: nil)
+  end
+  let(:connections) { [] }
+  let(:connector) do
+    lambda do |_url|
+      fake_connection_class.new.tap { |connection| connections << connection }
+    end
+  end
+  let(:sleeps) { [] }
+  let(:stream) do
+    described_class.new(session, heartbeat_interval: nil, connector: connector, sleeper: ->(s) { sleeps << s })
+  end
+
+  let(:order_message) do
+    {
+      "type" => "Order",
+      "data" => {
+        "id" => 12345,
+        "account-number" => "5WT0001",
+        "status" => "Filled",
+        "underlying-symbol" => "AAPL",
+        "legs" => []
+      },
+      "timestamp" => 1_710_532_800_000
+    }
+  end
+
+  let(:position_message) do
+    {
+      "type" => "CurrentPosition",
+      "data" => {
+        "account-number" => "5WT0001",
+        "symbol" => "AAPL",
+        "instrument-type" => "Equity",
+        "quantity" => "100",
+        "quantity-direction" => "Long"
+      }
+    }
+  end
+
+  describe "#initialize" do
+    it "uses the production streamer by default" do
+      expect(stream.url).to eq(Tastytrade::STREAMER_URL)
+    end
+
+    it "uses the cert streamer for test sessions" do
+      allow(session).to receive(:is_test).and_return(true)
+
+      expect(described_class.new(session).url).to eq(Tastytrade::CERT_STREAMER_URL)
+    end
+  end
+
+  describe "#subscribe" do
+    it "sends a connect action with the session token" do
+      stream.connect.subscribe("5WT0001")
+
+      expect(connections.first.sent).to eq([
+        { "action" => "connect", "auth-token" => "session-token", "request-id" => 1, "value" => ["5WT0001"] }
+      ])
+    end
+
+    it "includes every subscribed account" do
+      stream.connect
+      stream.subscribe("5WT0001")
+      stream.subscribe("5WT0002")
+
+      expect(connections.first.sent.last["value"]).to eq(%w[5WT0001 5WT0002])
+    end
+
+    it "returns the existing subscription for an account" do
+      stream.connect
+
+      expect(stream.subscribe("5WT0001")).to equal(stream.subscribe("5WT0001"))
+    end
+
+    it "raises when not connected" do
+      expect { stream.subscribe("5WT0001") }.to raise_error(Tastytrade::StreamError, /not connected/)
+    end
+  end
+
+  describe "notifications" do
+    let!(:subscription) { stream.connect.subscribe("5WT0001") }
+
+    it "delivers order updates as live orders" do
+      connections.first.push(order_message)
+
+      order = subscription.orders.pop
+      expect(order).to be_a(Tastytrade::Models::LiveOrder)
+      expect(order.id).to eq(12345)
+      expect(order.status).to eq("Filled")
+    end
+
+    it "delivers position updates as current positions" do
+      connections.first.push(position_message)
+
+      position = subscription.positions.pop
+      expect(position).to be_a(Tastytrade::Models::CurrentPosition)
+      expect(position.symbol).to eq("AAPL")
+      expect(position.quantity).to eq(BigDecimal("100"))
+    end
+
+    it "ignores other accounts and notification types" do
+      connections.first.push(order_message.merge("data" => order_message["data"].merge("account-number" => "OTHER")))
+      connections.first.push("type" => "AccountBalance", "data" => { "account-number" => "5WT0001" })
+
+      expect(subscription.orders).to be_empty
+      expect(subscription.positions).to be_empty
+    end
+
+    it "ignores malformed frames" do
+      expect { connections.first.push("not json") }.not_to raise_error
+    end
+
+    it "records failed actions" do
+      connections.first.push("status" => "error", "action" => "connect", "message" => "invalid token")
+
+      expect(stream.last_error).to be_a(Tastytrade::StreamError)
+      expect(stream.last_error.message).to include("invalid token")
+    end
+  end
+
+  describe "#heartbeat" do
+    it "sends a heartbeat action" do
+      stream.connect
+      stream.heartbeat
+
+      expect(connections.first.sent.last).to eq(
+        "action" => "heartbeat", "auth-token" => "session-token", "request-id" => 1
+      )
+    end
+
+    it "refreshes the session token before sending" do
+      stream.connect
+      stream.heartbeat
+
+      expect(session).to have_received(:ensure_valid_token)
+    end
+  end
+
+  describe "reconnection" do
+    it "reconnects and subscribes again when the connection drops" do
+      subscription = stream.connect.subscribe("5WT0001")
+      connections.first.drop
+
+      expect(connections.size).to eq(2)
+      expect(connections.last.sent.last).to include("action" => "connect", "value" => ["5WT0001"])
+      expect(stream).to be_connected
+
+      connections.last.push(order_message)
+      expect(subscription.orders.pop.id).to eq(12345)
+    end
+
+    it "backs off and gives up after the maximum attempts" do
+      attempts = 0
+      failing = lambda do |url|
+        attempts += 1
+        raise Tastytrade::StreamError, "refused" if attempts > 1
+
+        connector.call(url)
+      end
+      stream = described_class.new(
+        session, heartbeat_interval: nil, connector: failing, max_reconnect_attempts: 3, sleeper: ->(s) { sleeps << s }
+      )
+      subscription = stream.connect.subscribe("5WT0001")
+
+      connections.first.drop
+
+      expect(sleeps).to eq([1, 2, 4])
+      expect(stream).to be_closed
+      expect(stream.last_error.message).to eq("refused")
+      expect(subscription.orders.pop).to be_nil
+    end
+
+    it "does not reconnect after close" do
+      stream.connect
+      stream.close
+      connections.first.drop
+
+      expect(connections.size).to eq(1)
+    end
+  end
+
+  describe "#close" do
+    it "closes the connection and subscription queues" do
+      subscription = stream.connect.subscribe("5WT0001")
+      stream.close
+
+      expect(stream).not_to be_connected
+      expect(subscription.orders).to be_closed
+      expect(subscription.positions.pop).to be_nil
+    end
+  end
+end
@@ -204,6 +204,71 @@ RSpec.describe "Tastytrade::CLI authentication commands" do
       end
     end
 
+    context "with --remember-token" do
+      before do
+        allow(config).to receive(:get).with("current_username").and_return("test@example.com")
+        allow(config).to receive(:set)
+        allow(session_manager).to receive(:remember_token).and_return("saved_remember")
+        allow(Tastytrade::Session).to receive(:new).and_return(session)
+        allow(session).to receive(:login).and_return(session)
+        allow(session).to receive(:user).and_return(user)
+      end
+
+      it "logs in with the saved remember token without asking for a password" do
+        expect(prompt).not_to receive(:mask)
+        expect(Tastytrade::Session).to receive(:new).with(
+          username: "test@example.com",
+          remember_token: "saved_remember",
+          remember_me: true,
+          is_test: false
+        ).and_return(session)
+
+        cli.options = { test: false, remember_token: true }
+        expect { cli.login }.to output(/Successfully logged in as test@example.com/).to_stdout
+      end
+
+      it "looks up the token for the given username and environment" do
+        expect(Tastytrade::SessionManager).to receive(:new)
+          .with(username: "other@example.com", environment: "sandbox").and_return(session_manager)
+
+        cli.options = { test: true, remember_token: true, username: "other@example.com" }
+        cli.login
+      end
+
+      it "asks for the username when none is saved" do
+        allow(config).to receive(:get).with("current_username").and_return(nil)
+        expect(prompt).to receive(:ask).with("Username:").and_return("test@example.com")
+
+        cli.options = { test: false, remember_token: true }
+        cli.login
+      end
+
+      it "saves the rotated remember token" do
+        expect(session_manager).to receive(:save_session).with(session, password: nil, remember: true)
+
+        cli.options = { test: false, remember_token: true }
+        cli.login
+      end
+
+      it "exits when no remember token is saved" do
+        allow(session_manager).to receive(:remember_token).and_return(nil)
+        expect(Tastytrade::Session).not_to receive(:new)
+        expect(cli).to receive(:exit).with(1)
+
+        cli.options = { test: false, remember_token: true }
+        expect { cli.login }.to output(/No saved remember token for test@example.com/).to_stderr
+      end
+
+      it "reports an expired remember token" do
+        allow(session).to receive(:login)
+          .and_raise(Tastytrade::TokenRefreshError, "Remember token is expired or invalid")
+        expect(cli).to receive(:exit).with(1)
+
+        cli.options = { test: false, remember_token: true }
+        expect { cli.login }.to output(/Remember token is expired or invalid/).to_stderr
+      end
+    end
+
     context "on unexpected error" do
       before do
         allow(prompt).to receive(:ask).with("Username:").and_return("test@example.com")
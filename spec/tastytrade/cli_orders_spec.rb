@@ -165,4 +165,82 @@ RSpec.describe "CLI Order Placement" do
       end
     end
   end
+
+  describe "trailing stop orders" do
+    before do
+      allow(account).to receive(:place_order).and_return(
+        instance_double(Tastytrade::Models::OrderResponse, order_id: "12345", buying_power_effect: nil, warnings: [],
+                                                           errors: [], status: "Routed")
+      )
+      allow(cli).to receive(:exit)
+    end
+
+    it "creates a trailing stop with the trailing value and type" do
+      expect(Tastytrade::Order).to receive(:new).with(
+        type: Tastytrade::OrderType::TRAILING_STOP,
+        time_in_force: Tastytrade::OrderTimeInForce::GTC,
+        legs: anything,
+        trailing_value: 5,
+        trailing_value_type: Tastytrade::TrailingValueType::PERCENTAGE
+      ).and_call_original
+
+      allow(cli).to receive(:options).and_return({
+                                                   symbol: "AAPL",
+                                                   action: "sell_to_close",
+                                                   quantity: 100,
+                                                   type: "trailing_stop",
+                                                   trailing_value: 5,
+                                                   trailing_type: "percentage",
+                                                   time_in_force: "gtc",
+                                                   skip_confirmation: true
+                                                 })
+
+      expect { cli.place }.not_to raise_error
+    end
+  end
+
+  describe "stop orders" do
+    before do
+      allow(account).to receive(:place_order).and_return(
+        instance_double(Tastytrade::Models::OrderResponse, order_id: "12345", buying_power_effect: nil, warnings: [],
+                                                           errors: [], status: "Routed")
+      )
+      allow(cli).to receive(:exit)
+    end
+
+    it "creates a stop order with the stop trigger" do
+      expect(Tastytrade::Order).to receive(:new).with(
+        type: Tastytrade::OrderType::STOP,
+        time_in_force: Tastytrade::OrderTimeInForce::DAY,
+        legs: anything,
+        stop_trigger: BigDecimal("145.5")
+      ).and_call_original
+
+      allow(cli).to receive(:options).and_return({
+                                                   symbol: "AAPL",
+                                                   action: "sell_to_close",
+                                                   quantity: 100,
+                                                   type: "stop",
+                                                   stop_trigger: 145.5,
+                                                   time_in_force: "day",
+                                                   skip_confirmation: true
+                                                 })
+
+      expect { cli.place }.not_to raise_error
+    end
+
+    it "requires a stop trigger" do
+      allow(cli).to receive(:options).and_return({
+                                                   symbol: "AAPL",
+                                                   action: "sell_to_close",
+                                                   quantity: 100,
+                                                   type: "stop",
+                                                   time_in_force: "day"
+                                                 })
+      allow(cli).to receive(:exit).and_raise(SystemExit)
+
+      expect { cli.place }.to raise_error(SystemExit)
+      expect(cli).to have_received(:error).with("Stop trigger is required for stop orders")
+    end
+  end
 end
@@ -12,6 +12,40 @@ RSpec.describe Tastytrade::Client do
     end
   end
 
+  describe "User-Agent" do
+    it "sends the default User-Agent with the gem version" do
+      stub = stub_request(:get, "#{base_url}/test")
+             .with(headers: { "User-Agent" => "tastytrade-ruby/#{Tastytrade::VERSION}" })
+             .to_return(status: 200, body: "{}")
+
+      client.get("/test")
+
+      expect(stub).to have_been_requested
+    end
+
+    it "sends a custom User-Agent on every method" do
+      custom = described_class.new(base_url: base_url, user_agent: "my-app/1.0")
+      stub_request(:any, "#{base_url}/test").to_return(status: 200, body: "{}")
+
+      custom.get("/test")
+      custom.post("/test", {})
+      custom.delete("/test")
+
+      expect(a_request(:any, "#{base_url}/test").with(headers: { "User-Agent" => "my-app/1.0" }))
+        .to have_been_made.times(3)
+    end
+
+    it "lets per-request headers override it" do
+      stub = stub_request(:get, "#{base_url}/test")
+             .with(headers: { "User-Agent" => "override" })
+             .to_return(status: 200, body: "{}")
+
+      client.get("/test", {}, { "User-Agent" => "override" })
+
+      expect(stub).to have_been_requested
+    end
+  end
+
   describe "HTTP methods" do
     let(:path) { "/test" }
     let(:response_body) { '{"key": "value"}' }
@@ -34,6 +68,35 @@ RSpec.describe Tastytrade::Client do
 
         client.get(path, params)
       end
+
+      it "sends array parameters as repeated bracketed keys" do
+        stub = stub_request(:get, "#{base_url}#{path}?symbol[]=AAPL&symbol[]=MSFT&include-closed=true")
+               .to_return(status: 200, body: response_body)
+
+        client.get(path, { "symbol" => %w[AAPL MSFT], "include-closed" => true })
+
+        expect(stub).to have_been_requested
+      end
+    end
+
+    describe "#get_items" do
+      let(:items_body) { '{"data":{"items":[{"symbol":"A","legs":[{"q":1}]},{"symbol":"B"}]},"context":"/test"}' }
+
+      it "yields each item of the response" do
+        stub_request(:get, "#{base_url}#{path}").with(query: { "symbol" => "SPY" })
+                                                .to_return(status: 200, body: items_body)
+        items = []
+
+        expect(client.get_items(path, { "symbol" => "SPY" }) { |item| items << item }).to be_nil
+        expect(items).to eq([{ "symbol" => "A", "legs" => [{ "q" => 1 }] }, { "symbol" => "B" }])
+      end
+
+      it "raises API errors with the error body" do
+        stub_request(:get, "#{base_url}#{path}").to_return(status: 404, body: '{"error": "No chain"}')
+
+        expect { client.get_items(path) { |_item| nil } }
+          .to raise_error(Tastytrade::Error, /Resource not found: No chain/)
+      end
     end
 
     describe "#post" do
@@ -62,6 +125,34 @@ RSpec.describe Tastytrade::Client do
       end
     end
 
+    describe "response bodies" do
+      it "returns a data-wrapped body unchanged" do
+        body = { "data" => { "items" => [{ "symbol" => "AAPL" }] }, "context" => "/test" }
+        stub_request(:get, "#{base_url}#{path}").to_return(status: 200, body: body.to_json)
+
+        expect(client.get(path)).to eq(body)
+      end
+
+      it "returns an unwrapped body unchanged" do
+        body = { "symbol" => "AAPL", "data" => nil }
+        stub_request(:get, "#{base_url}#{path}").to_return(status: 200, body: body.to_json)
+
+        expect(client.get(path)).to eq(body)
+      end
+
+      it "parses objects as StrictTimeHash in strict time parsing mode" do
+        strict = described_class.new(base_url: base_url, strict_time_parsing: true)
+        body = { "data" => { "items" => [{ "symbol" => "AAPL" }] } }
+        stub_request(:get, "#{base_url}#{path}").to_return(status: 200, body: body.to_json)
+
+        result = strict.get(path)
+
+        expect(result).to eq(body)
+        expect(result["data"]["items"].first).to be_a(Tastytrade::StrictTimeHash)
+        expect(client.get(path)["data"]).not_to be_a(Tastytrade::StrictTimeHash)
+      end
+    end
+
     describe "#delete" do
       it "makes a DELETE request" do
         stub_request(:delete, "#{base_url}#{path}")
@@ -119,6 +210,20 @@ RSpec.describe Tastytrade::Client do
       expect(result).to be_nil
     end
 
+    it "handles a whitespace-only response body" do
+      stub_request(:get, "#{base_url}#{path}")
+        .to_return(status: 200, body: "\n")
+
+      expect(client.get(path)).to be_nil
+    end
+
+    it "does not parse the body of a 204 response" do
+      stub_request(:delete, "#{base_url}#{path}")
+        .to_return(status: 204, body: "No Content")
+
+      expect(client.delete(path)).to be_nil
+    end
+
     context "with different error message formats" do
       it "handles 'error' field" do
         stub_request(:get, "#{base_url}#{path}")
@@ -143,6 +248,106 @@ RSpec.describe Tastytrade::Client do
     end
   end
 
+  describe "error codes" do
+    let(:path) { "/accounts/5WV12345/orders" }
+
+    def error_for(status, body)
+      stub_request(:post, "#{base_url}#{path}").to_return(status: status, body: JSON.generate(body))
+      client.post(path)
+    rescue Tastytrade::Error => e
+      e
+    end
+
+    it "raises InsufficientFundsError for a preflight buying power failure" do
+      error = error_for(422, "error" => {
+                          "code" => "preflight_check_failure",
+                          "message" => "One or more preflight checks failed",
+                          "errors" => [{ "code" => "margin_check_failed", "message" => "Insufficient buying power" }]
+                        })
+
+      expect(error).to be_a(Tastytrade::InsufficientFundsError)
+      expect(error.code).to eq("preflight_check_failure")
+      expect(error.codes).to eq(%w[preflight_check_failure margin_check_failed])
+      expect(error).to be_insufficient_buying_power
+      expect(error).not_to be_market_closed
+      expect(error.message).to include("One or more preflight checks failed: Insufficient buying power")
+    end
+
+    it "raises MarketClosedError for a market closed code" do
+      error = error_for(400, "code" => "market_closed", "message" => "Market is closed")
+
+      expect(error).to be_a(Tastytrade::MarketClosedError)
+      expect(error).to be_market_closed
+      expect(error.message).to include("Market is closed")
+    end
+
+    it "matches invalid symbol and account restriction codes" do
+      expect(error_for(400, "error" => { "code" => "invalid_symbol", "message" => "Bad symbol" }))
+        .to be_invalid_symbol
+      expect(error_for(422, "error" => { "code" => "account_closing_only", "message" => "Closing only" }))
+        .to be_account_restricted
+    end
+
+    it "keeps the status-based class for authentication failures" do
+      error = error_for(401, "error" => { "code" => "invalid_credentials", "message" => "Bad login" })
+
+      expect(error).to be_a(Tastytrade::InvalidCredentialsError)
+      expect(error.code).to eq("invalid_credentials")
+    end
+
+    it "has no code when the body has none" do
+      error = error_for(400, "error" => "Bad request")
+
+      expect(error.code).to be_nil
+      expect(error.details).to eq([])
+      expect(error).not_to be_insufficient_buying_power
+    end
+  end
+
+  describe "error request context" do
+    let(:path) { "/accounts/5WV12345/orders" }
+
+    it "includes the method, endpoint and status in the error" do
+      stub_request(:get, "#{base_url}#{path}")
+        .to_return(status: 403, body: '{"error": "token expired"}', headers: { "X-Request-Id" => "req-123" })
+
+      expect { client.get(path) }.to raise_error(Tastytrade::SessionExpiredError) { |error|
+        expect(error.message).to eq("Session expired or invalid: token expired " \
+                                    "(GET /accounts/5WV12345/orders returned 403, request ID req-123)")
+        expect(error.http_method).to eq("GET")
+        expect(error.endpoint).to eq(path)
+        expect(error.status).to eq(403)
+        expect(error.request_id).to eq("req-123")
+        expect(error).to be_request_context
+      }
+    end
+
+    it "leaves the request ID nil when the header is absent" do
+      stub_request(:post, "#{base_url}#{path}").to_return(status: 400, body: '{"error": "Bad request"}')
+
+      expect { client.post(path) }.to raise_error(Tastytrade::Error) { |error|
+        expect(error.http_method).to eq("POST")
+        expect(error.request_id).to be_nil
+      }
+    end
+
+    it "adds the request to timeout errors" do
+      stub_request(:delete, "#{base_url}#{path}").to_timeout
+
+      expect { client.delete(path) }.to raise_error(Tastytrade::NetworkTimeoutError) { |error|
+        expect(error.http_method).to eq("DELETE")
+        expect(error.endpoint).to eq(path)
+      }
+    end
+
+    it "keeps errors raised without context backwards compatible" do
+      error = Tastytrade::Error.new("plain")
+
+      expect(error.message).to eq("plain")
+      expect(error).not_to be_request_context
+    end
+  end
+
   describe "retry behavior" do
     let(:path) { "/test" }
 
@@ -163,6 +368,73 @@ RSpec.describe Tastytrade::Client do
 
       expect { client.post(path) }.to raise_error(Tastytrade::Error, /Server error/)
     end
+
+    context "with custom retry settings" do
+      let(:client) { described_class.new(base_url: base_url, max_retries: 3, retry_interval: 0) }
+
+      it "retries rate-limited GET requests until they succeed" do
+        stub_request(:get, "#{base_url}#{path}")
+          .to_return(status: 429, body: "").times(2)
+          .then.to_return(status: 200, body: '{"success": true}')
+
+        expect(client.get(path)).to eq({ "success" => true })
+        expect(a_request(:get, "#{base_url}#{path}")).to have_been_made.times(3)
+      end
+
+      it "honors the Retry-After header" do
+        sleeps = []
+        allow_any_instance_of(Faraday::Retry::Middleware).to receive(:sleep) { |_, amount| sleeps << amount }
+        stub_request(:get, "#{base_url}#{path}")
+          .to_return(status: 429, body: "", headers: { "Retry-After" => "2" })
+          .then.to_return(status: 200, body: '{"success": true}')
+
+        client.get(path)
+
+        expect(sleeps).to eq([2.0])
+      end
+
+      it "retries PUT and DELETE requests" do
+        stub_request(:put, "#{base_url}#{path}")
+          .to_return(status: 503, body: "")
+          .then.to_return(status: 200, body: '{"success": true}')
+        stub_request(:delete, "#{base_url}#{path}")
+          .to_return(status: 503, body: "")
+          .then.to_return(status: 200, body: '{"success": true}')
+
+        expect(client.put(path)).to eq({ "success" => true })
+        expect(client.delete(path)).to eq({ "success" => true })
+        expect(a_request(:put, "#{base_url}#{path}")).to have_been_made.twice
+      end
+    end
+
+    it "retries non-idempotent requests when opted in" do
+      client = described_class.new(base_url: base_url, retry_interval: 0, retry_non_idempotent: true)
+      stub_request(:post, "#{base_url}#{path}")
+        .to_return(status: 503, body: "")
+        .then.to_return(status: 200, body: '{"success": true}')
+
+      expect(client.post(path)).to eq({ "success" => true })
+    end
+
+    it "does not retry when retries are disabled" do
+      client = described_class.new(base_url: base_url, max_retries: 0)
+      stub_request(:get, "#{base_url}#{path}").to_return(status: 429, body: "")
+
+      expect { client.get(path) }.to raise_error(Tastytrade::Error, /Rate limit exceeded/)
+      expect(a_request(:get, "#{base_url}#{path}")).to have_been_made.once
+    end
+
+    it "logs retries" do
+      log = StringIO.new
+      client = described_class.new(base_url: base_url, retry_interval: 0, logger: Logger.new(log))
+      stub_request(:get, "#{base_url}#{path}")
+        .to_return(status: 502, body: "")
+        .then.to_return(status: 200, body: "{}")
+
+      client.get(path)
+
+      expect(log.string).to match(%r{Retrying GET /test \(attempt 1 of 2\)})
+    end
   end
 
   describe "timeout handling" do
@@ -195,6 +467,120 @@ RSpec.describe Tastytrade::Client do
 
       expect { client.delete(path) }.to raise_error(Tastytrade::NetworkTimeoutError, /Request timed out/)
     end
+
+    it "raises NetworkTimeoutError on a read timeout" do
+      stub_request(:post, "#{base_url}#{path}")
+        .to_raise(Faraday::TimeoutError)
+
+      expect { client.post(path) }.to raise_error(Tastytrade::NetworkTimeoutError, /Request timed out/)
+    end
+  end
+
+  describe "metrics" do
+    let(:hook) { instance_double(Tastytrade::Metrics::NullHook, observe_request: nil) }
+    let(:client) { described_class.new(base_url: base_url, metrics: hook, max_retries: 0) }
+
+    it "defaults to a no-op hook" do
+      expect(described_class.new(base_url: base_url).metrics).to be(Tastytrade::Metrics::NULL_HOOK)
+    end
+
+    it "observes each request with a templated endpoint" do
+      stub_request(:get, "#{base_url}/accounts/5WT0001/orders/12345").to_return(status: 200, body: "{}")
+
+      client.get("/accounts/5WT0001/orders/12345")
+
+      expect(hook).to have_received(:observe_request)
+        .with("GET", "/accounts/{id}/orders/{id}", 200, a_kind_of(Float))
+    end
+
+    it "observes error responses" do
+      stub_request(:delete, "#{base_url}/accounts/5WT0001/orders/12345").to_return(status: 404, body: "{}")
+
+      expect { client.delete("/accounts/5WT0001/orders/12345") }.to raise_error(Tastytrade::Error)
+      expect(hook).to have_received(:observe_request).with("DELETE", "/accounts/{id}/orders/{id}", 404, anything)
+    end
+
+    it "observes timeouts without a status" do
+      stub_request(:post, "#{base_url}/accounts/5WT0001/orders/dry-run").to_timeout
+
+      expect { client.post("/accounts/5WT0001/orders/dry-run") }.to raise_error(Tastytrade::NetworkTimeoutError)
+      expect(hook).to have_received(:observe_request).with("POST", "/accounts/{id}/orders/dry-run", nil, anything)
+    end
+  end
+
+  describe "logging" do
+    let(:path) { "/accounts" }
+    let(:log) { StringIO.new }
+    let(:client) { described_class.new(base_url: base_url, logger: Logger.new(log)) }
+    let(:token) { "a1b2c3d4e5f6g7h8i9j0-session-token" }
+
+    it "logs requests and responses at debug level" do
+      stub_request(:get, "#{base_url}#{path}").to_return(status: 200, body: "{}")
+
+      client.get(path, { "symbol" => "AAPL" })
+
+      expect(log.string).to include("DEBUG")
+      expect(log.string).to include("GET /accounts headers=")
+      expect(log.string).to include('"symbol" => "AAPL"').or include('"symbol"=>"AAPL"')
+      expect(log.string).to include("GET /accounts returned 200")
+    end
+
+    it "does not log the session token" do
+      stub_request(:get, "#{base_url}#{path}").to_return(status: 200, body: "{}")
+
+      client.get(path, {}, { "Authorization" => token })
+
+      expect(log.string).not_to include(token)
+      expect(log.string).to include("[REDACTED]")
+    end
+
+    it "does not log passwords or remember tokens" do
+      stub_request(:post, "#{base_url}/sessions").to_return(status: 201, body: "{}")
+
+      client.post("/sessions", { "login" => "user", "password" => "hunter2", "remember-token" => "remember-me" })
+
+      expect(log.string).to include("user")
+      expect(log.string).not_to include("hunter2")
+      expect(log.string).not_to include("remember-me")
+    end
+
+    it "does not log without a logger or debug variables" do
+      allow(ENV).to receive(:[]).and_call_original
+      allow(ENV).to receive(:[]).with("DEBUG_HTTP").and_return(nil)
+      allow(ENV).to receive(:[]).with("DEBUG_SESSION").and_return(nil)
+
+      expect(described_class.new(base_url: base_url).logger).to be_nil
+    end
+
+    it "falls back to a stderr debug logger when DEBUG_HTTP is set" do
+      allow(ENV).to receive(:[]).and_call_original
+      allow(ENV).to receive(:[]).with("DEBUG_HTTP").and_return("1")
+
+      logger = described_class.new(base_url: base_url).logger
+
+      expect(logger).to be_a(Logger)
+      expect(logger.level).to eq(Logger::DEBUG)
+    end
+  end
+
+  describe "base URL with a path prefix" do
+    it "keeps the prefix when joining request paths" do
+      stub = stub_request(:get, "http://localhost:8080/proxy/accounts")
+             .to_return(status: 200, body: "{}")
+
+      described_class.new(base_url: "http://localhost:8080/proxy").get("/accounts")
+
+      expect(stub).to have_been_requested
+    end
+
+    it "does not double the slash for a base URL with a trailing slash" do
+      stub = stub_request(:get, "http://localhost:8080/proxy/accounts")
+             .to_return(status: 200, body: "{}")
+
+      described_class.new(base_url: "http://localhost:8080/proxy/").get("/accounts")
+
+      expect(stub).to have_been_requested
+    end
   end
 
   describe "timeout configuration" do
@@ -206,5 +592,64 @@ RSpec.describe Tastytrade::Client do
     it "uses default timeout when not specified" do
       expect(client.instance_variable_get(:@timeout)).to eq(Tastytrade::Client::DEFAULT_TIMEOUT)
     end
+
+    it "applies the timeout to reads and connections" do
+      options = described_class.new(base_url: base_url, timeout: 5).send(:connection).options
+
+      expect(options.timeout).to eq(5)
+      expect(options.open_timeout).to eq(5)
+    end
+
+    it "accepts a separate connection timeout" do
+      custom_client = described_class.new(base_url: base_url, timeout: 300, open_timeout: 2)
+
+      expect(custom_client.timeout).to eq(300)
+      expect(custom_client.open_timeout).to eq(2)
+      expect(custom_client.send(:connection).options.open_timeout).to eq(2)
+    end
+  end
+
+  describe "connection pooling" do
+    let(:handler) { client.send(:connection).builder.adapter }
+
+    it "uses the default adapter without pooling" do
+      expect(client).not_to be_pooled
+      expect(handler.klass).to eq(Faraday::Adapter.lookup_middleware(Faraday.default_adapter))
+    end
+
+    context "with a pool size and idle timeout" do
+      let(:client) { described_class.new(base_url: base_url, pool_size: 10, idle_timeout: 30) }
+
+      it "keeps connections open with a persistent adapter" do
+        expect(client).to be_pooled
+        expect(handler.klass).to eq(Faraday::Adapter::NetHttpPersistent)
+        expect(handler.instance_variable_get(:@args)).to eq([{ pool_size: 10 }])
+      end
+
+      it "closes connections after the idle timeout" do
+        http = Struct.new(:idle_timeout).new
+
+        handler.instance_variable_get(:@block).call(http)
+
+        expect(http.idle_timeout).to eq(30)
+      end
+    end
+
+    it "pools with the default size when only an idle timeout is given" do
+      expect(described_class.new(base_url: base_url, idle_timeout: 30).pool_size)
+        .to eq(Tastytrade::Client::DEFAULT_POOL_SIZE)
+    end
+
+    it "cannot be combined with a custom adapter" do
+      expect { described_class.new(base_url: base_url, pool_size: 2, adapter: :test) }
+        .to raise_error(ArgumentError, /custom adapter/)
+    end
+
+    it "validates the settings" do
+      expect { described_class.new(base_url: base_url, pool_size: 0) }
+        .to raise_error(ArgumentError, "Pool size must be a positive integer")
+      expect { described_class.new(base_url: base_url, idle_timeout: -1) }
+        .to raise_error(ArgumentError, "Idle timeout must be positive")
+    end
   end
 end
@@ -0,0 +1,138 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::ComplexOrderRequest do
+  def limit_order(action, price)
+    Tastytrade::Order.new(
+      type: Tastytrade::OrderType::LIMIT,
+      time_in_force: Tastytrade::OrderTimeInForce::GTC,
+      legs: Tastytrade::OrderLeg.new(action: action, symbol: "AAPL", quantity: 100),
+      price: price
+    )
+  end
+
+  let(:entry) { limit_order(Tastytrade::OrderAction::BUY_TO_OPEN, 150) }
+  let(:profit_target) { limit_order(Tastytrade::OrderAction::SELL_TO_CLOSE, 160) }
+  let(:stop_loss) { limit_order(Tastytrade::OrderAction::SELL_TO_CLOSE, 145) }
+
+  describe "validation" do
+    it "rejects unknown types" do
+      expect { described_class.new(type: "OTOTO", orders: [profit_target]) }
+        .to raise_error(ArgumentError, /Invalid complex order type: OTOTO/)
+    end
+
+    it "requires a trigger order for OTO" do
+      expect { described_class.new(type: "OTO", orders: [profit_target]) }
+        .to raise_error(ArgumentError, "OTO orders require a trigger order")
+    end
+
+    it "requires exactly one contingent order for OTO" do
+      expect { described_class.new(type: "OTO", trigger_order: entry, orders: [profit_target, stop_loss]) }
+        .to raise_error(ArgumentError, "OTO orders require exactly one contingent order")
+    end
+
+    it "requires a trigger order for OTOCO" do
+      expect { described_class.new(type: "OTOCO", orders: [profit_target, stop_loss]) }
+        .to raise_error(ArgumentError, "OTOCO orders require a trigger order")
+    end
+
+    it "requires two contingent orders for OTOCO" do
+      expect { described_class.new(type: "OTOCO", trigger_order: entry, orders: [profit_target]) }
+        .to raise_error(ArgumentError, "OTOCO orders require exactly two contingent orders")
+    end
+
+    it "rejects a trigger order for OCO" do
+      expect { described_class.new(type: "OCO", trigger_order: entry, orders: [profit_target, stop_loss]) }
+        .to raise_error(ArgumentError, "OCO orders cannot have a trigger order")
+    end
+
+    it "requires at least two orders for OCO" do
+      expect { described_class.new(type: "OCO", orders: [profit_target]) }
+        .to raise_error(ArgumentError, "OCO orders require at least two orders")
+    end
+
+    it "accepts well-formed groups" do
+      expect { described_class.new(type: "OTO", trigger_order: entry, orders: [stop_loss]) }.not_to raise_error
+      expect { described_class.new(type: "OCO", orders: [profit_target, stop_loss]) }.not_to raise_error
+      expect { described_class.new(type: "OTOCO", trigger_order: entry, orders: [profit_target, stop_loss]) }
+        .not_to raise_error
+    end
+  end
+
+  describe "#to_api_params" do
+    it "includes the trigger order and contingent orders" do
+      params = described_class.new(type: "OTOCO", trigger_order: entry, orders: [profit_target, stop_loss])
+                              .to_api_params
+
+      expect(params["type"]).to eq("OTOCO")
+      expect(params["trigger-order"]).to eq(entry.to_api_params)
+      expect(params["orders"]).to eq([profit_target.to_api_params, stop_loss.to_api_params])
+    end
+
+    it "omits the trigger order for OCO" do
+      params = described_class.new(type: "OCO", orders: [profit_target, stop_loss]).to_api_params
+
+      expect(params).not_to have_key("trigger-order")
+    end
+  end
+
+  describe ".bracket" do
+    let(:bracket) { described_class.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 160, stop: 145) }
+
+    it "builds an OTOCO with a limit entry, a limit target and a stop" do
+      expect(bracket.type).to eq("OTOCO")
+      expect(bracket.to_api_params).to eq(
+        "type" => "OTOCO",
+        "trigger-order" => {
+          "order-type" => "Limit", "time-in-force" => "Day", "price" => "150.0", "price-effect" => "Debit",
+          "legs" => [{ "action" => "Buy to Open", "symbol" => "AAPL", "quantity" => 100,
+                       "instrument-type" => "Equity" }]
+        },
+        "orders" => [
+          {
+            "order-type" => "Limit", "time-in-force" => "GTC", "price" => "160.0", "price-effect" => "Credit",
+            "legs" => [{ "action" => "Sell to Close", "symbol" => "AAPL", "quantity" => 100,
+                         "instrument-type" => "Equity" }]
+          },
+          {
+            "order-type" => "Stop", "time-in-force" => "GTC", "stop-trigger" => "145.0",
+            "legs" => [{ "action" => "Sell to Close", "symbol" => "AAPL", "quantity" => 100,
+                         "instrument-type" => "Equity" }]
+          }
+        ]
+      )
+    end
+
+    it "sells to open and buys to close for shorts" do
+      short = described_class.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 140, stop: 155,
+                                      direction: :short)
+
+      expect(short.trigger_order.legs.first.action).to eq("Sell to Open")
+      expect(short.trigger_order).to be_credit
+      target, stop = short.orders
+      expect(target.legs.first.action).to eq("Buy to Close")
+      expect(target).to be_debit
+      expect(target.price).to eq(BigDecimal("140"))
+      expect(stop).to be_stop
+      expect(stop.stop_trigger).to eq(BigDecimal("155"))
+    end
+
+    it "rejects long prices out of order" do
+      expect { described_class.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 145, stop: 140) }
+        .to raise_error(ArgumentError, "Long brackets require target > entry > stop")
+    end
+
+    it "rejects short prices out of order" do
+      expect do
+        described_class.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 160, stop: 145, direction: :short)
+      end.to raise_error(ArgumentError, "Short brackets require target < entry < stop")
+    end
+
+    it "rejects unknown directions" do
+      expect do
+        described_class.bracket(symbol: "AAPL", quantity: 100, entry: 150, target: 160, stop: 145, direction: :up)
+      end.to raise_error(ArgumentError, /Invalid bracket direction: up/)
+    end
+  end
+end
@@ -0,0 +1,26 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::ContractMultiplier do
+  describe ".for" do
+    it "prefers the reported multiplier" do
+      expect(described_class.for("Future", "50.0")).to eq(50)
+      expect(described_class.for("Future", "50.0")).to be_an(Integer)
+      expect(described_class.for("Future Option", "0.1")).to eq(BigDecimal("0.1"))
+      expect(described_class.for("Equity Option", 10)).to eq(10)
+    end
+
+    it "falls back to the standard multiplier" do
+      expect(described_class.for("Equity")).to eq(1)
+      expect(described_class.for("Equity Option")).to eq(100)
+      expect(described_class.for("Option", "")).to eq(100)
+      expect(described_class.for("Cryptocurrency")).to eq(1)
+    end
+
+    it "is unknown for futures without a reported multiplier" do
+      expect(described_class.for("Future")).to be_nil
+      expect(described_class.for("Future Option", nil)).to be_nil
+    end
+  end
+end
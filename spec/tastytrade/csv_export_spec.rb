@@ -0,0 +1,92 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+require "stringio"
+
+RSpec.describe Tastytrade::CSVExport do
+  def golden(name)
+    File.read(File.expand_path("../fixtures/csv_export/#{name}", __dir__))
+  end
+
+  let(:transaction) do
+    Tastytrade::Models::Transaction.new(
+      "id" => 42961,
+      "account-number" => "5WT0001",
+      "executed-at" => "2024-03-15T14:32:05.123-04:00",
+      "transaction-date" => "2024-03-15",
+      "transaction-type" => "Trade",
+      "transaction-sub-type" => "Buy to Open",
+      "action" => "Buy to Open",
+      "symbol" => "AAPL",
+      "underlying-symbol" => "AAPL",
+      "instrument-type" => "Equity",
+      "quantity" => "100.0",
+      "price" => "172.25",
+      "value" => "17225.0",
+      "value-effect" => "Debit",
+      "commission" => "0.0",
+      "clearing-fees" => "0.08",
+      "regulatory-fees" => "0.02",
+      "net-value" => "17225.1",
+      "net-value-effect" => "Debit",
+      "order-id" => 98765,
+      "description" => "Bought 100 AAPL @ 172.25"
+    )
+  end
+  let(:dividend) do
+    Tastytrade::Models::Transaction.new(
+      "id" => 42962,
+      "account-number" => "5WT0001",
+      "transaction-date" => "2024-03-18",
+      "transaction-type" => "Money Movement",
+      "transaction-sub-type" => "Dividend",
+      "symbol" => "AAPL",
+      "value" => "24.0",
+      "value-effect" => "Credit",
+      "net-value" => "24.0",
+      "net-value-effect" => "Credit",
+      "description" => "AAPL dividend, \"qualified\""
+    )
+  end
+  let(:position) do
+    Tastytrade::Models::CurrentPosition.new(
+      "account-number" => "5WT0001",
+      "symbol" => "SPY   240419P00500000",
+      "underlying-symbol" => "SPY",
+      "instrument-type" => "Equity Option",
+      "quantity" => "2",
+      "quantity-direction" => "Short",
+      "average-open-price" => "4.35",
+      "close-price" => "3.9",
+      "multiplier" => 100,
+      "cost-effect" => "Credit",
+      "realized-day-gain" => "0.0",
+      "expires-at" => "2024-04-19T20:15:00.000+00:00",
+      "created-at" => "2024-03-11T15:02:10.000+00:00",
+      "updated-at" => "2024-03-15T20:00:00.000+00:00"
+    )
+  end
+
+  describe ".transactions" do
+    it "writes a header and one row per transaction" do
+      expect(described_class.transactions([transaction, dividend])).to eq(golden("transactions.csv"))
+    end
+
+    it "writes only the header when there are no transactions" do
+      expect(described_class.transactions([]).lines.size).to eq(1)
+    end
+
+    it "writes to a stream" do
+      io = StringIO.new
+
+      expect(described_class.transactions([transaction], io: io)).to be(io)
+      expect(io.string).to eq(described_class.transactions([transaction]))
+    end
+  end
+
+  describe ".positions" do
+    it "writes a header and one row per position" do
+      expect(described_class.positions([position])).to eq(golden("positions.csv"))
+    end
+  end
+end
@@ -0,0 +1,203 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::DXLinkStream do
+  # Stands in for the DXLink server: answers the handshake, records sent
+  # frames and lets tests push feed data or drop the connection
+  let(:fake_connection_class) do
+    Class.new do
+      attr_reader :sent
+
+      def initialize(rejected_tokens)
+        @rejected_tokens = rejected_tokens
+        @sent = []
+        @open = false
+      end
+
+      def connect(on_message:, on_close:)
+        @on_message = on_message
+        @on_close = on_close
+        @open = true
+        self
+      end
+
+      def send_text(text)
+        return false unless @open
+
+        message = JSON.parse(text)
+        @sent << message
+        respond(message)
+        true
+      end
+
+      def open?
+        @open
+      end
+
+      def close
+        @open = false
+      end
+
+      def push(message)
+        @on_message.call(message.to_json)
+      end
+
+      def drop(reason = "1006")
+        @open = false
+        @on_close.call(reason)
+      end
+
+      def subscriptions
+        @sent.select { |message| message["type"] == "FEED_SUBSCRIPTION" }.flat_map { |message| message["add"] }
+      end
+
+      private
+
+      def respond(message)
+        case message["type"]
+        when "AUTH"
+          if @rejected_tokens.include?(message["token"])
+            push("type" => "ERROR", "channel" => 0, "error" => "UNAUTHORIZED", "message" => "token expired")
+          else
+            push("type" => "AUTH_STATE", "channel" => 0, "state" => "AUTHORIZED")
+          end
+        when "CHANNEL_REQUEST"
+          push("type" => "CHANNEL_OPENED", "channel" => message["channel"], "service" => "FEED")
+        end
+      end
+    end
+  end
+
+  let(:rejected_tokens) { [] }
+  let(:connections) { [] }
+  let(:connector) do
+    lambda do |_url|
+      fake_connection_class.new(rejected_tokens).tap { |connection| connections << connection }
+    end
+  end
+  let(:sleeps) { [] }
+  let(:stream_options) { { keepalive_interval: nil, connector: connector, sleeper: ->(s) { sleeps << s } } }
+  let(:stream) { described_class.new("quote-token", "wss://tasty-openapi-ws.dxfeed.com/realtime", **stream_options) }
+  let(:fields) { %w[eventType eventSymbol bidPrice askPrice] }
+
+  describe "#connect" do
+    it "authorizes and opens the feed channel" do
+      stream.connect
+
+      expect(stream).to be_connected
+      expect(connections.first.sent.map { |message| message["type"] }).to eq(%w[SETUP AUTH CHANNEL_REQUEST])
+    end
+
+    it "raises StreamUnauthorizedError when the token is rejected" do
+      rejected_tokens << "quote-token"
+
+      expect { stream.connect }.to raise_error(Tastytrade::StreamUnauthorizedError, /UNAUTHORIZED/)
+    end
+  end
+
+  describe "#subscribe" do
+    it "yields feed data as events" do
+      events = []
+      stream.connect.subscribe("Quote", ["SPY"], fields) { |event| events << event }
+
+      connections.first.push("type" => "FEED_DATA", "channel" => 1,
+                             "data" => ["Quote", ["Quote", "SPY", 505.1, 505.2]])
+
+      expect(events).to eq([{ "eventType" => "Quote", "eventSymbol" => "SPY", "bidPrice" => 505.1,
+                              "askPrice" => 505.2 }])
+    end
+  end
+
+  describe "keepalive" do
+    it "waits between keepalives with the sleeper" do
+      slept = Queue.new
+      wakeups = Queue.new
+      sleeper = lambda do |seconds|
+        slept << seconds
+        wakeups.pop
+      end
+      stream = described_class.new("quote-token", "wss://example.test",
+                                   **stream_options.merge(keepalive_interval: 15, sleeper: sleeper))
+      stream.connect
+
+      expect(slept.pop).to eq(15)
+      wakeups << true
+      expect(slept.pop).to eq(15)
+      expect(connections.first.sent.last).to eq("type" => "KEEPALIVE", "channel" => 0)
+    ensure
+      stream&.close
+    end
+  end
+
+  describe "reconnection" do
+    it "reconnects and replays every subscription when the connection drops" do
+      events = []
+      stream.connect
+      stream.subscribe("Quote", ["SPY"], fields) { |event| events << event }
+      stream.subscribe("Quote", ["QQQ"], fields) { |event| events << event }
+
+      connections.first.drop
+
+      expect(connections.size).to eq(2)
+      expect(connections.last.subscriptions).to eq([{ "type" => "Quote", "symbol" => "SPY" },
+                                                    { "type" => "Quote", "symbol" => "QQQ" }])
+      expect(stream.statuses.pop).to eq(:disconnected)
+      expect(stream.statuses.pop).to eq(:reconnected)
+
+      connections.last.push("type" => "FEED_DATA", "channel" => 1, "data" => ["Quote", ["Quote", "QQQ", 440, 440.1]])
+      expect(events.map { |event| event["eventSymbol"] }).to eq(["QQQ"])
+    end
+
+    it "backs off and gives up after the maximum attempts" do
+      attempts = 0
+      failing = lambda do |url|
+        attempts += 1
+        raise Tastytrade::StreamError, "refused" if attempts > 1
+
+        connector.call(url)
+      end
+      stream = described_class.new("quote-token", "wss://example.test",
+                                   **stream_options.merge(connector: failing, max_reconnect_attempts: 3))
+      stream.connect
+
+      connections.first.drop
+
+      expect(sleeps).to eq([1, 2, 4])
+      expect(stream).to be_closed
+      expect(stream.last_error.message).to eq("refused")
+    end
+
+    it "closes with an unauthorized error when the token has expired" do
+      stream.connect
+      rejected_tokens << "quote-token"
+
+      connections.first.drop
+
+      expect(stream).to be_closed
+      expect(stream.last_error).to be_a(Tastytrade::StreamUnauthorizedError)
+      expect(stream.statuses.pop).to eq(:disconnected)
+      expect(stream.statuses.pop).to be_nil
+    end
+
+    it "reconnects with a new token from the token provider" do
+      stream = described_class.new("quote-token", "wss://example.test", **stream_options,
+                                   token_provider: -> { "new-token" })
+      stream.connect
+      rejected_tokens << "quote-token"
+
+      connections.first.drop
+
+      expect(stream).to be_connected
+      expect(connections.last.sent.find { |message| message["type"] == "AUTH" }["token"]).to eq("new-token")
+    end
+
+    it "does not reconnect after close" do
+      stream.connect
+      stream.close
+      connections.first.drop
+
+      expect(connections.size).to eq(1)
+    end
+  end
+end
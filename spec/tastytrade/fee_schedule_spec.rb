@@ -0,0 +1,99 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::FeeSchedule do
+  def option_leg(action, quantity, symbol: "SPY 240419P00500000")
+    Tastytrade::OrderLeg.new(action: action, symbol: symbol, quantity: quantity, instrument_type: "Option")
+  end
+
+  def equity_leg(action, quantity)
+    Tastytrade::OrderLeg.new(action: action, symbol: "AAPL", quantity: quantity)
+  end
+
+  subject(:schedule) { described_class::DEFAULT }
+
+  describe "#estimate" do
+    # Captured from /accounts/{account}/orders/dry-run for one SPY put sold to open
+    let(:dry_run_fees) do
+      Tastytrade::Models::FeeCalculation.new(
+        "regulatory-fees" => "0.04", "regulatory-fees-effect" => "Debit",
+        "clearing-fees" => "0.1", "clearing-fees-effect" => "Debit",
+        "commission" => "1.0", "commission-effect" => "Debit",
+        "proprietary-index-option-fees" => "0.0", "proprietary-index-option-fees-effect" => "None",
+        "total-fees" => "1.14", "total-fees-effect" => "Debit"
+      )
+    end
+
+    it "matches a dry run for an opening option order" do
+      order = Tastytrade::Order.new(type: Tastytrade::OrderType::LIMIT, price: "4.35",
+                                    legs: option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, 1))
+
+      expect(schedule.estimate(order).to_h).to eq(dry_run_fees.to_h)
+    end
+
+    it "caps the opening commission per leg" do
+      legs = [option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, 15),
+              option_leg(Tastytrade::OrderAction::BUY_TO_OPEN, 15, symbol: "SPY 240419P00495000")]
+
+      fees = schedule.estimate(legs)
+
+      expect(fees.commission).to eq(BigDecimal("20"))
+      expect(fees.clearing_fees).to eq(BigDecimal("3"))
+      expect(fees.total).to eq(BigDecimal("24.2"))
+    end
+
+    it "charges no commission to close options" do
+      fees = schedule.estimate([option_leg(Tastytrade::OrderAction::BUY_TO_CLOSE, 2)])
+
+      expect(fees.commission).to be_zero
+      expect(fees.commission_effect).to eq("None")
+      expect(fees.total).to eq(BigDecimal("0.28"))
+    end
+
+    it "charges regulatory fees only on shares sold" do
+      bought = schedule.estimate([equity_leg(Tastytrade::OrderAction::BUY_TO_OPEN, 100)])
+      sold = schedule.estimate([equity_leg(Tastytrade::OrderAction::SELL_TO_CLOSE, 1000)])
+
+      expect(bought.to_h).to include(commission: "0.0", clearing_fees: "0.08", regulatory_fees: "0.0")
+      expect(sold.to_h).to include(clearing_fees: "0.8", regulatory_fees: "0.17")
+    end
+
+    it "applies the index option fee to cash-settled roots" do
+      custom = described_class.new(index_option_fee: "0.6")
+      spx = option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, 2, symbol: "SPXW 240419P05000000")
+
+      expect(custom.estimate([spx]).proprietary_index_option_fees).to eq(BigDecimal("1.2"))
+      expect(custom.estimate([option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, 2)]).proprietary_index_option_fees)
+        .to be_zero
+    end
+
+    it "rejects legs it has no rates for" do
+      leg = Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "/ESM4", quantity: 1,
+                                     instrument_type: "Future")
+
+      expect { schedule.estimate([leg]) }.to raise_error(ArgumentError, /Future legs/)
+    end
+
+    it "rejects notional legs, which have no share quantity" do
+      leg = equity_leg(Tastytrade::OrderAction::BUY_TO_OPEN, nil)
+      order = Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: leg, value: 100)
+
+      expect { schedule.estimate(order) }.to raise_error(ArgumentError, /notional leg AAPL without a quantity/)
+    end
+  end
+
+  describe "#initialize" do
+    it "overrides rates and removes the cap" do
+      custom = described_class.new(option_commission: "0.65", option_commission_cap: nil)
+
+      expect(custom.estimate([option_leg(Tastytrade::OrderAction::BUY_TO_OPEN, 20)]).commission)
+        .to eq(BigDecimal("13"))
+    end
+
+    it "rejects unknown and negative rates" do
+      expect { described_class.new(stock_commission: 1) }.to raise_error(ArgumentError, /Unknown fee rate/)
+      expect { described_class.new(option_commission: -1) }.to raise_error(ArgumentError, /cannot be negative/)
+    end
+  end
+end
@@ -0,0 +1,87 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Instruments::Cryptocurrency do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  let(:btc_data) do
+    {
+      "id" => 1,
+      "symbol" => "BTC/USD",
+      "instrument-type" => "Cryptocurrency",
+      "short-description" => "Bitcoin",
+      "description" => "Bitcoin to USD",
+      "is-closing-only" => false,
+      "active" => true,
+      "tick-size" => "0.01",
+      "streamer-symbol" => "BTC/USD:CXTALP",
+      "destination-venue-symbols" => [
+        {
+          "id" => 1,
+          "symbol" => "BTC/USD",
+          "destination-venue" => "CBOE_DIGITAL",
+          "max-quantity-precision" => 8,
+          "max-price-precision" => 2,
+          "routable" => true
+        }
+      ]
+    }
+  end
+
+  describe "#initialize" do
+    subject(:crypto) { described_class.new(btc_data) }
+
+    it "parses the instrument" do
+      expect(crypto.symbol).to eq("BTC/USD")
+      expect(crypto.instrument_type).to eq("Cryptocurrency")
+      expect(crypto.short_description).to eq("Bitcoin")
+      expect(crypto.tick_size).to eq(BigDecimal("0.01"))
+      expect(crypto.streamer_symbol).to eq("BTC/USD:CXTALP")
+      expect(crypto).to be_active
+      expect(crypto).not_to be_closing_only
+    end
+
+    it "parses destination venue symbols" do
+      venue = crypto.destination_venue_symbols.first
+
+      expect(venue.destination_venue).to eq("CBOE_DIGITAL")
+      expect(venue.max_quantity_precision).to eq(8)
+      expect(venue.routable).to be true
+    end
+  end
+
+  describe ".get" do
+    it "fetches a cryptocurrency with the symbol escaped" do
+      allow(session).to receive(:get).with("/instruments/cryptocurrencies/BTC%2FUSD").and_return("data" => btc_data)
+
+      expect(described_class.get(session, "BTC/USD").symbol).to eq("BTC/USD")
+    end
+  end
+
+  describe ".get_all" do
+    it "fetches the given symbols" do
+      allow(session).to receive(:get)
+        .with("/instruments/cryptocurrencies", { "symbol[]" => ["BTC/USD", "ETH/USD"] })
+        .and_return("data" => { "items" => [btc_data, btc_data.merge("symbol" => "ETH/USD")] })
+
+      expect(described_class.get_all(session, ["BTC/USD", "ETH/USD"]).map(&:symbol)).to eq(["BTC/USD", "ETH/USD"])
+    end
+
+    it "fetches every cryptocurrency without symbols" do
+      allow(session).to receive(:get).with("/instruments/cryptocurrencies", {}).and_return("data" => { "items" => [] })
+
+      expect(described_class.get_all(session)).to eq([])
+    end
+  end
+
+  describe "#build_leg" do
+    it "keeps a fractional quantity" do
+      leg = described_class.new(btc_data).build_leg(action: Tastytrade::OrderAction::BUY_TO_OPEN, quantity: "0.0125")
+
+      expect(leg.quantity).to eq(BigDecimal("0.0125"))
+      expect(leg.to_api_params).to include("symbol" => "BTC/USD", "quantity" => "0.0125",
+                                           "instrument-type" => "Cryptocurrency")
+    end
+  end
+end
@@ -0,0 +1,55 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Instruments::Equity do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  describe ".get" do
+    it "normalizes a mixed-case symbol" do
+      allow(session).to receive(:get).with("/instruments/equities/AAPL").and_return("data" => { "symbol" => "AAPL" })
+
+      expect(described_class.get(session, " aApl").symbol).to eq("AAPL")
+    end
+  end
+
+  describe ".get_all" do
+    let(:symbols) { Array.new(250) { |i| "SYM#{i}" } }
+
+    before do
+      allow(session).to receive(:get) do |_path, params|
+        { "data" => { "items" => params["symbol[]"].map { |symbol| { "symbol" => symbol, "active" => true } } } }
+      end
+    end
+
+    it "splits the symbols into batches and merges the results" do
+      equities = described_class.get_all(session, symbols)
+
+      expect(session).to have_received(:get).with("/instruments/equities", anything).exactly(3).times
+      expect(session).to have_received(:get).with("/instruments/equities", { "symbol[]" => symbols.last(50) })
+      expect(equities.map(&:symbol)).to eq(symbols)
+    end
+
+    it "uses the given batch size" do
+      described_class.get_all(session, symbols, batch_size: 50)
+
+      expect(session).to have_received(:get).exactly(5).times
+    end
+
+    it "requests each symbol once, whatever its case" do
+      described_class.get_all(session, %w[AAPL msft aapl])
+
+      expect(session).to have_received(:get).with("/instruments/equities", { "symbol[]" => %w[AAPL MSFT] })
+    end
+
+    it "makes no request without symbols" do
+      expect(described_class.get_all(session, [])).to eq([])
+      expect(session).not_to have_received(:get)
+    end
+
+    it "rejects a non-positive batch size" do
+      expect { described_class.get_all(session, symbols, batch_size: 0) }
+        .to raise_error(ArgumentError, /positive integer/)
+    end
+  end
+end
@@ -0,0 +1,96 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Instruments::FutureOptionChain do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  # Trimmed from GET /futures-option-chains/ES/nested
+  let(:response) do
+    {
+      "data" => {
+        "futures" => [
+          { "symbol" => "/ESZ4", "root-symbol" => "/ES", "expiration-date" => "2024-12-20", "days-to-expiration" => 66 }
+        ],
+        "option-chains" => [
+          {
+            "underlying-symbol" => "/ES",
+            "root-symbol" => "/ES",
+            "exercise-style" => "American",
+            "expirations" => [
+              {
+                "underlying-symbol" => "/ESZ4",
+                "root-symbol" => "/ES",
+                "option-root-symbol" => "EW4",
+                "option-contract-symbol" => "EW4X4",
+                "asset" => "EW4",
+                "expiration-date" => "2024-11-29",
+                "days-to-expiration" => 45,
+                "expiration-type" => "Weekly",
+                "settlement-type" => "PM",
+                "notional-value" => "0.5",
+                "display-factor" => "0.01",
+                "strike-factor" => "1.0",
+                "strikes" => [
+                  {
+                    "strike-price" => "5800.0",
+                    "call" => "./ESZ4 EW4X4 241129C5800",
+                    "call-streamer-symbol" => "./EW4X24C5800:XCME",
+                    "put" => "./ESZ4 EW4X4 241129P5800",
+                    "put-streamer-symbol" => "./EW4X24P5800:XCME"
+                  }
+                ]
+              },
+              {
+                "underlying-symbol" => "/ESZ4",
+                "root-symbol" => "/ES",
+                "option-root-symbol" => "ES",
+                "option-contract-symbol" => "ESZ4",
+                "expiration-date" => "2024-12-20",
+                "expiration-type" => "Regular",
+                "strikes" => []
+              }
+            ]
+          }
+        ]
+      }
+    }
+  end
+
+  describe ".get" do
+    before do
+      allow(session).to receive(:get).with("/futures-option-chains/ES/nested").and_return(response)
+    end
+
+    it "parses one chain per option root" do
+      chains = described_class.get(session, "ES")
+
+      expect(chains.size).to eq(1)
+      expect(chains.first.root_symbol).to eq("/ES")
+      expect(chains.first.exercise_style).to eq("American")
+    end
+
+    it "parses expirations and strikes" do
+      expiration = described_class.get(session, "ES").first.expirations.first
+
+      expect(expiration.underlying_symbol).to eq("/ESZ4")
+      expect(expiration.option_contract_symbol).to eq("EW4X4")
+      expect(expiration.expiration_date).to eq(Date.new(2024, 11, 29))
+      expect(expiration.notional_value).to eq(BigDecimal("0.5"))
+      expect(expiration.strikes.first.strike_price).to eq(BigDecimal("5800"))
+      expect(expiration.strikes.first.call).to eq("./ESZ4 EW4X4 241129C5800")
+    end
+
+    it "accepts a product code with a leading slash" do
+      expect(described_class.get(session, "/ES").size).to eq(1)
+    end
+  end
+
+  describe "#expiration_dates" do
+    it "returns sorted unique dates" do
+      chain = described_class.new(response["data"]["option-chains"].first)
+
+      expect(chain.expiration_dates).to eq([Date.new(2024, 11, 29), Date.new(2024, 12, 20)])
+    end
+  end
+end
@@ -0,0 +1,112 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Instruments::FutureOption do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:symbol) { "./ESZ4 EW4X4 241129C5800" }
+
+  # Captured from GET /instruments/future-options/{symbol}
+  let(:es_option_data) do
+    {
+      "symbol" => "./ESZ4 EW4X4 241129C5800",
+      "underlying-symbol" => "/ESZ4",
+      "product-code" => "ES",
+      "expiration-date" => "2024-11-29",
+      "root-symbol" => "/ES",
+      "option-root-symbol" => "EW4",
+      "strike-price" => "5800.0",
+      "exchange" => "CME",
+      "exchange-symbol" => "EW4X4 C5800",
+      "streamer-symbol" => "./EW4X24C5800:XCME",
+      "option-type" => "C",
+      "exercise-style" => "American",
+      "is-vanilla" => true,
+      "is-primary-deliverable" => true,
+      "future-price-ratio" => "1.0",
+      "multiplier" => "1.0",
+      "underlying-count" => "1.0",
+      "is-confirmed" => true,
+      "notional-value" => "0.5",
+      "display-factor" => "0.01",
+      "security-exchange" => "2",
+      "sx-id" => "0",
+      "settlement-type" => "Future",
+      "strike-factor" => "1.0",
+      "maturity-date" => "2024-11-29",
+      "is-exercisable-weekly" => true,
+      "last-trade-time" => "0",
+      "days-to-expiration" => 45,
+      "is-closing-only" => false,
+      "active" => true,
+      "stops-trading-at" => "2024-11-29T21:00:00.000+00:00",
+      "expires-at" => "2024-11-29T21:00:00.000+00:00"
+    }
+  end
+
+  describe "#initialize" do
+    subject(:option) { described_class.new(es_option_data) }
+
+    it "parses the instrument" do
+      expect(option.symbol).to eq(symbol)
+      expect(option.underlying_symbol).to eq("/ESZ4")
+      expect(option.product_code).to eq("ES")
+      expect(option.root_symbol).to eq("/ES")
+      expect(option.option_root_symbol).to eq("EW4")
+      expect(option.expiration_date).to eq(Date.new(2024, 11, 29))
+      expect(option.exercise_style).to eq("American")
+      expect(option.streamer_symbol).to eq("./EW4X24C5800:XCME")
+      expect(option.days_to_expiration).to eq(45)
+    end
+
+    it "parses numeric fields as BigDecimal" do
+      expect(option.strike_price).to eq(BigDecimal("5800"))
+      expect(option.future_price_ratio).to eq(BigDecimal("1"))
+      expect(option.multiplier).to eq(BigDecimal("1"))
+    end
+
+    it "accepts numbers as well as strings" do
+      option = described_class.new(es_option_data.merge("strike-price" => 5812.5, "multiplier" => 50))
+
+      expect(option.strike_price).to eq(BigDecimal("5812.5"))
+      expect(option.multiplier).to eq(BigDecimal("50"))
+    end
+
+    it "exposes predicates" do
+      expect(option).to be_call
+      expect(option).not_to be_put
+      expect(option).to be_vanilla
+      expect(option).not_to be_closing_only
+    end
+  end
+
+  describe ".get" do
+    it "fetches a future option by percent-encoded symbol" do
+      allow(session).to receive(:get)
+        .with("/instruments/future-options/.%2FESZ4%20EW4X4%20241129C5800")
+        .and_return("data" => es_option_data)
+
+      expect(described_class.get(session, symbol).strike_price).to eq(BigDecimal("5800"))
+    end
+  end
+
+  describe ".get_all" do
+    it "fetches several future options" do
+      allow(session).to receive(:get)
+        .with("/instruments/future-options", { "symbol[]" => [symbol] })
+        .and_return("data" => { "items" => [es_option_data] })
+
+      expect(described_class.get_all(session, [symbol]).map(&:symbol)).to eq([symbol])
+    end
+  end
+
+  describe "#build_leg" do
+    it "creates a future option order leg" do
+      leg = described_class.new(es_option_data).build_leg(action: Tastytrade::OrderAction::BUY_TO_OPEN, quantity: 2)
+
+      expect(leg.symbol).to eq(symbol)
+      expect(leg.quantity).to eq(2)
+      expect(leg.instrument_type).to eq("Future Option")
+    end
+  end
+end
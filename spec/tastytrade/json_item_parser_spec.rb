@@ -0,0 +1,57 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::JSONItemParser do
+  let(:items) { [] }
+  let(:parser) { described_class.new { |item| items << item } }
+
+  let(:body) do
+    {
+      "data" => {
+        "underlying" => { "symbol" => "SPY", "items" => [{ "ignored" => true }] },
+        "items" => [
+          { "symbol" => "SPY   240315C00450000", "strike-price" => "450.0", "tags" => ["a]", "{b"] },
+          { "symbol" => "SPY   240315P00450000", "description" => 'Say "hi" \\ }' }
+        ]
+      },
+      "context" => "/option-chains/SPY"
+    }.to_json
+  end
+
+  it "yields each item of data.items" do
+    parser << body
+
+    expect(items.map { |item| item["symbol"] }).to eq(["SPY   240315C00450000", "SPY   240315P00450000"])
+    expect(items.first["tags"]).to eq(["a]", "{b"])
+    expect(items.last["description"]).to eq('Say "hi" \\ }')
+  end
+
+  it "parses items split across chunks" do
+    body.chars.each_slice(7) { |chunk| parser << chunk.join }
+
+    expect(items.size).to eq(2)
+    expect(items.first["strike-price"]).to eq("450.0")
+  end
+
+  it "yields each item before the rest of the body is read" do
+    first, rest = body.split("},{", 2)
+    parser << first << "},"
+
+    expect(items.size).to eq(1)
+
+    parser << "{#{rest}"
+    expect(items.size).to eq(2)
+  end
+
+  it "yields nothing for a response without items" do
+    parser << { "data" => { "symbol" => "SPY" } }.to_json
+
+    expect(items).to be_empty
+  end
+
+  it "raises on a malformed item" do
+    expect { parser << '{"data":{"items":[{"symbol":}]}}' }
+      .to raise_error(Tastytrade::Error, /Invalid JSON response/)
+  end
+end
@@ -0,0 +1,142 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::MarketHours do
+  describe ".eastern_time" do
+    it "uses EST in winter" do
+      et = described_class.eastern_time(Time.utc(2024, 1, 10, 15, 0))
+
+      expect(et.hour).to eq(10)
+      expect(et.utc_offset).to eq(-5 * 3600)
+    end
+
+    it "uses EDT in summer" do
+      et = described_class.eastern_time(Time.utc(2024, 7, 10, 14, 0))
+
+      expect(et.hour).to eq(10)
+      expect(et.utc_offset).to eq(-4 * 3600)
+    end
+
+    it "switches to daylight time on the second Sunday in March" do
+      expect(described_class.dst?(Time.utc(2024, 3, 10, 6, 59))).to be false
+      expect(described_class.dst?(Time.utc(2024, 3, 10, 7, 0))).to be true
+    end
+
+    it "switches back on the first Sunday in November" do
+      expect(described_class.dst?(Time.utc(2024, 11, 3, 5, 59))).to be true
+      expect(described_class.dst?(Time.utc(2024, 11, 3, 6, 0))).to be false
+    end
+  end
+
+  describe ".holidays" do
+    it "includes the NYSE holidays for 2024" do
+      holidays = described_class.holidays(2024)
+
+      expect(holidays).to include(
+        Date.new(2024, 1, 1),
+        Date.new(2024, 1, 15),
+        Date.new(2024, 2, 19),
+        Date.new(2024, 3, 29),
+        Date.new(2024, 5, 27),
+        Date.new(2024, 6, 19),
+        Date.new(2024, 7, 4),
+        Date.new(2024, 9, 2),
+        Date.new(2024, 11, 28),
+        Date.new(2024, 12, 25)
+      )
+      expect(holidays.size).to eq(10)
+    end
+
+    it "observes Saturday holidays on the preceding Friday" do
+      expect(described_class.holiday?(Date.new(2026, 7, 3))).to be true
+    end
+
+    it "observes Sunday holidays on the following Monday" do
+      expect(described_class.holiday?(Date.new(2022, 12, 26))).to be true
+    end
+
+    it "does not observe a Saturday New Year's Day" do
+      expect(described_class.holidays(2022)).not_to include(Date.new(2021, 12, 31))
+      expect(described_class.trading_day?(Date.new(2021, 12, 31))).to be true
+    end
+  end
+
+  describe ".session_for" do
+    it "identifies each session on a regular trading day" do
+      expect(described_class.session_for(Time.utc(2024, 1, 10, 8, 0))).to eq(:closed)
+      expect(described_class.session_for(Time.utc(2024, 1, 10, 12, 0))).to eq(:pre_market)
+      expect(described_class.session_for(Time.utc(2024, 1, 10, 14, 30))).to eq(:regular)
+      expect(described_class.session_for(Time.utc(2024, 1, 10, 21, 0))).to eq(:after_hours)
+      expect(described_class.session_for(Time.utc(2024, 1, 11, 1, 0))).to eq(:closed)
+    end
+
+    it "is closed on weekends and holidays" do
+      expect(described_class.session_for(Time.utc(2024, 1, 13, 15, 0))).to eq(:closed)
+      expect(described_class.session_for(Time.utc(2024, 12, 25, 15, 0))).to eq(:closed)
+    end
+
+    it "ends the regular session at 1 PM on early-close days" do
+      expect(described_class.early_close?(Date.new(2024, 11, 29))).to be true
+      expect(described_class.regular_hours?(Time.utc(2024, 11, 29, 17, 59))).to be true
+      expect(described_class.regular_hours?(Time.utc(2024, 11, 29, 18, 30))).to be false
+    end
+  end
+
+  describe ".next_open" do
+    it "skips weekends and holidays" do
+      # Friday after the close, followed by MLK day
+      next_open = described_class.next_open(Time.utc(2024, 1, 12, 22, 0))
+
+      expect(next_open).to eq(Time.utc(2024, 1, 16, 14, 30))
+    end
+
+    it "returns the same day's open before the bell" do
+      next_open = described_class.next_open(Time.utc(2024, 7, 10, 12, 0))
+
+      expect(next_open).to eq(Time.utc(2024, 7, 10, 13, 30))
+    end
+  end
+
+  describe ".suggest_time_in_force" do
+    let(:regular) { Time.utc(2024, 1, 10, 15, 0) }
+    let(:after_hours) { Time.utc(2024, 1, 10, 22, 0) }
+    let(:weekend) { Time.utc(2024, 1, 13, 15, 0) }
+
+    it "keeps Day without a warning during regular hours" do
+      suggestion = described_class.suggest_time_in_force(Tastytrade::OrderType::LIMIT, now: regular)
+
+      expect(suggestion.time_in_force).to eq("Day")
+      expect(suggestion.warning?).to be false
+    end
+
+    it "suggests Ext for a Day limit order in the extended session" do
+      suggestion = described_class.suggest_time_in_force(Tastytrade::OrderType::LIMIT, now: after_hours)
+
+      expect(suggestion.time_in_force).to eq(Tastytrade::OrderTimeInForce::EXT)
+      expect(suggestion.warning).to include("will not execute until the market opens at 2024-01-11 09:30 ET")
+    end
+
+    it "suggests GTC for a Day limit order while the market is closed" do
+      suggestion = described_class.suggest_time_in_force(Tastytrade::OrderType::LIMIT, now: weekend)
+
+      expect(suggestion.time_in_force).to eq(Tastytrade::OrderTimeInForce::GTC)
+      expect(suggestion.warning).to include("2024-01-16 09:30 ET")
+    end
+
+    it "warns but keeps Day for market orders" do
+      suggestion = described_class.suggest_time_in_force(Tastytrade::OrderType::MARKET, now: after_hours)
+
+      expect(suggestion.time_in_force).to eq("Day")
+      expect(suggestion.warning?).to be true
+    end
+
+    it "does not second-guess non-Day orders" do
+      suggestion = described_class.suggest_time_in_force(Tastytrade::OrderType::LIMIT,
+                                                         time_in_force: "GTC", now: weekend)
+
+      expect(suggestion.time_in_force).to eq("GTC")
+      expect(suggestion.warning?).to be false
+    end
+  end
+end
@@ -0,0 +1,27 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Metrics do
+  describe ".endpoint_template" do
+    it "replaces account numbers and IDs" do
+      expect(described_class.endpoint_template("/accounts/5WT0001/orders/12345")).to eq("/accounts/{id}/orders/{id}")
+    end
+
+    it "replaces symbols" do
+      expect(described_class.endpoint_template("/option-chains/SPY/nested")).to eq("/option-chains/{id}/nested")
+      expect(described_class.endpoint_template("/instruments/equities/BRK%2FB")).to eq("/instruments/equities/{id}")
+    end
+
+    it "keeps static paths and drops the query string" do
+      expect(described_class.endpoint_template("/customers/me/accounts")).to eq("/customers/me/accounts")
+      expect(described_class.endpoint_template("/market-metrics?symbols=SPY")).to eq("/market-metrics")
+    end
+  end
+
+  describe Tastytrade::Metrics::NullHook do
+    it "accepts observations" do
+      expect(described_class.new.observe_request("GET", "/accounts", 200, 0.1)).to be_nil
+    end
+  end
+end
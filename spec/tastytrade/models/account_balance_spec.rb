@@ -94,6 +94,73 @@ RSpec.describe Tastytrade::Models::AccountBalance do
     end
   end
 
+  describe "captured API payload" do
+    let(:balance_data) do
+      {
+        "account-number" => "5WX12345",
+        "cash-balance" => "8103.92",
+        "long-equity-value" => "12450.0",
+        "short-equity-value" => "0.0",
+        "long-derivative-value" => "1320.0",
+        "short-derivative-value" => "845.0",
+        "long-margineable-value" => "12450.0",
+        "short-margineable-value" => "0.0",
+        "margin-equity" => "21028.92",
+        "equity-buying-power" => "15604.4",
+        "derivative-buying-power" => "7802.2",
+        "day-trading-buying-power" => "0.0",
+        "net-liquidating-value" => "21028.92",
+        "cash-available-to-withdraw" => "7802.2",
+        "maintenance-requirement" => "13226.72",
+        "maintenance-excess" => "7802.2",
+        "maintenance-call-value" => "0.0",
+        "reg-t-call-value" => "0.0",
+        "day-trading-call-value" => "0.0",
+        "day-equity-call-value" => "0.0",
+        "pending-cash" => "120.5",
+        "pending-cash-effect" => "Debit",
+        "buying-power-adjustment" => "0.0",
+        "buying-power-adjustment-effect" => "None",
+        "available-trading-funds" => "0.0",
+        "updated-at" => "2024-03-01T15:42:10.781+00:00"
+      }
+    end
+
+    it "parses margin requirement fields" do
+      expect(subject.maintenance_requirement).to eq(BigDecimal("13226.72"))
+      expect(subject.maintenance_excess).to eq(BigDecimal("7802.2"))
+      expect(subject.cash_available_to_withdraw).to eq(BigDecimal("7802.2"))
+      expect(subject.long_margineable_value).to eq(BigDecimal("12450.0"))
+    end
+
+    it "keeps effect fields as strings" do
+      expect(subject.pending_cash_effect).to eq("Debit")
+      expect(subject.buying_power_adjustment_effect).to eq("None")
+    end
+
+    it "signs pending cash by its effect" do
+      expect(subject.signed_pending_cash).to eq(BigDecimal("-120.5"))
+    end
+
+    it "reports no margin call when all call values are zero" do
+      expect(subject.margin_call?).to be false
+    end
+
+    it "reports a margin call when any call value is positive" do
+      balance = described_class.new(balance_data.merge("reg-t-call-value" => "250.0"))
+
+      expect(balance.margin_call?).to be true
+    end
+
+    it "defaults missing fields to zero" do
+      balance = described_class.new("account-number" => "5WX12345")
+
+      expect(balance.maintenance_requirement).to eq(BigDecimal("0"))
+      expect(balance.pending_cash_effect).to be_nil
+      expect(balance.signed_pending_cash).to eq(BigDecimal("0"))
+    end
+  end
+
   describe "#buying_power_usage_percentage" do
     context "with normal usage" do
       it "calculates the percentage correctly" do
@@ -93,6 +93,38 @@ RSpec.describe "Tastytrade::Models::Account#get_order_history" do
 
       account.get_order_history(session, status: "InvalidStatus")
     end
+
+    it "sends several statuses as an array" do
+      expect(session).to receive(:get)
+        .with("/accounts/#{account_number}/orders/", { "status" => %w[Filled Cancelled] })
+        .and_return(order_history_response)
+
+      account.get_order_history(session, status: %w[Filled InvalidStatus Cancelled])
+    end
+  end
+
+  context "with date filters" do
+    it "sends the start and end dates" do
+      expect(session).to receive(:get)
+        .with("/accounts/#{account_number}/orders/", { "start-date" => "2024-01-02", "end-date" => "2024-01-31" })
+        .and_return(order_history_response)
+
+      account.get_order_history(session, start_date: Date.new(2024, 1, 2), end_date: "2024-01-31")
+    end
+  end
+
+  context "with underlying instrument type filter" do
+    it "includes the instrument type in request params" do
+      expect(session).to receive(:get)
+        .with("/accounts/#{account_number}/orders/", { "underlying-instrument-type" => "Equity" })
+        .and_return(order_history_response)
+
+      account.get_order_history(session, underlying_instrument_type: "Equity")
+    end
+  end
+
+  it "rejects unknown filters" do
+    expect { account.get_order_history(session, order_type: "Limit") }.to raise_error(ArgumentError, /order_type/)
   end
 
   context "with underlying symbol filter" do
@@ -134,6 +166,50 @@ RSpec.describe "Tastytrade::Models::Account#get_order_history" do
     end
   end
 
+  context "across pages" do
+    def page(ids, offset:, total_pages: 2)
+      {
+        "data" => { "items" => ids.map { |id| { "id" => id, "status" => "Filled", "legs" => [] } } },
+        "pagination" => { "per-page" => 2, "page-offset" => offset, "total-pages" => total_pages }
+      }
+    end
+
+    it "returns pagination metadata with a page" do
+      allow(session).to receive(:get).and_return(page(%w[1 2], offset: 0))
+
+      orders, pagination = account.get_order_history_page(session, status: "Filled")
+
+      expect(orders.map(&:id)).to eq(%w[1 2])
+      expect(pagination.total_pages).to eq(2)
+      expect(pagination).to be_next_page
+    end
+
+    it "iterates over every page" do
+      expect(session).to receive(:get)
+        .with("/accounts/#{account_number}/orders/", { "page-offset" => 0, "page-limit" => 2 })
+        .and_return(page(%w[1 2], offset: 0))
+      expect(session).to receive(:get)
+        .with("/accounts/#{account_number}/orders/", { "page-offset" => 1, "page-limit" => 2 })
+        .and_return(page(%w[3], offset: 1))
+
+      ids = account.each_order_history(session, page_limit: 2).map(&:id)
+
+      expect(ids).to eq(%w[1 2 3])
+    end
+
+    it "fetches pages lazily" do
+      expect(session).to receive(:get).once.and_return(page(%w[1 2], offset: 0))
+
+      expect(account.each_order_history(session).first(2).map(&:id)).to eq(%w[1 2])
+    end
+
+    it "stops when the response has no pagination" do
+      expect(session).to receive(:get).once.and_return(order_history_response)
+
+      expect(account.each_order_history(session).count).to eq(2)
+    end
+  end
+
   context "with multiple filters" do
     it "combines all filters in request" do
       from_time = Time.parse("2024-01-01T00:00:00Z")
@@ -227,3 +303,105 @@ RSpec.describe "Tastytrade::Models::Account#get_order" do
     end
   end
 end
+
+RSpec.describe "Tastytrade::Models::Account#get_order_detail" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { Tastytrade::Models::Account.new("account-number" => "5WZ38925") }
+  let(:order_response) do
+    {
+      "data" => {
+        "id" => 12345,
+        "status" => "Filled",
+        "underlying-symbol" => "AAPL",
+        "created-at" => "2024-01-02T15:00:00Z",
+        "legs" => [
+          {
+            "symbol" => "AAPL", "instrument-type" => "Equity", "action" => "Buy to Open", "quantity" => 100,
+            "remaining-quantity" => 0,
+            "fills" => [{ "quantity" => 40, "fill-price" => "150.00" }, { "quantity" => 60, "fill-price" => "150.10" }]
+          }
+        ]
+      }
+    }
+  end
+
+  before do
+    allow(session).to receive(:get).with("/accounts/5WZ38925/orders/12345/").and_return(order_response)
+  end
+
+  it "derives the net execution price without fetching fees by default" do
+    detail = account.get_order_detail(session, 12345)
+
+    expect(detail.order.id).to eq(12345)
+    expect(detail.net_execution_price).to eq(BigDecimal("-150.06"))
+    expect(detail.fee_calculation).to be_nil
+  end
+
+  it "sums fees from the order's transactions" do
+    allow(session).to receive(:get)
+      .with("/accounts/5WZ38925/transactions", { "start-date" => "2024-01-02", "underlying-symbol" => "AAPL" })
+      .and_return(
+        "data" => {
+          "items" => [
+            { "id" => 1, "order-id" => 12345, "commission" => "0.0", "clearing-fees" => "-0.04",
+              "regulatory-fees" => "-0.02" },
+            { "id" => 2, "order-id" => 12345, "commission" => "0.0", "clearing-fees" => "-0.04",
+              "regulatory-fees" => "-0.03" },
+            { "id" => 3, "order-id" => 99999, "commission" => "-1.0", "clearing-fees" => "-0.1" }
+          ]
+        },
+        "pagination" => { "page-offset" => 0, "total-pages" => 1 }
+      )
+
+    detail = account.get_order_detail(session, 12345, include_fees: true)
+
+    expect(detail.fee_calculation.clearing_fees).to eq(BigDecimal("0.08"))
+    expect(detail.fee_calculation.total).to eq(BigDecimal("0.13"))
+    expect(detail.effective_price).to eq(BigDecimal("-150.0613"))
+  end
+end
+
+RSpec.describe "Tastytrade::Models::Account#find_order_by_ext_number" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { Tastytrade::Models::Account.new("account-number" => "5WZ38925") }
+
+  def orders(*numbers)
+    items = numbers.map.with_index(1) do |number, id|
+      { "id" => id, "status" => "Filled", "ext-exchange-order-number" => number, "legs" => [] }
+    end
+    { "data" => { "items" => items } }
+  end
+
+  before do
+    allow(Date).to receive(:today).and_return(Date.new(2024, 3, 15))
+  end
+
+  it "finds a live order without searching the history" do
+    allow(session).to receive(:get).with("/accounts/5WZ38925/orders/live/", {})
+                                   .and_return(orders("12271026815300", "12271026815307"))
+
+    order = account.find_order_by_ext_number(session, "12271026815307")
+
+    expect(order.id).to eq(2)
+    expect(order.ext_exchange_order_number).to eq("12271026815307")
+    expect(session).to have_received(:get).once
+  end
+
+  it "searches recent order history when no live order matches" do
+    allow(session).to receive(:get).with("/accounts/5WZ38925/orders/live/", {}).and_return(orders("1"))
+    allow(session).to receive(:get)
+      .with("/accounts/5WZ38925/orders/", { "start-date" => "2024-03-08", "page-offset" => 0 })
+      .and_return(orders("2", "12271026815307"))
+
+    expect(account.find_order_by_ext_number(session, "12271026815307").id).to eq(2)
+  end
+
+  it "returns nil when no order matches" do
+    allow(session).to receive(:get).with("/accounts/5WZ38925/orders/live/", {}).and_return(orders("1"))
+    allow(session).to receive(:get)
+      .with("/accounts/5WZ38925/orders/", { "start-date" => "2024-03-04", "page-offset" => 0 })
+      .and_return(orders("2"))
+
+    expect(account.find_order_by_ext_number(session, "12271026815307", days: 11)).to be_nil
+  end
+end
@@ -174,6 +174,107 @@ RSpec.describe Tastytrade::Models::Account, "#cancel_order" do
   end
 end
 
+RSpec.describe Tastytrade::Models::Account, "#cancel_order_with_result" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+
+  # A two-leg spread for 10 that filled 4 before the cancel
+  let(:cancelled_order) do
+    {
+      "id" => 12345,
+      "status" => "Cancelled",
+      "legs" => [
+        { "symbol" => "SPY   240419P00500000", "instrument-type" => "Equity Option", "action" => "Sell to Open",
+          "quantity" => 10, "remaining-quantity" => 6,
+          "fills" => [{ "fill-id" => "f1", "quantity" => 3, "fill-price" => "2.1" },
+                      { "fill-id" => "f2", "quantity" => 1, "fill-price" => "2.1" }] },
+        { "symbol" => "SPY   240419P00495000", "instrument-type" => "Equity Option", "action" => "Buy to Open",
+          "quantity" => 10, "remaining-quantity" => 6, "fills" => [] }
+      ]
+    }
+  end
+
+  it "reports filled and cancelled quantities of a partially filled order" do
+    allow(session).to receive(:delete).with("/accounts/5WV12345/orders/12345/")
+                                      .and_return("data" => cancelled_order)
+
+    result = account.cancel_order_with_result(session, 12345)
+
+    expect(result.order.id).to eq(12345)
+    expect(result).to be_partially_filled
+    expect(result.legs.map(&:filled_quantity)).to eq([4, 4])
+    expect(result.legs.map(&:cancelled_quantity)).to eq([6, 6])
+    expect(result.filled_quantity).to eq(8)
+    expect(result.cancelled_quantity).to eq(12)
+  end
+
+  it "fetches the order when the cancel response has none" do
+    allow(session).to receive(:delete).and_return(nil)
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/12345/").and_return("data" => cancelled_order)
+
+    expect(account.cancel_order_with_result(session, 12345).cancelled_quantity).to eq(12)
+  end
+
+  it "raises OrderAlreadyFilledError for a filled order" do
+    allow(session).to receive(:delete).and_raise(Tastytrade::Error.new("Order already filled"))
+
+    expect { account.cancel_order_with_result(session, 12345) }.to raise_error(Tastytrade::OrderAlreadyFilledError)
+  end
+end
+
+RSpec.describe Tastytrade::Models::Account, "#cancel_all_orders" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+
+  def order_data(id, underlying, status: "Live", cancellable: true)
+    { "id" => id, "status" => status, "cancellable" => cancellable, "underlying-symbol" => underlying, "legs" => [] }
+  end
+
+  before do
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/live/", {}).and_return(
+      "data" => {
+        "items" => [
+          order_data("1", "SPY"),
+          order_data("2", "AAPL"),
+          order_data("3", "SPY", cancellable: false),
+          order_data("4", "QQQ", status: "Filled", cancellable: false),
+          order_data("5", "SPY")
+        ]
+      }
+    )
+  end
+
+  it "cancels every cancellable order and records failures" do
+    allow(session).to receive(:delete).with("/accounts/5WV12345/orders/1/")
+    allow(session).to receive(:delete).with("/accounts/5WV12345/orders/2/")
+                                       .and_raise(Tastytrade::Error, "Order already filled")
+    allow(session).to receive(:delete).with("/accounts/5WV12345/orders/5/")
+
+    results = account.cancel_all_orders(session)
+
+    expect(results.map(&:order_id)).to eq(%w[1 2 5])
+    expect(results.map(&:success?)).to eq([true, false, true])
+    expect(results[1].error).to be_a(Tastytrade::OrderAlreadyFilledError)
+    expect(session).not_to have_received(:delete).with("/accounts/5WV12345/orders/3/")
+  end
+
+  it "only cancels orders matching the block" do
+    allow(session).to receive(:delete)
+
+    results = account.cancel_all_orders(session) { |order| order.underlying_symbol == "SPY" }
+
+    expect(results.map(&:order_id)).to eq(%w[1 5])
+    expect(session).to have_received(:delete).twice
+  end
+
+  it "passes the underlying symbol filter to the live orders request" do
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/live/", { "underlying-symbol" => "SPY" })
+                                   .and_return("data" => { "items" => [] })
+
+    expect(account.cancel_all_orders(session, underlying_symbol: "SPY")).to eq([])
+  end
+end
+
 RSpec.describe Tastytrade::Models::Account, "#replace_order" do
   let(:session) { instance_double(Tastytrade::Session) }
   let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
@@ -222,6 +323,13 @@ RSpec.describe Tastytrade::Models::Account, "#replace_order" do
       expect(result.order_id).to eq("12347")
       expect(result.status).to eq("Received")
     end
+
+    it "reads an order nested under the order key" do
+      allow(new_order).to receive(:to_api_params).and_return(order_params)
+      allow(session).to receive(:put).and_return("data" => { "order" => replace_response["data"] })
+
+      expect(account.replace_order(session, order_id, new_order).order_id).to eq("12347")
+    end
   end
 
   describe "error handling" do
@@ -269,3 +377,182 @@ RSpec.describe Tastytrade::Models::Account, "#replace_order" do
     end
   end
 end
+
+RSpec.describe Tastytrade::Models::Account, "#replace_order_with_result" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+  let(:new_order) do
+    Tastytrade::Order.new(
+      type: Tastytrade::OrderType::LIMIT,
+      legs: Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: 50),
+      price: "155.00"
+    )
+  end
+
+  def live_order(id, price:, status: "Live", symbol: "AAPL", created_at: "2024-03-15T14:30:00Z")
+    {
+      "id" => id, "status" => status, "price" => price, "created-at" => created_at,
+      "legs" => [{ "symbol" => symbol, "instrument-type" => "Equity", "action" => "Buy to Open", "quantity" => 50 }]
+    }
+  end
+
+  context "when the PUT response carries the new order ID" do
+    it "returns a confident match" do
+      allow(session).to receive(:put).and_return("data" => { "id" => 12347, "status" => "Received" })
+
+      result = account.replace_order_with_result(session, "12345", new_order, match_live_orders: true)
+
+      expect(result.order_id).to eq(12347)
+      expect(result).to be_confident
+      expect(result.response.status).to eq("Received")
+    end
+
+    it "reads the ID from a nested order" do
+      allow(session).to receive(:put).and_return("data" => { "order" => { "id" => 12347 } })
+
+      expect(account.replace_order_with_result(session, "12345", new_order).order_id).to eq(12347)
+    end
+  end
+
+  context "when the PUT response has no order ID" do
+    before do
+      allow(session).to receive(:put).and_return(nil)
+    end
+
+    it "returns no match without searching live orders by default" do
+      expect(session).not_to receive(:get)
+
+      result = account.replace_order_with_result(session, "12345", new_order)
+
+      expect(result.order_id).to be_nil
+      expect(result.match).to be_nil
+      expect(result).not_to be_confident
+    end
+
+    it "takes the newest working order with the same symbols and price as a best guess" do
+      allow(session).to receive(:get).with("/accounts/5WV12345/orders/live/", {}).and_return(
+        "data" => {
+          "items" => [
+            live_order(12345, price: "150.0", status: "Cancelled"),
+            live_order(12346, price: "155.0", created_at: "2024-03-15T14:00:00Z"),
+            live_order(12347, price: "155.0", created_at: "2024-03-15T14:31:00Z"),
+            live_order(12348, price: "155.0", symbol: "MSFT", created_at: "2024-03-15T14:32:00Z"),
+            live_order(12349, price: "154.0", created_at: "2024-03-15T14:33:00Z")
+          ]
+        }
+      )
+
+      result = account.replace_order_with_result(session, "12345", new_order, match_live_orders: true)
+
+      expect(result.order_id).to eq(12347)
+      expect(result).to be_best_guess
+      expect(result).not_to be_confident
+    end
+
+    it "returns no match when no live order fits" do
+      allow(session).to receive(:get).and_return("data" => { "items" => [live_order(12349, price: "154.0")] })
+
+      result = account.replace_order_with_result(session, "12345", new_order, match_live_orders: true)
+
+      expect(result.order_id).to be_nil
+      expect(result).not_to be_best_guess
+    end
+  end
+end
+
+RSpec.describe Tastytrade::Models::Account, "#reprice_order" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+  # Captured from /accounts/{account}/orders/{id}, a partially filled credit vertical
+  let(:live_vertical) do
+    {
+      "id" => 12345,
+      "account-number" => "5WV12345",
+      "status" => "Live",
+      "cancellable" => true,
+      "editable" => true,
+      "time-in-force" => "GTC",
+      "order-type" => "Limit",
+      "size" => 3,
+      "price" => "1.15",
+      "price-effect" => "Credit",
+      "underlying-symbol" => "SPY",
+      "legs" => [
+        { "instrument-type" => "Equity Option", "symbol" => "SPY   240419P00500000", "quantity" => 3,
+          "remaining-quantity" => 2, "action" => "Sell to Open", "fills" => [] },
+        { "instrument-type" => "Equity Option", "symbol" => "SPY   240419P00495000", "quantity" => 3,
+          "remaining-quantity" => 2, "action" => "Buy to Open", "fills" => [] }
+      ]
+    }
+  end
+
+  before do
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/12345/").and_return("data" => live_vertical)
+  end
+
+  it "replaces the order keeping its legs and changing only the price" do
+    expect(session).to receive(:put).with(
+      "/accounts/5WV12345/orders/12345/",
+      {
+        "order-type" => "Limit",
+        "time-in-force" => "GTC",
+        "price" => "1.05",
+        "price-effect" => "Credit",
+        "legs" => [
+          { "action" => "Sell to Open", "symbol" => "SPY 240419P00500000", "quantity" => 2,
+            "instrument-type" => "Option" },
+          { "action" => "Buy to Open", "symbol" => "SPY 240419P00495000", "quantity" => 2,
+            "instrument-type" => "Option" }
+        ]
+      }
+    ).and_return("data" => { "id" => 12346, "status" => "Received" })
+
+    expect(account.reprice_order(session, "12345", BigDecimal("1.05")).order_id).to eq(12346)
+  end
+
+  it "uses a given price effect" do
+    expect(session).to receive(:put)
+      .with("/accounts/5WV12345/orders/12345/", hash_including("price" => "0.1", "price-effect" => "Debit"))
+      .and_return("data" => { "id" => 12346 })
+
+    account.reprice_order(session, "12345", "0.10", price_effect: Tastytrade::PriceEffect::DEBIT)
+  end
+
+  it "raises OrderNotEditableError without replacing an order that cannot be edited" do
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/12345/")
+                                   .and_return("data" => live_vertical.merge("status" => "Filled"))
+    expect(session).not_to receive(:put)
+
+    expect { account.reprice_order(session, "12345", "1.05") }
+      .to raise_error(Tastytrade::OrderNotEditableError, /not in an editable state/)
+  end
+
+  it "moves the trigger of a stop order" do
+    stop_order = live_vertical.merge("order-type" => "Stop", "price" => nil, "price-effect" => nil,
+                                     "stop-trigger" => "480.00",
+                                     "legs" => [{ "instrument-type" => "Equity", "symbol" => "SPY", "quantity" => 10,
+                                                  "remaining-quantity" => 10, "action" => "Sell to Close",
+                                                  "fills" => [] }])
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/12345/").and_return("data" => stop_order)
+    expect(session).to receive(:put).with(
+      "/accounts/5WV12345/orders/12345/",
+      {
+        "order-type" => "Stop",
+        "time-in-force" => "GTC",
+        "stop-trigger" => "485.0",
+        "legs" => [{ "action" => "Sell to Close", "symbol" => "SPY", "quantity" => 10, "instrument-type" => "Equity" }]
+      }
+    ).and_return("data" => { "id" => 12346 })
+
+    account.reprice_order(session, "12345", "485.00")
+  end
+
+  it "raises ArgumentError without replacing an order that has no price" do
+    allow(session).to receive(:get).with("/accounts/5WV12345/orders/12345/")
+                                   .and_return("data" => live_vertical.merge("order-type" => "Market", "price" => nil))
+    expect(session).not_to receive(:put)
+
+    expect { account.reprice_order(session, "12345", "1.05") }
+      .to raise_error(ArgumentError, /Cannot reprice Market order 12345/)
+  end
+end
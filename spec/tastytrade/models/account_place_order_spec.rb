@@ -85,6 +85,15 @@ RSpec.describe "Tastytrade::Models::Account#place_order" do
       expect(response).to be_a(Tastytrade::Models::OrderResponse)
     end
 
+    it "checks the order's structure before a dry run" do
+      allow(session).to receive(:post)
+      stop_order = Tastytrade::Order.new(type: Tastytrade::OrderType::STOP, legs: order_leg)
+
+      expect { account.place_order(session, stop_order, dry_run: true) }
+        .to raise_error(Tastytrade::OrderValidationError, /Stop orders require a stop trigger/)
+      expect(session).not_to have_received(:post)
+    end
+
     it "handles dry run orders" do
       allow(session).to receive(:post).and_return(dry_run_response)
 
@@ -101,6 +110,222 @@ RSpec.describe "Tastytrade::Models::Account#place_order" do
     end
   end
 
+  describe "client order IDs" do
+    before do
+      allow(session).to receive(:post).and_return(successful_response)
+    end
+
+    it "serializes the client order ID into the request body" do
+      account.place_order(session, market_order, skip_validation: true, client_order_id: "retry-safe-1")
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders",
+        hash_including("ext-client-order-id" => "retry-safe-1", "order-type" => "Market")
+      )
+    end
+
+    it "sends the same ID on each retry of a submission" do
+      2.times { account.place_order(session, market_order, skip_validation: true, client_order_id: "retry-safe-1") }
+
+      expect(session).to have_received(:post)
+        .with("/accounts/5WX12345/orders", hash_including("ext-client-order-id" => "retry-safe-1")).twice
+    end
+
+    it "overrides the order's own ID without changing the order" do
+      order = Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: order_leg, client_order_id: "original")
+
+      account.place_order(session, order, skip_validation: true, client_order_id: "override")
+
+      expect(session).to have_received(:post)
+        .with("/accounts/5WX12345/orders", hash_including("ext-client-order-id" => "override"))
+      expect(order.client_order_id).to eq("original")
+    end
+  end
+
+  describe "order source" do
+    before do
+      allow(session).to receive_messages(post: successful_response,
+                                         order_defaults: Tastytrade::OrderDefaults.new(source: "my-app"))
+    end
+
+    it "tags an order without a source with the session default" do
+      account.place_order(session, market_order, dry_run: true)
+
+      expect(session).to have_received(:post)
+        .with("/accounts/5WX12345/orders/dry-run", hash_including("source" => "my-app"))
+    end
+
+    it "keeps the order's own source" do
+      order = Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: order_leg, source: "rebalancer")
+
+      account.place_order(session, order, skip_validation: true)
+
+      expect(session).to have_received(:post)
+        .with("/accounts/5WX12345/orders", hash_including("source" => "rebalancer"))
+    end
+
+    it "sends no source without a default" do
+      allow(session).to receive(:order_defaults).and_return(Tastytrade::OrderDefaults.new)
+
+      account.place_order(session, market_order, skip_validation: true)
+
+      expect(session).to have_received(:post).with("/accounts/5WX12345/orders", hash_excluding("source"))
+    end
+  end
+
+  describe "#place_order_checked" do
+    let(:clean_dry_run) { { "data" => { "buying-power-effect" => { "impact" => "1.50" }, "warnings" => [] } } }
+
+    it "submits the order after a clean dry run" do
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders/dry-run", anything).and_return(clean_dry_run)
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders", anything).and_return(successful_response)
+
+      response = account.place_order_checked(session, market_order, skip_validation: true)
+
+      expect(response.order_id).to eq("123456")
+    end
+
+    it "aborts with the warnings when the dry run warns" do
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders/dry-run", anything).and_return(dry_run_response)
+
+      expect {
+        account.place_order_checked(session, market_order)
+      }.to raise_error(Tastytrade::OrderWarningsError) { |error|
+        expect(error.message).to eq("Dry run returned warnings: Market is closed")
+        expect(error.warnings).to eq([{ "code" => "market_closed", "message" => "Market is closed" }])
+        expect(error.dry_run_response.buying_power_effect.impact).to eq(BigDecimal("1.50"))
+      }
+      expect(session).not_to have_received(:post).with("/accounts/5WX12345/orders", anything)
+    end
+
+    it "submits despite warnings when allowed" do
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders/dry-run", anything).and_return(dry_run_response)
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders", anything).and_return(successful_response)
+
+      response = account.place_order_checked(session, market_order, allow_warnings: true, skip_validation: true)
+
+      expect(response.status).to eq("Routed")
+    end
+
+    it "aborts when the dry run returns errors, even with warnings allowed" do
+      errors = { "data" => { "errors" => [{ "code" => "margin_check_failed", "message" => "Low buying power" }] } }
+      allow(session).to receive(:post).with("/accounts/5WX12345/orders/dry-run", anything).and_return(errors)
+
+      expect { account.place_order_checked(session, market_order, allow_warnings: true) }
+        .to raise_error(Tastytrade::OrderValidationError, "Low buying power")
+      expect(session).not_to have_received(:post).with("/accounts/5WX12345/orders", anything)
+    end
+  end
+
+  describe "equity market order helpers" do
+    before do
+      allow(session).to receive(:post).and_return(successful_response)
+    end
+
+    it "places a single-leg market order by quantity" do
+      account.place_equity_market_order(session, "AAPL", 10, Tastytrade::OrderAction::BUY_TO_OPEN,
+                                        skip_validation: true)
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders",
+        "order-type" => "Market",
+        "time-in-force" => "Day",
+        "legs" => [
+          { "action" => "Buy to Open", "symbol" => "AAPL", "quantity" => 10, "instrument-type" => "Equity" }
+        ]
+      )
+    end
+
+    it "places a notional market order by dollar value without a leg quantity" do
+      account.place_equity_notional_market_order(session, "AAPL", "250.50", Tastytrade::OrderAction::BUY_TO_OPEN,
+                                                 dry_run: true)
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders/dry-run",
+        "order-type" => "Market",
+        "time-in-force" => "Day",
+        "legs" => [{ "action" => "Buy to Open", "symbol" => "AAPL", "instrument-type" => "Equity" }],
+        "value" => "250.5",
+        "value-effect" => "Debit"
+      )
+    end
+
+    it "credits the value of a notional sell" do
+      account.place_equity_notional_market_order(session, "AAPL", 100, Tastytrade::OrderAction::SELL_TO_CLOSE,
+                                                 dry_run: true)
+
+      expect(session).to have_received(:post).with(anything, hash_including("value-effect" => "Credit"))
+    end
+  end
+
+  describe "#place_marketable_limit_order" do
+    before do
+      allow(session).to receive(:post).and_return(successful_response)
+    end
+
+    it "submits an immediate-or-cancel limit order" do
+      account.place_marketable_limit_order(session, "AAPL", 100, "190.55", Tastytrade::OrderAction::BUY_TO_OPEN,
+                                           skip_validation: true)
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders",
+        "order-type" => "Limit",
+        "time-in-force" => "IOC",
+        "legs" => [
+          { "action" => "Buy to Open", "symbol" => "AAPL", "quantity" => 100, "instrument-type" => "Equity" }
+        ],
+        "price" => "190.55",
+        "price-effect" => "Debit"
+      )
+    end
+
+    it "submits a fill-or-kill option order" do
+      account.place_marketable_limit_order(session, "SPY 240419C00520000", 5, "2.1",
+                                           Tastytrade::OrderAction::SELL_TO_CLOSE,
+                                           time_in_force: Tastytrade::OrderTimeInForce::FOK,
+                                           instrument_type: "Option", skip_validation: true)
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders",
+        hash_including("order-type" => "Limit", "time-in-force" => "FOK", "price" => "2.1",
+                       "price-effect" => "Credit")
+      )
+    end
+
+    it "rejects other times in force" do
+      expect do
+        account.place_marketable_limit_order(session, "AAPL", 100, "190.55", Tastytrade::OrderAction::BUY_TO_OPEN,
+                                             time_in_force: Tastytrade::OrderTimeInForce::DAY)
+      end.to raise_error(ArgumentError, "Time in force must be one of: IOC, FOK")
+      expect(session).not_to have_received(:post)
+    end
+  end
+
+  describe "#close_position" do
+    let(:position) do
+      Tastytrade::Models::CurrentPosition.new(
+        "symbol" => "AAPL", "instrument-type" => "Equity", "quantity" => "25", "quantity-direction" => "Long"
+      )
+    end
+
+    it "submits the offsetting order" do
+      allow(session).to receive(:post).and_return(successful_response)
+
+      account.close_position(session, position, price: "190.5", skip_validation: true)
+
+      expect(session).to have_received(:post).with(
+        "/accounts/5WX12345/orders",
+        "order-type" => "Limit",
+        "time-in-force" => "Day",
+        "legs" => [
+          { "action" => "Sell to Close", "symbol" => "AAPL", "quantity" => 25, "instrument-type" => "Equity" }
+        ],
+        "price" => "190.5",
+        "price-effect" => "Credit"
+      )
+    end
+  end
+
   describe "error handling" do
     it "handles API errors" do
       allow(session).to receive(:post).and_raise(
@@ -83,6 +83,24 @@ RSpec.describe Tastytrade::Models::Account do
       expect(accounts.last.account_number).to eq("789012")
     end
 
+    it "keeps the authority level of each account" do
+      response["data"]["items"].last["authority-level"] = "trade-only"
+      allow(session).to receive(:get).with("/customers/me/accounts/", {}).and_return(response)
+
+      accounts = described_class.get_all(session)
+
+      expect(accounts.map(&:authority_level)).to eq(%w[owner trade-only])
+      expect(accounts.map(&:owner?)).to eq([true, false])
+    end
+
+    it "lists the accounts from the API" do
+      stubbed = Tastytrade::Testing.session do |stub|
+        stub.get("/customers/me/accounts/") { Tastytrade::Testing.response(response["data"]) }
+      end
+
+      expect(described_class.get_all(stubbed).map(&:account_number)).to eq(%w[5WT0001 789012])
+    end
+
     it "includes closed accounts when specified" do
       allow(session).to receive(:get).with("/customers/me/accounts/", { "include-closed" => true })
                                      .and_return(response)
@@ -152,6 +170,93 @@ RSpec.describe Tastytrade::Models::Account do
       expect(positions.first.symbol).to eq("AAPL")
       expect(positions.first.quantity).to eq(BigDecimal("100"))
     end
+
+    it "passes filters as query parameters" do
+      allow(session).to receive(:get).and_return(positions_data)
+
+      account.get_positions(session, symbol: %w[AAPL MSFT], underlying_symbol: "AAPL",
+                                     instrument_type: "Equity", include_closed: true)
+
+      expect(session).to have_received(:get).with(
+        "/accounts/5WT0001/positions/",
+        { "symbol" => %w[AAPL MSFT], "underlying-symbol" => "AAPL", "instrument-type" => "Equity",
+          "include-closed" => true }
+      )
+    end
+
+    it "filters several instrument types after fetching" do
+      positions_data["data"]["items"] = [
+        { "symbol" => "AAPL", "instrument-type" => "Equity" },
+        { "symbol" => "AAPL  240119C00150000", "instrument-type" => "Equity Option" },
+        { "symbol" => "/ESZ4", "instrument-type" => "Future" }
+      ]
+      allow(session).to receive(:get).with("/accounts/5WT0001/positions/", {}).and_return(positions_data)
+
+      positions = account.get_positions(session, instrument_type: ["Equity", "Equity Option"])
+
+      expect(positions.map(&:instrument_type)).to eq(["Equity", "Equity Option"])
+    end
+  end
+
+  describe "#get_futures_positions" do
+    it "returns only futures and futures option positions" do
+      positions_data = {
+        "data" => {
+          "items" => [
+            { "symbol" => "AAPL", "instrument-type" => "Equity", "multiplier" => 1 },
+            { "symbol" => "/ESZ3", "instrument-type" => "Future", "multiplier" => 50 },
+            { "symbol" => "./ESZ3 EW3X3 231117C4500", "instrument-type" => "Future Option",
+              "underlying-symbol" => "/ESZ3", "multiplier" => 50 }
+          ]
+        }
+      }
+      allow(session).to receive(:get).and_return(positions_data)
+
+      positions = account.get_futures_positions(session, underlying_symbol: "/ESZ3")
+
+      expect(session).to have_received(:get).with("/accounts/5WT0001/positions/", { "underlying-symbol" => "/ESZ3" })
+      expect(positions.map(&:symbol)).to eq(["/ESZ3", "./ESZ3 EW3X3 231117C4500"])
+      expect(positions.map(&:multiplier)).to eq([50, 50])
+    end
+  end
+
+  describe "#get_positions_by_expiration" do
+    let(:positions_data) do
+      {
+        "data" => {
+          "items" => [
+            { "symbol" => "SPY   240419P00500000", "instrument-type" => "Equity Option", "multiplier" => 100 },
+            { "symbol" => "SPY", "instrument-type" => "Equity", "multiplier" => 1 },
+            { "symbol" => "QQQ   240315C00440000", "instrument-type" => "Equity Option", "multiplier" => 100 },
+            { "symbol" => "SPY   240419C00520000", "instrument-type" => "Equity Option", "multiplier" => 100 },
+            { "symbol" => "/ESZ3", "instrument-type" => "Future", "multiplier" => 50 }
+          ]
+        }
+      }
+    end
+
+    before { allow(session).to receive(:get).and_return(positions_data) }
+
+    it "groups option positions by expiration and equities under :equity" do
+      grouped = account.get_positions_by_expiration(session)
+
+      expect(grouped.keys).to eq([Date.new(2024, 3, 15), Date.new(2024, 4, 19), :equity])
+      expect(grouped[Date.new(2024, 4, 19)].map(&:symbol))
+        .to eq(["SPY   240419P00500000", "SPY   240419C00520000"])
+      expect(grouped[:equity].map(&:symbol)).to eq(["SPY"])
+    end
+
+    it "passes filters to the positions request" do
+      account.get_positions_by_expiration(session, underlying_symbol: "SPY")
+
+      expect(session).to have_received(:get).with("/accounts/5WT0001/positions/", { "underlying-symbol" => "SPY" })
+    end
+
+    it "omits the equity group when there are no equity positions" do
+      positions_data["data"]["items"].reject! { |item| item["instrument-type"] == "Equity" }
+
+      expect(account.get_positions_by_expiration(session)).not_to have_key(:equity)
+    end
   end
 
   describe "#get_trading_status" do
@@ -201,6 +306,19 @@ RSpec.describe Tastytrade::Models::Account do
     end
   end
 
+  describe "#get_margin_requirements" do
+    it "returns a MarginRequirements report" do
+      group = { "underlying-symbol" => "SPY", "maintenance-requirement" => "500.0" }
+      allow(session).to receive(:get).with("/margin/accounts/5WT0001/requirements")
+        .and_return("data" => { "account-number" => "5WT0001", "groups" => [group] })
+
+      report = account.get_margin_requirements(session)
+
+      expect(report).to be_a(Tastytrade::Models::MarginRequirements)
+      expect(report.total_maintenance_requirement).to eq(BigDecimal("500.0"))
+    end
+  end
+
   describe "boolean helper methods" do
     describe "#closed?" do
       it "returns true when is_closed is true" do
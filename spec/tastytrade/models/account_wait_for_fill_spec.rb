@@ -0,0 +1,65 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Account, "#wait_for_fill" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+  let(:endpoint) { "/accounts/5WV12345/orders/12345/" }
+
+  def order_response(status)
+    {
+      "data" => {
+        "id" => "12345",
+        "account-number" => "5WV12345",
+        "status" => status,
+        "order-type" => "Limit",
+        "price" => "150.50",
+        "legs" => []
+      }
+    }
+  end
+
+  before do
+    allow(account).to receive(:sleep)
+  end
+
+  it "polls a working order until it fills" do
+    allow(session).to receive(:get).with(endpoint).and_return(
+      order_response("Received"), order_response("Live"), order_response("Live"), order_response("Filled")
+    )
+
+    order = account.wait_for_fill(session, "12345")
+
+    expect(order).to be_filled
+    expect(session).to have_received(:get).exactly(4).times
+  end
+
+  it "backs off between polls up to the maximum interval" do
+    allow(session).to receive(:get).with(endpoint).and_return(
+      *Array.new(5) { order_response("Live") }, order_response("Filled")
+    )
+
+    account.wait_for_fill(session, "12345", interval: 1, max_interval: 5)
+
+    expect(account).to have_received(:sleep).with(1).ordered
+    expect(account).to have_received(:sleep).with(2).ordered
+    expect(account).to have_received(:sleep).with(4).ordered
+    expect(account).to have_received(:sleep).with(5).twice.ordered
+  end
+
+  it "returns cancelled and rejected orders" do
+    allow(session).to receive(:get).with(endpoint).and_return(order_response("Live"), order_response("Rejected"))
+
+    expect(account.wait_for_fill(session, "12345").status).to eq("Rejected")
+  end
+
+  it "returns the working order once the timeout passes" do
+    allow(session).to receive(:get).with(endpoint).and_return(order_response("Live"))
+
+    order = account.wait_for_fill(session, "12345", timeout: 0)
+
+    expect(order.status).to eq("Live")
+    expect(account).not_to have_received(:sleep)
+  end
+end
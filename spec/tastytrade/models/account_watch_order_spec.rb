@@ -0,0 +1,69 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Account, "#watch_order" do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account) { described_class.new({ "account-number" => "5WV12345" }) }
+  let(:endpoint) { "/accounts/5WV12345/orders/12345/" }
+
+  def order_response(status, updated_at)
+    {
+      "data" => {
+        "id" => "12345",
+        "account-number" => "5WV12345",
+        "status" => status,
+        "order-type" => "Limit",
+        "price" => "150.50",
+        "updated-at" => updated_at,
+        "legs" => []
+      }
+    }
+  end
+
+  before do
+    allow(account).to receive(:sleep)
+  end
+
+  it "does not yield again for polls with the same updated-at" do
+    allow(session).to receive(:get).with(endpoint).and_return(
+      order_response("Live", "2024-01-15T09:30:05.000Z"),
+      order_response("Live", "2024-01-15T09:30:05.000Z"),
+      order_response("Live", "2024-01-15T09:30:05.000Z"),
+      order_response("Filled", "2024-01-15T09:31:00.000Z")
+    )
+
+    updates = []
+    result = account.watch_order(session, "12345") { |order| updates << order.status }
+
+    expect(updates).to eq(%w[Live Filled])
+    expect(result.status).to eq("Filled")
+    expect(session).to have_received(:get).exactly(4).times
+  end
+
+  it "yields when updated-at changes even if the status does not" do
+    allow(session).to receive(:get).with(endpoint).and_return(
+      order_response("Live", "2024-01-15T09:30:05.000Z"),
+      order_response("Live", "2024-01-15T09:30:10.000Z"),
+      order_response("Cancelled", "2024-01-15T09:30:20.000Z")
+    )
+
+    updates = []
+    account.watch_order(session, "12345") { |order| updates << order.updated_at }
+
+    expect(updates.size).to eq(3)
+  end
+
+  it "stops at the timeout" do
+    allow(session).to receive(:get).with(endpoint).and_return(order_response("Live", "2024-01-15T09:30:05.000Z"))
+
+    result = account.watch_order(session, "12345", timeout: 0) { |_order| nil }
+
+    expect(result.status).to eq("Live")
+    expect(account).not_to have_received(:sleep)
+  end
+
+  it "requires a block" do
+    expect { account.watch_order(session, "12345") }.to raise_error(ArgumentError, /block is required/)
+  end
+end
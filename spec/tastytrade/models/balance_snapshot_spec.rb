@@ -0,0 +1,75 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::BalanceSnapshot do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account_number) { "5WT0001" }
+
+  # Captured from /accounts/{account}/balance-snapshots
+  let(:bod_data) do
+    {
+      "account-number" => "5WT0001",
+      "cash-balance" => "25000.5",
+      "long-equity-value" => "15120.0",
+      "short-equity-value" => "0.0",
+      "long-derivative-value" => "830.0",
+      "short-derivative-value" => "412.5",
+      "net-liquidating-value" => "40538.0",
+      "equity-buying-power" => "48010.22",
+      "derivative-buying-power" => "24005.11",
+      "maintenance-requirement" => "8350.0",
+      "pending-cash" => "0.0",
+      "currency" => "USD",
+      "snapshot-date" => "2024-03-15",
+      "time-of-day" => "BOD"
+    }
+  end
+  let(:eod_data) do
+    bod_data.merge("time-of-day" => "EOD", "net-liquidating-value" => "40912.75", "cash-balance" => "24810.5")
+  end
+
+  describe "#initialize" do
+    subject(:snapshot) { described_class.new(bod_data) }
+
+    it "parses balances as BigDecimal" do
+      expect(snapshot.cash_balance).to eq(BigDecimal("25000.5"))
+      expect(snapshot.net_liquidating_value).to eq(BigDecimal("40538.0"))
+      expect(snapshot.maintenance_requirement).to eq(BigDecimal("8350.0"))
+    end
+
+    it "parses the snapshot date and time of day" do
+      expect(snapshot.snapshot_date).to eq(Date.new(2024, 3, 15))
+      expect(snapshot).to be_beginning_of_day
+      expect(snapshot).not_to be_end_of_day
+    end
+  end
+
+  describe ".get_all" do
+    it "filters by date and time of day" do
+      allow(session).to receive(:get)
+        .with("/accounts/5WT0001/balance-snapshots", { "snapshot-date" => "2024-03-15", "time-of-day" => "EOD" })
+        .and_return("data" => { "items" => [eod_data] })
+
+      snapshots = described_class.get_all(session, account_number, snapshot_date: "2024-03-15", time_of_day: "EOD")
+
+      expect(snapshots.map(&:net_liquidating_value)).to eq([BigDecimal("40912.75")])
+    end
+
+    it "orders snapshots by date with the beginning of day first" do
+      earlier = bod_data.merge("snapshot-date" => "2024-03-14", "time-of-day" => "EOD")
+      allow(session).to receive(:get)
+        .with("/accounts/5WT0001/balance-snapshots", {})
+        .and_return("data" => { "items" => [eod_data, bod_data, earlier] })
+
+      snapshots = described_class.get_all(session, account_number)
+
+      expect(snapshots.map { |s| [s.snapshot_date.day, s.time_of_day] }).to eq([[14, "EOD"], [15, "BOD"], [15, "EOD"]])
+    end
+
+    it "rejects unknown times of day" do
+      expect { described_class.get_all(session, account_number, time_of_day: "noon") }
+        .to raise_error(ArgumentError, /Invalid time of day: noon/)
+    end
+  end
+end
@@ -0,0 +1,291 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::ComplexOrder do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account_number) { "5WZ38925" }
+
+  def order_data(id, status, action, price)
+    {
+      "id" => id,
+      "account-number" => account_number,
+      "status" => status,
+      "order-type" => "Limit",
+      "time-in-force" => "GTC",
+      "price" => price,
+      "price-effect" => action.start_with?("Buy") ? "Debit" : "Credit",
+      "underlying-symbol" => "AAPL",
+      "complex-order-id" => 900,
+      "legs" => [
+        {
+          "symbol" => "AAPL",
+          "instrument-type" => "Equity",
+          "action" => action,
+          "quantity" => 100,
+          "remaining-quantity" => status == "Filled" ? 0 : 100
+        }
+      ]
+    }
+  end
+
+  let(:otoco_data) do
+    {
+      "id" => 900,
+      "account-number" => account_number,
+      "type" => "OTOCO",
+      "terminal-at" => "2024-01-05T15:00:00Z",
+      "trigger-order" => order_data(1001, "Filled", "Buy to Open", "150.00"),
+      "orders" => [
+        order_data(1002, "Filled", "Sell to Close", "160.00"),
+        order_data(1003, "Cancelled", "Sell to Close", "145.00")
+      ],
+      "related-orders" => [
+        { "id" => 1002, "complex-order-id" => 900, "complex-order-tag" => "OTOCO::oco-1", "status" => "Filled" },
+        { "id" => 1003, "complex-order-id" => 900, "complex-order-tag" => "OTOCO::oco-2", "status" => "Cancelled" }
+      ]
+    }
+  end
+
+  describe "#initialize" do
+    subject(:complex_order) { described_class.new(otoco_data) }
+
+    it "parses the complex order attributes" do
+      expect(complex_order.id).to eq(900)
+      expect(complex_order.type).to eq("OTOCO")
+      expect(complex_order).to be_otoco
+      expect(complex_order).not_to be_oco
+      expect(complex_order.terminal_at).to eq(Time.parse("2024-01-05T15:00:00Z"))
+    end
+
+    it "populates the trigger and child orders" do
+      expect(complex_order.trigger_order).to be_a(Tastytrade::Models::LiveOrder)
+      expect(complex_order.trigger_order.id).to eq(1001)
+      expect(complex_order.orders.map(&:id)).to eq([1002, 1003])
+      expect(complex_order.all_orders.size).to eq(3)
+    end
+
+    it "parses related orders" do
+      expect(complex_order.related_orders.first).to be_a(Tastytrade::Models::RelatedOrder)
+      expect(complex_order.related_orders.first.complex_order_tag).to eq("OTOCO::oco-1")
+    end
+
+    it "identifies which child order filled" do
+      expect(complex_order.filled_orders.map(&:id)).to eq([1002])
+    end
+
+    it "is terminal" do
+      expect(complex_order).to be_terminal
+    end
+
+    it "handles an OCO without a trigger order" do
+      oco = described_class.new("id" => 901, "type" => "OCO",
+                                "orders" => [order_data(1004, "Live", "Sell to Close", "160.00")])
+
+      expect(oco.trigger_order).to be_nil
+      expect(oco.all_orders.size).to eq(1)
+      expect(oco).not_to be_terminal
+    end
+
+    it "serializes to a hash" do
+      hash = complex_order.to_h
+
+      expect(hash[:type]).to eq("OTOCO")
+      expect(hash[:trigger_order][:id]).to eq(1001)
+      expect(hash[:orders].size).to eq(2)
+    end
+  end
+
+  describe ".get_history" do
+    let(:endpoint) { "/accounts/#{account_number}/complex-orders/" }
+
+    it "fetches every page when no page offset is given" do
+      expect(session).to receive(:get).with(endpoint, {}).and_return(
+        "data" => { "items" => [otoco_data] }, "pagination" => { "page-offset" => 0, "total-pages" => 2 }
+      )
+      expect(session).to receive(:get).with(endpoint, { "page-offset" => 1 }).and_return(
+        "data" => { "items" => [otoco_data.merge("id" => 902)] },
+        "pagination" => { "page-offset" => 1, "total-pages" => 2 }
+      )
+
+      orders = described_class.get_history(session, account_number)
+
+      expect(orders.map(&:id)).to eq([900, 902])
+    end
+
+    it "fetches a single page when a page offset is given" do
+      expect(session).to receive(:get).with(endpoint, { "page-offset" => 3, "page-limit" => 10 }).and_return(
+        "data" => { "items" => [otoco_data] }, "pagination" => { "total-pages" => 10 }
+      )
+
+      orders = described_class.get_history(session, account_number, page_offset: 3, page_limit: 10)
+
+      expect(orders.size).to eq(1)
+    end
+
+    it "returns a page with its pagination metadata" do
+      allow(session).to receive(:get).with(endpoint, { "page-offset" => 1 }).and_return(
+        "data" => { "items" => [otoco_data] }, "pagination" => { "page-offset" => 1, "total-pages" => 2 }
+      )
+
+      orders, pagination = described_class.get_history_page(session, account_number, page_offset: 1)
+
+      expect(orders.map(&:id)).to eq([900])
+      expect(pagination).to be_a(Tastytrade::Models::Pagination)
+      expect(pagination.next_page?).to be false
+    end
+
+    it "passes date and status filters" do
+      from_time = Time.utc(2024, 1, 1)
+      to_time = Time.utc(2024, 1, 31)
+
+      expect(session).to receive(:get).with(
+        endpoint,
+        { "status" => "Filled", "from-time" => from_time.iso8601, "to-time" => to_time.iso8601 }
+      ).and_return("data" => { "items" => [] })
+
+      expect(described_class.get_history(session, account_number, status: "Filled",
+                                                                  from_time: from_time, to_time: to_time)).to eq([])
+    end
+  end
+
+  describe ".get_live" do
+    # Captured from /accounts/{account}/complex-orders/live: the entry has
+    # filled and triggered the profit target and stop, which are both working
+    let(:live_otoco) do
+      {
+        "id" => 905,
+        "account-number" => account_number,
+        "type" => "OTOCO",
+        "trigger-order" => order_data(1011, "Filled", "Buy to Open", "150.00").merge("complex-order-id" => 905),
+        "orders" => [
+          order_data(1012, "Live", "Sell to Close", "160.00").merge("complex-order-id" => 905),
+          order_data(1013, "Live", "Sell to Close", "145.00").merge("complex-order-id" => 905, "order-type" => "Stop",
+                                                                    "price" => nil, "stop-trigger" => "145.00")
+        ],
+        "related-orders" => [
+          { "id" => 1012, "complex-order-id" => 905, "complex-order-tag" => "OTOCO::oco-1", "status" => "Live" },
+          { "id" => 1013, "complex-order-id" => 905, "complex-order-tag" => "OTOCO::oco-2", "status" => "Live" }
+        ]
+      }
+    end
+
+    it "parses the triggered order and its working OCO children" do
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/complex-orders/live/").and_return("data" => { "items" => [live_otoco] })
+
+      complex_orders = described_class.get_live(session, account_number)
+
+      expect(complex_orders.size).to eq(1)
+      complex_order = complex_orders.first
+      expect(complex_order).to be_otoco
+      expect(complex_order).not_to be_terminal
+      expect(complex_order.trigger_order.id).to eq(1011)
+      expect(complex_order.trigger_order).to be_filled
+      expect(complex_order.orders.map(&:id)).to eq([1012, 1013])
+      expect(complex_order.orders.map(&:status)).to eq(%w[Live Live])
+      expect(complex_order.orders.last.stop_trigger).to eq(BigDecimal("145.00"))
+      expect(complex_order.related_orders.map(&:complex_order_tag)).to eq(%w[OTOCO::oco-1 OTOCO::oco-2])
+    end
+
+    it "returns an empty list when nothing is working" do
+      allow(session).to receive(:get).and_return("data" => { "items" => [] })
+
+      expect(described_class.get_live(session, account_number)).to eq([])
+    end
+
+    it "is available from the account" do
+      account = Tastytrade::Models::Account.new("account-number" => account_number)
+      allow(session).to receive(:get).and_return("data" => { "items" => [live_otoco] })
+
+      expect(account.get_live_complex_orders(session).map(&:id)).to eq([905])
+    end
+  end
+
+  describe "Account#get_complex_order_history" do
+    it "delegates to ComplexOrder.get_history" do
+      account = Tastytrade::Models::Account.new("account-number" => account_number)
+      expect(described_class).to receive(:get_history)
+        .with(session, account_number, status: "Filled").and_return([])
+
+      expect(account.get_complex_order_history(session, status: "Filled")).to eq([])
+    end
+  end
+
+  describe "Account complex order methods" do
+    let(:account) { Tastytrade::Models::Account.new("account-number" => account_number) }
+    let(:request) { instance_double(Tastytrade::ComplexOrderRequest, to_api_params: { "type" => "OTOCO" }) }
+
+    it "places a complex order" do
+      allow(session).to receive(:post)
+        .with("/accounts/#{account_number}/complex-orders", { "type" => "OTOCO" })
+        .and_return("data" => { "complex-order" => otoco_data, "warnings" => [{ "code" => "w" }] })
+
+      response = account.place_complex_order(session, request)
+
+      expect(response).to be_a(Tastytrade::Models::ComplexOrderResponse)
+      expect(response.complex_order_id).to eq(900)
+      expect(response.complex_order).to be_otoco
+      expect(response.warnings.size).to eq(1)
+    end
+
+    it "dry-runs a complex order" do
+      dry_run_data = {
+        "complex-order" => otoco_data.merge("id" => nil),
+        "buying-power-effect" => {
+          "change-in-margin-requirement" => "15000.0",
+          "change-in-buying-power" => "-15001.42",
+          "current-buying-power" => "50000.0",
+          "new-buying-power" => "34998.58",
+          "isolated-order-margin-requirement" => "15000.0",
+          "is-spread" => false,
+          "impact" => "15001.42",
+          "effect" => "Debit"
+        },
+        "fee-calculation" => {
+          "regulatory-fees" => "0.02", "regulatory-fees-effect" => "Debit",
+          "clearing-fees" => "0.08", "clearing-fees-effect" => "Debit",
+          "commission" => "0.0", "commission-effect" => "None",
+          "proprietary-index-option-fees" => "0.0", "proprietary-index-option-fees-effect" => "None",
+          "total-fees" => "0.1", "total-fees-effect" => "Debit"
+        },
+        "warnings" => [{ "code" => "tif_next_valid_sesssion",
+                         "message" => "Your order will begin working during next valid session." }]
+      }
+      allow(session).to receive(:post)
+        .with("/accounts/#{account_number}/complex-orders/dry-run", { "type" => "OTOCO" })
+        .and_return("data" => dry_run_data)
+
+      response = account.place_complex_order(session, request, dry_run: true)
+
+      expect(response.complex_order_id).to be_nil
+      expect(response.complex_order).to be_otoco
+      expect(response.buying_power_effect.change_in_buying_power).to eq(BigDecimal("-15001.42"))
+      expect(response.buying_power_effect).to be_debit
+      expect(response.fee_calculation.total).to eq(BigDecimal("0.1"))
+      expect(response.warnings.map { |warning| warning["code"] }).to eq(["tif_next_valid_sesssion"])
+    end
+
+    it "gets a complex order by ID" do
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/complex-orders/900/").and_return("data" => otoco_data)
+
+      complex_order = account.get_complex_order(session, 900)
+
+      expect(complex_order.id).to eq(900)
+      expect(complex_order.orders.size).to eq(2)
+    end
+
+    it "cancels a complex order" do
+      expect(session).to receive(:delete).with("/accounts/#{account_number}/complex-orders/900/")
+
+      expect(account.cancel_complex_order(session, 900)).to be_nil
+    end
+
+    it "maps cancel errors" do
+      allow(session).to receive(:delete).and_raise(Tastytrade::Error, "Order already filled")
+
+      expect { account.cancel_complex_order(session, 900) }.to raise_error(Tastytrade::OrderAlreadyFilledError)
+    end
+  end
+end
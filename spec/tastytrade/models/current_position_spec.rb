@@ -116,6 +116,60 @@ RSpec.describe Tastytrade::Models::CurrentPosition do
       end
     end
 
+    context "with futures position" do
+      let(:position_data) do
+        {
+          "account-number" => "5WX12345",
+          "symbol" => "/ESZ3",
+          "instrument-type" => "Future",
+          "underlying-symbol" => "/ESZ3",
+          "quantity" => "2",
+          "quantity-direction" => "Short",
+          "close-price" => "4550.25",
+          "average-open-price" => "4560.0",
+          "mark-price" => "4540.5",
+          "multiplier" => "50.0",
+          "cost-effect" => "Credit",
+          "expires-at" => "2023-12-15T14:30:00Z",
+          "root-symbol" => "/ES"
+        }
+      end
+
+      it "parses the futures multiplier" do
+        expect(subject).to be_futures
+        expect(subject).to be_futures_related
+        expect(subject.root_symbol).to eq("/ES")
+        expect(subject.multiplier).to eq(50)
+        expect(subject.multiplier).to be_an(Integer)
+      end
+
+      it "values the position with the futures multiplier" do
+        # (4560 - 4540.5) * 2 * 50
+        expect(subject.unrealized_pnl).to eq(BigDecimal("1950"))
+        expect(subject.position_value).to eq(BigDecimal("454050"))
+      end
+
+      it "keeps a fractional multiplier" do
+        position_data.merge!("symbol" => "/MBTZ3", "multiplier" => "0.1", "root-symbol" => "/MBT")
+
+        expect(subject.multiplier).to eq(BigDecimal("0.1"))
+      end
+
+      it "closes with a futures leg" do
+        leg = subject.closing_leg
+
+        expect(leg.symbol).to eq("/ESZ3")
+        expect(leg.instrument_type).to eq("Future")
+        expect(leg.action).to eq(Tastytrade::OrderAction::BUY_TO_CLOSE)
+      end
+    end
+
+    it "uses the standard multiplier when none is reported" do
+      option = described_class.new("symbol" => "SPY   240419P00500000", "instrument-type" => "Equity Option")
+
+      expect(option.multiplier).to eq(100)
+    end
+
     context "with nil values" do
       let(:position_data) do
         {
@@ -374,6 +428,54 @@ RSpec.describe Tastytrade::Models::CurrentPosition do
     end
   end
 
+  describe "#closing_order" do
+    it "sells a long equity position to close" do
+      order = subject.closing_order
+
+      expect(order.type).to eq(Tastytrade::OrderType::MARKET)
+      expect(order.legs.first.to_api_params).to eq(
+        "action" => "Sell to Close", "symbol" => "AAPL", "quantity" => 100, "instrument-type" => "Equity"
+      )
+    end
+
+    it "buys a short equity position to close at a limit price" do
+      position_data.merge!("quantity" => "-50", "quantity-direction" => "Short")
+
+      order = subject.closing_order(price: BigDecimal("151.25"))
+
+      expect(order.type).to eq(Tastytrade::OrderType::LIMIT)
+      expect(order.price).to eq(BigDecimal("151.25"))
+      expect(order.price_effect).to eq(Tastytrade::PriceEffect::DEBIT)
+      expect(order.legs.first.action).to eq(Tastytrade::OrderAction::BUY_TO_CLOSE)
+      expect(order.legs.first.quantity).to eq(50)
+    end
+
+    it "sells a long option position to close" do
+      position_data.merge!("symbol" => "AAPL  240119C00150000", "instrument-type" => "Equity Option",
+                           "quantity" => "2", "multiplier" => 100)
+
+      order = subject.closing_order(price: "3.10", time_in_force: Tastytrade::OrderTimeInForce::GTC)
+
+      expect(order.time_in_force).to eq(Tastytrade::OrderTimeInForce::GTC)
+      expect(order.legs.first.to_api_params).to eq(
+        "action" => "Sell to Close", "symbol" => "AAPL 240119C00150000", "quantity" => 2,
+        "instrument-type" => "Option", "position-effect" => "Closing"
+      )
+    end
+
+    it "raises for a closed position" do
+      position_data.merge!("quantity" => "0", "quantity-direction" => "Zero")
+
+      expect { subject.closing_order }.to raise_error(ArgumentError, /already closed/)
+    end
+
+    it "raises for instrument types that cannot be ordered" do
+      position_data["instrument-type"] = "Bond"
+
+      expect { subject.closing_order }.to raise_error(ArgumentError, /Cannot close Bond/)
+    end
+  end
+
   describe "#display_symbol" do
     context "with equity position" do
       it "returns the symbol as-is" do
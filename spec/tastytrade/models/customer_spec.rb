@@ -0,0 +1,36 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Customer do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:customer_data) do
+    {
+      "id" => "me|12345",
+      "first-name" => "Jane",
+      "last-name" => "Trader",
+      "email" => "jane@example.com",
+      "is-professional" => false,
+      "permitted-account-types" => [{ "name" => "Individual" }],
+      "created-at" => "2020-05-01T14:00:00.000+00:00"
+    }
+  end
+
+  describe ".get_current" do
+    it "fetches the customer for the session" do
+      allow(session).to receive(:get).with("/customers/me").and_return("data" => customer_data)
+
+      customer = described_class.get_current(session)
+
+      expect(customer.id).to eq("me|12345")
+      expect(customer.full_name).to eq("Jane Trader")
+      expect(customer.email).to eq("jane@example.com")
+      expect(customer).not_to be_professional
+      expect(customer.created_at).to eq(Time.utc(2020, 5, 1, 14))
+    end
+  end
+
+  it "defaults permitted account types to empty" do
+    expect(described_class.new({}).permitted_account_types).to eq([])
+  end
+end
@@ -0,0 +1,50 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::DividendEvent do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  # Captured from GET /market-metrics/historic-corporate-events/dividends/AAPL
+  let(:response) do
+    {
+      "data" => {
+        "items" => [
+          { "occurred-date" => "2024-05-10", "amount" => "0.25" },
+          { "occurred-date" => "2024-02-09", "amount" => "0.24" }
+        ]
+      }
+    }
+  end
+
+  describe ".get" do
+    before do
+      allow(session).to receive(:get)
+        .with("/market-metrics/historic-corporate-events/dividends/AAPL")
+        .and_return(response)
+    end
+
+    it "returns events oldest first" do
+      events = described_class.get(session, "AAPL")
+
+      expect(events.map(&:occurred_date)).to eq([Date.new(2024, 2, 9), Date.new(2024, 5, 10)])
+      expect(events.map(&:amount)).to eq([BigDecimal("0.24"), BigDecimal("0.25")])
+      expect(events.first.symbol).to eq("AAPL")
+    end
+
+    it "returns an empty list without items" do
+      allow(session).to receive(:get).and_return("data" => {})
+
+      expect(described_class.get(session, "AAPL")).to eq([])
+    end
+  end
+
+  describe "#within?" do
+    it "checks the ex-date against the window" do
+      event = described_class.new("occurred-date" => "2024-05-10", "amount" => "0.25")
+
+      expect(event.within?(Date.new(2024, 5, 1), Date.new(2024, 5, 31))).to be true
+      expect(event.within?(Date.new(2024, 6, 1), Date.new(2024, 6, 30))).to be false
+    end
+  end
+end
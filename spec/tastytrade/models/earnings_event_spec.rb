@@ -0,0 +1,75 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::EarningsEvent do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  # Captured from GET /market-metrics/historic-corporate-events/earnings-reports/AAPL
+  let(:response) do
+    {
+      "data" => {
+        "items" => [
+          { "occurred-date" => "2024-05-02", "eps" => "1.53", "time-of-day" => "AMC", "is-estimated" => false },
+          { "occurred-date" => "2024-02-01", "eps" => "2.18", "time-of-day" => "AMC", "is-estimated" => false },
+          { "occurred-date" => "2024-08-01", "estimated-eps" => "1.35", "time-of-day" => "AMC",
+            "is-estimated" => true }
+        ]
+      }
+    }
+  end
+
+  describe ".get" do
+    it "returns events oldest first" do
+      allow(session).to receive(:get)
+        .with("/market-metrics/historic-corporate-events/earnings-reports/AAPL", {})
+        .and_return(response)
+
+      events = described_class.get(session, "AAPL")
+
+      expect(events.map(&:occurred_date)).to eq([Date.new(2024, 2, 1), Date.new(2024, 5, 2), Date.new(2024, 8, 1)])
+      expect(events.map(&:symbol).uniq).to eq(["AAPL"])
+    end
+
+    it "passes the start date" do
+      allow(session).to receive(:get)
+        .with("/market-metrics/historic-corporate-events/earnings-reports/AAPL", { "start-date" => "2024-03-01" })
+        .and_return("data" => { "items" => [] })
+
+      expect(described_class.get(session, "AAPL", start_date: Date.new(2024, 3, 1))).to eq([])
+    end
+  end
+
+  describe "#initialize" do
+    it "parses a reported event" do
+      event = described_class.new(response["data"]["items"][1])
+
+      expect(event.eps).to eq(BigDecimal("2.18"))
+      expect(event.estimated_eps).to be_nil
+      expect(event).not_to be_estimated
+      expect(event).to be_after_close
+      expect(event).not_to be_before_open
+    end
+
+    it "parses an estimated event" do
+      event = described_class.new(response["data"]["items"][2])
+
+      expect(event).to be_estimated
+      expect(event.eps).to be_nil
+      expect(event.estimated_eps).to eq(BigDecimal("1.35"))
+    end
+  end
+
+  describe "#within?" do
+    let(:event) { described_class.new("occurred-date" => "2024-08-01") }
+
+    it "includes the window boundaries" do
+      expect(event.within?(Date.new(2024, 7, 1), Date.new(2024, 8, 1))).to be true
+      expect(event.within?(Date.new(2024, 8, 2), Date.new(2024, 9, 1))).to be false
+    end
+
+    it "is false without a date" do
+      expect(described_class.new({}).within?(Date.new(2024, 1, 1), Date.new(2024, 12, 31))).to be false
+    end
+  end
+end
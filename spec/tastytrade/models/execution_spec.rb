@@ -0,0 +1,139 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Execution do
+  let(:order_data) do
+    {
+      "id" => "12345",
+      "status" => "Filled",
+      "underlying-symbol" => "AAPL",
+      "legs" => [
+        {
+          "symbol" => "AAPL  240119C00150000",
+          "instrument-type" => "Equity Option",
+          "action" => "Buy to Open",
+          "quantity" => 3,
+          "remaining-quantity" => 0,
+          "fills" => [
+            { "fill-id" => "f1", "quantity" => 1, "fill-price" => "2.50", "filled-at" => "2024-01-10T15:00:00Z" },
+            { "fill-id" => "f2", "quantity" => 2, "fill-price" => "2.55", "filled-at" => "2024-01-10T15:00:01Z" }
+          ]
+        },
+        {
+          "symbol" => "AAPL",
+          "instrument-type" => "Equity",
+          "action" => "Sell to Close",
+          "quantity" => 100,
+          "remaining-quantity" => 0,
+          "fills" => [
+            { "fill-id" => "f3", "quantity" => 100, "fill-price" => "151.00", "filled-at" => "2024-01-10T15:00:02Z" }
+          ]
+        }
+      ]
+    }
+  end
+
+  let(:order) { Tastytrade::Models::LiveOrder.new(order_data) }
+
+  describe ".from_order" do
+    it "creates one execution per fill" do
+      executions = described_class.from_order(order)
+
+      expect(executions.map(&:fill_id)).to eq(%w[f1 f2 f3])
+      expect(executions.first.order_id).to eq("12345")
+      expect(executions.first.underlying_symbol).to eq("AAPL")
+      expect(executions.first.symbol).to eq("AAPL  240119C00150000")
+    end
+  end
+
+  describe "#cost" do
+    it "applies the option multiplier to buys" do
+      execution = described_class.from_order(order).first
+
+      expect(execution.side).to eq("Buy")
+      expect(execution.cost).to eq(BigDecimal("250"))
+    end
+
+    it "leaves futures uncosted without a multiplier" do
+      leg = order_data["legs"].first.merge("symbol" => "/ESH4", "instrument-type" => "Future")
+      futures_order = Tastytrade::Models::LiveOrder.new(order_data.merge("legs" => [leg]))
+
+      expect(described_class.from_order(futures_order).first.cost).to be_nil
+      expect(described_class.from_order(futures_order, multipliers: { "/ESH4" => 50 }).first.cost)
+        .to eq(BigDecimal("12500"))
+    end
+
+    it "is negative for sells" do
+      execution = described_class.from_order(order).last
+
+      expect(execution.side).to eq("Sell")
+      expect(execution.cost).to eq(BigDecimal("-15100"))
+    end
+  end
+
+  describe "Account#get_todays_fills" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:account) { Tastytrade::Models::Account.new("account-number" => "5WV12345") }
+    let(:now) { Time.utc(2024, 1, 10, 20, 0) }
+
+    it "searches from midnight Eastern and flattens fills in time order" do
+      later_order = order_data.merge(
+        "id" => "12346",
+        "legs" => [
+          order_data["legs"].last.merge(
+            "fills" => [{ "fill-id" => "f0", "quantity" => 1, "fill-price" => "150.00",
+                          "filled-at" => "2024-01-10T14:45:00Z" }]
+          )
+        ]
+      )
+
+      expect(session).to receive(:get)
+        .with("/accounts/5WV12345/orders/", { "from-time" => "2024-01-10T00:00:00-05:00", "page-offset" => 0 })
+        .and_return("data" => { "items" => [order_data, later_order] })
+
+      fills = account.get_todays_fills(session, now: now)
+
+      expect(fills.map(&:fill_id)).to eq(%w[f0 f1 f2 f3])
+    end
+
+    it "reads every page of the day's orders" do
+      later_order = order_data.merge("id" => "12346", "legs" => [order_data["legs"].last.merge(
+        "fills" => [{ "fill-id" => "f4", "quantity" => 1, "fill-price" => "150.00",
+                      "filled-at" => "2024-01-10T16:00:00Z" }]
+      )])
+      allow(session).to receive(:get)
+        .with("/accounts/5WV12345/orders/", hash_including("page-offset" => 0))
+        .and_return("data" => { "items" => [order_data] }, "pagination" => { "page-offset" => 0, "total-pages" => 2 })
+      allow(session).to receive(:get)
+        .with("/accounts/5WV12345/orders/", hash_including("page-offset" => 1))
+        .and_return("data" => { "items" => [later_order] }, "pagination" => { "page-offset" => 1, "total-pages" => 2 })
+
+      fills = account.get_todays_fills(session, now: now)
+
+      expect(fills.map(&:fill_id)).to eq(%w[f1 f2 f3 f4])
+    end
+
+    it "costs futures fills with the position multiplier" do
+      futures_leg = {
+        "symbol" => "/ESH4", "instrument-type" => "Future", "action" => "Buy to Open", "quantity" => 1,
+        "remaining-quantity" => 0,
+        "fills" => [{ "fill-id" => "f5", "quantity" => 1, "fill-price" => "4800.25",
+                      "filled-at" => "2024-01-10T15:00:00Z" }]
+      }
+      futures_order = order_data.merge("legs" => [futures_leg])
+      allow(session).to receive(:get)
+        .with("/accounts/5WV12345/orders/", anything)
+        .and_return("data" => { "items" => [futures_order] })
+      allow(session).to receive(:get)
+        .with("/accounts/5WV12345/positions/", { "symbol" => "/ESH4", "include-closed" => true })
+        .and_return("data" => { "items" => [{ "symbol" => "/ESH4", "instrument-type" => "Future",
+                                              "multiplier" => 50 }] })
+
+      fill = account.get_todays_fills(session, now: now).first
+
+      expect(fill.multiplier).to eq(50)
+      expect(fill.cost).to eq(BigDecimal("240012.5"))
+    end
+  end
+end
@@ -0,0 +1,55 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::FeeCalculation do
+  let(:fee_data) do
+    {
+      "regulatory-fees" => "0.04",
+      "regulatory-fees-effect" => "Debit",
+      "clearing-fees" => "0.10",
+      "clearing-fees-effect" => "Debit",
+      "commission" => "1.00",
+      "commission-effect" => "Debit",
+      "proprietary-index-option-fees" => "0.0",
+      "proprietary-index-option-fees-effect" => "None",
+      "total-fees" => "1.14",
+      "total-fees-effect" => "Debit"
+    }
+  end
+
+  subject(:fees) { described_class.new(fee_data) }
+
+  it "parses fee amounts as BigDecimal" do
+    expect(fees.regulatory_fees).to eq(BigDecimal("0.04"))
+    expect(fees.clearing_fees).to eq(BigDecimal("0.10"))
+    expect(fees.commission).to eq(BigDecimal("1.00"))
+    expect(fees.total_fees).to eq(BigDecimal("1.14"))
+    expect(fees.commission_effect).to eq("Debit")
+  end
+
+  describe "#total" do
+    it "uses the reported total fees" do
+      expect(fees.total).to eq(BigDecimal("1.14"))
+    end
+
+    it "sums individual fees without rounding error when no total is reported" do
+      fees = described_class.new(fee_data.except("total-fees").merge("regulatory-fees" => "0.1",
+                                                                      "clearing-fees" => "0.2",
+                                                                      "commission" => "-0.3"))
+
+      expect(fees.total).to eq(BigDecimal("0.6"))
+    end
+
+    it "is zero without any fees" do
+      expect(described_class.new({}).total).to eq(BigDecimal("0"))
+    end
+  end
+
+  describe "#to_h" do
+    it "round-trips amounts as decimal strings" do
+      expect(fees.to_h[:total_fees]).to eq("1.14")
+      expect(described_class.new("commission" => fees.to_h[:commission]).commission).to eq(fees.commission)
+    end
+  end
+end
@@ -0,0 +1,138 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Fundamentals do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  let(:aapl_data) do
+    {
+      "symbol" => "AAPL",
+      "market-cap" => "2850000000000",
+      "price-earnings-ratio" => "29.5",
+      "earnings-per-share" => "6.43",
+      "dividend-yield" => "0.0052",
+      "dividend-rate-per-share" => "0.96",
+      "beta" => "1.28",
+      "listed-market" => "XNAS",
+      "implied-volatility-index" => "0.21",
+      "updated-at" => "2024-03-01T21:00:00.000Z",
+      "earnings" => {
+        "expected-report-date" => "2024-04-25",
+        "time-of-day" => "AMC"
+      }
+    }
+  end
+
+  describe "#initialize" do
+    subject(:fundamentals) { described_class.new(aapl_data) }
+
+    it "parses valuation fields as BigDecimal" do
+      expect(fundamentals.symbol).to eq("AAPL")
+      expect(fundamentals.market_cap).to eq(BigDecimal("2850000000000"))
+      expect(fundamentals.price_earnings_ratio).to eq(BigDecimal("29.5"))
+      expect(fundamentals.earnings_per_share).to eq(BigDecimal("6.43"))
+      expect(fundamentals.beta).to eq(BigDecimal("1.28"))
+    end
+
+    it "parses the next earnings date" do
+      expect(fundamentals.next_earnings_date).to eq(Date.new(2024, 4, 25))
+      expect(fundamentals.earnings_time_of_day).to eq("AMC")
+      expect(fundamentals.days_to_earnings(Date.new(2024, 4, 20))).to eq(5)
+    end
+
+    it "detects dividend payers" do
+      expect(fundamentals).to be_dividend
+      expect(described_class.new("symbol" => "TSLA")).not_to be_dividend
+    end
+
+    it "parses a full market metrics item" do
+      item = JSON.parse(<<~JSON)
+        {
+          "symbol": "KO",
+          "implied-volatility-index": "0.152",
+          "liquidity-rating": 4,
+          "market-cap": 262400000000,
+          "price-earnings-ratio": "24.41",
+          "earnings-per-share": "2.47",
+          "dividend-yield": "0.0318",
+          "dividend-rate-per-share": "1.94",
+          "shares-outstanding": "4310000000.0",
+          "sector": "Consumer Staples",
+          "industry": "Beverages",
+          "beta": "0.59",
+          "listed-market": "XNYS",
+          "updated-at": "2024-03-01T21:00:00.000Z",
+          "earnings": { "expected-report-date": "2024-04-30", "time-of-day": "BMO" }
+        }
+      JSON
+
+      fundamentals = described_class.new(item)
+
+      expect(fundamentals.market_cap).to eq(BigDecimal("262400000000"))
+      expect(fundamentals.dividend_yield).to eq(BigDecimal("0.0318"))
+      expect(fundamentals.shares_outstanding).to eq(4_310_000_000)
+      expect(fundamentals.sector).to eq("Consumer Staples")
+      expect(fundamentals.to_h).to include(market_cap: "262400000000.0", shares_outstanding: 4_310_000_000)
+      expect(described_class.new(item.merge("shares-outstanding" => "4.31E9")).shares_outstanding).to eq(4_310_000_000)
+    end
+
+    it "leaves missing fields nil" do
+      minimal = described_class.new("symbol" => "XYZ")
+
+      expect(minimal.market_cap).to be_nil
+      expect(minimal.sector).to be_nil
+      expect(minimal.next_earnings_date).to be_nil
+      expect(minimal.days_to_earnings).to be_nil
+    end
+  end
+
+  describe ".get" do
+    it "fetches fundamentals from market metrics" do
+      expect(session).to receive(:get)
+        .with("/market-metrics", { "symbols" => "AAPL" })
+        .and_return("data" => { "items" => [aapl_data] })
+
+      fundamentals = described_class.get(session, "AAPL")
+
+      expect(fundamentals).to be_a(described_class)
+      expect(fundamentals.symbol).to eq("AAPL")
+    end
+
+    it "returns nil for an unknown symbol" do
+      allow(session).to receive(:get).and_return("data" => { "items" => [] })
+
+      expect(described_class.get(session, "NOPE")).to be_nil
+    end
+  end
+
+  describe ".dividend_yield" do
+    it "returns the yield for a symbol" do
+      allow(session).to receive(:get)
+        .with("/market-metrics", { "symbols" => "AAPL" })
+        .and_return("data" => { "items" => [aapl_data] })
+
+      expect(described_class.dividend_yield(session, "AAPL")).to eq(BigDecimal("0.0052"))
+    end
+
+    it "returns nil for an unknown symbol" do
+      allow(session).to receive(:get).and_return("data" => { "items" => [] })
+
+      expect(described_class.dividend_yield(session, "NOPE")).to be_nil
+    end
+  end
+
+  describe ".get_all" do
+    it "requests several symbols at once" do
+      expect(session).to receive(:get)
+        .with("/market-metrics", { "symbols" => "AAPL,MSFT" })
+        .and_return("data" => { "items" => [aapl_data, aapl_data.merge("symbol" => "MSFT")] })
+
+      expect(described_class.get_all(session, %w[AAPL MSFT]).map(&:symbol)).to eq(%w[AAPL MSFT])
+    end
+
+    it "skips the request for an empty list" do
+      expect(described_class.get_all(session, [])).to eq([])
+    end
+  end
+end
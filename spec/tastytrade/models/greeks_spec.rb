@@ -0,0 +1,149 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Greeks do
+  # Answers the client the way DXLink does and replays a recorded feed
+  # snapshot once symbols are subscribed
+  let(:fake_dxlink_class) do
+    Class.new do
+      attr_reader :sent
+
+      def initialize(feed_data, auth_state: "AUTHORIZED")
+        @feed_data = feed_data
+        @auth_state = auth_state
+        @sent = []
+      end
+
+      def connect(on_message:, on_close:)
+        @on_message = on_message
+        @on_close = on_close
+        @open = true
+        self
+      end
+
+      def send_text(text)
+        message = JSON.parse(text)
+        @sent << message
+        reply_to(message)
+        true
+      end
+
+      def open?
+        @open
+      end
+
+      def close
+        @open = false
+      end
+
+      private
+
+      def reply_to(message)
+        case message["type"]
+        when "SETUP"
+          push("type" => "SETUP", "channel" => 0, "keepaliveTimeout" => 60, "version" => "1.0-2.0.5")
+          push("type" => "AUTH_STATE", "channel" => 0, "state" => "UNAUTHORIZED")
+        when "AUTH"
+          if @auth_state == "AUTHORIZED"
+            push("type" => "AUTH_STATE", "channel" => 0, "state" => "AUTHORIZED", "userId" => "u-1")
+          else
+            push("type" => "ERROR", "channel" => 0, "error" => "UNAUTHORIZED", "message" => "Invalid token")
+          end
+        when "CHANNEL_REQUEST"
+          push("type" => "CHANNEL_OPENED", "channel" => 1, "service" => "FEED")
+        when "FEED_SUBSCRIPTION"
+          @feed_data.each { |data| push("type" => "FEED_DATA", "channel" => 1, "data" => data) }
+        end
+      end
+
+      def push(message)
+        @on_message.call(message.to_json)
+      end
+    end
+  end
+
+  # Captured from the Greeks feed for two SPY contracts
+  let(:feed_data) do
+    [
+      ["Greeks", [
+        "Greeks", ".SPY240315C450", 1_710_532_800_000, 12.41, 0.1432, 0.5213, 0.0187, -0.2954, 0.0941, 0.3112,
+        "Greeks", ".SPY240315P450", 1_710_532_800_000, 11.87, 0.1455, -0.4791, 0.0187, -0.2401, -0.0872, 0.3109
+      ]],
+      ["Greeks", ["Greeks", ".SPY240315C999", 0, "NaN", "NaN", "NaN", "NaN", "NaN", "NaN", "NaN"]]
+    ]
+  end
+
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:connections) { [] }
+  let(:connector) do
+    lambda do |_url|
+      fake_dxlink_class.new(feed_data).tap { |connection| connections << connection }
+    end
+  end
+
+  before do
+    allow(session).to receive(:quote_token).and_return(
+      Tastytrade::Models::QuoteToken.new(
+        "token" => "quote-token", "dxlink-url" => "wss://tasty-openapi-ws.dxfeed.com/realtime", "level" => "api"
+      )
+    )
+  end
+
+  describe ".get_all" do
+    let(:symbols) { %w[.SPY240315C450 .SPY240315P450] }
+
+    it "returns greeks keyed by streamer symbol" do
+      greeks = described_class.get_all(session, symbols, connector: connector)
+
+      expect(greeks.keys).to match_array(symbols)
+      call = greeks[".SPY240315C450"]
+      expect(call.delta).to eq(BigDecimal("0.5213"))
+      expect(call.gamma).to eq(BigDecimal("0.0187"))
+      expect(call.theta).to eq(BigDecimal("-0.2954"))
+      expect(call.rho).to eq(BigDecimal("0.0941"))
+      expect(call.vega).to eq(BigDecimal("0.3112"))
+      expect(call.volatility).to eq(BigDecimal("0.1432"))
+      expect(call.time).to eq(Time.at(1_710_532_800))
+      expect(greeks[".SPY240315P450"].delta).to eq(BigDecimal("-0.4791"))
+    end
+
+    it "authorizes with the quote token and subscribes to the Greeks feed" do
+      described_class.get_all(session, symbols, connector: connector)
+
+      sent = connections.first.sent
+      expect(sent.map { |message| message["type"] })
+        .to eq(%w[SETUP AUTH CHANNEL_REQUEST FEED_SETUP FEED_SUBSCRIPTION])
+      expect(sent[1]["token"]).to eq("quote-token")
+      expect(sent[3]["acceptEventFields"]).to eq("Greeks" => described_class::FIELDS)
+      expect(sent[4]["add"]).to eq(symbols.map { |symbol| { "type" => "Greeks", "symbol" => symbol } })
+      expect(connections.first).not_to be_open
+    end
+
+    it "parses NaN values as nil" do
+      greeks = described_class.get_all(session, [".SPY240315C999"], connector: connector)
+
+      expect(greeks[".SPY240315C999"].delta).to be_nil
+      expect(greeks[".SPY240315C999"].time).to be_nil
+    end
+
+    it "leaves out symbols without an event once the timeout passes" do
+      symbols = [".SPY240315C450", ".QQQ240315C400"]
+      greeks = described_class.get_all(session, symbols, timeout: 0.05, connector: connector)
+
+      expect(greeks.keys).to eq([".SPY240315C450"])
+    end
+
+    it "raises when the token is rejected" do
+      rejecting = ->(_url) { fake_dxlink_class.new(feed_data, auth_state: "UNAUTHORIZED") }
+
+      expect { described_class.get_all(session, symbols, connector: rejecting) }
+        .to raise_error(Tastytrade::StreamError, /Invalid token/)
+    end
+
+    it "returns an empty hash without connecting for no symbols" do
+      expect(described_class.get_all(session, [], connector: connector)).to eq({})
+      expect(connections).to be_empty
+    end
+  end
+end
@@ -183,6 +183,29 @@ RSpec.describe Tastytrade::Models::LiveOrder do
         expect(filled_order.filled?).to be true
       end
     end
+
+    context "with a contingent order" do
+      it "#contingent? returns true while waiting on the trigger" do
+        order = described_class.new(live_order_data.merge("status" => "Contingent", "contingent-status" => "Pending"))
+
+        expect(order.contingent?).to be true
+        expect(order.triggered?).to be false
+      end
+
+      it "#triggered? returns true once released" do
+        order = described_class.new(live_order_data.merge("contingent-status" => "Triggered"))
+
+        expect(order.contingent?).to be false
+        expect(order.triggered?).to be true
+      end
+
+      it "is neither contingent nor triggered without a contingent status" do
+        order = described_class.new(live_order_data)
+
+        expect(order.contingent?).to be false
+        expect(order.triggered?).to be false
+      end
+    end
   end
 
   describe "quantity methods" do
@@ -222,6 +245,51 @@ RSpec.describe Tastytrade::Models::LiveOrder do
       end
     end
   end
+
+  describe "fill aggregation" do
+    let(:fill) do
+      lambda do |quantity, price|
+        { "quantity" => quantity, "fill-price" => price, "filled-at" => "2024-01-15T09:35:00.000Z" }
+      end
+    end
+
+    let(:spread_order_data) do
+      live_order_data.merge(
+        "legs" => [
+          { "symbol" => "AAPL", "quantity" => 3, "remaining-quantity" => 0,
+            "fills" => [fill.call(1, "2.10"), fill.call(2, "2.40")] },
+          { "symbol" => "MSFT", "quantity" => 3, "remaining-quantity" => 1,
+            "fills" => [fill.call(2, "1.00")] }
+        ]
+      )
+    end
+
+    it "sums fill quantities across legs" do
+      expect(described_class.new(spread_order_data).total_filled).to eq(5)
+    end
+
+    it "weights the average fill price by quantity" do
+      # (1 * 2.10 + 2 * 2.40 + 2 * 1.00) / 5
+      expect(described_class.new(spread_order_data).average_fill_price).to eq(BigDecimal("1.78"))
+    end
+
+    it "returns nil average price when nothing has filled" do
+      order = described_class.new(live_order_data)
+
+      expect(order.total_filled).to eq(0)
+      expect(order.average_fill_price).to be_nil
+    end
+
+    it "is complete only when every leg has no remaining quantity" do
+      expect(described_class.new(spread_order_data)).not_to be_complete
+      expect(described_class.new(filled_order_data)).to be_complete
+      expect(described_class.new(partially_filled_order_data)).not_to be_complete
+    end
+
+    it "is not complete without legs" do
+      expect(described_class.new(live_order_data.merge("legs" => []))).not_to be_complete
+    end
+  end
 end
 
 RSpec.describe Tastytrade::Models::LiveOrderLeg do
@@ -0,0 +1,167 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::MarginRequirements do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account_number) { "5WT0001" }
+
+  let(:response) do
+    {
+      "data" => {
+        "account-number" => account_number,
+        "description" => "Total",
+        "margin-calculation-type" => "Reg T",
+        "option-level" => "No Restrictions",
+        "margin-requirement" => "6250.0",
+        "margin-requirement-effect" => "Debit",
+        "initial-requirement" => "6250.0",
+        "initial-requirement-effect" => "Debit",
+        "maintenance-requirement" => "6250.0",
+        "maintenance-requirement-effect" => "Debit",
+        "margin-excess" => "43750.0",
+        "margin-excess-effect" => "Credit",
+        "buying-power" => "43750.0",
+        "buying-power-effect" => "Credit",
+        "last-state-timestamp" => 1_710_532_800_000,
+        "groups" => [
+          {
+            "description" => "SPY",
+            "code" => "SPY",
+            "underlying-symbol" => "SPY",
+            "underlying-type" => "Equity",
+            "margin-calculation-type" => "Reg T",
+            "expected-price-range-up-adjustment" => "0.1",
+            "expected-price-range-down-adjustment" => "-0.1",
+            "point-of-no-return-percent" => "0.85",
+            "margin-requirement" => "500.0",
+            "margin-requirement-effect" => "Debit",
+            "initial-requirement" => "500.0",
+            "initial-requirement-effect" => "Debit",
+            "maintenance-requirement" => "500.0",
+            "maintenance-requirement-effect" => "Debit",
+            "buying-power" => "500.0",
+            "buying-power-effect" => "Debit",
+            "position-entries" => [
+              {
+                "instrument-symbol" => "SPY   240419P00500000",
+                "instrument-type" => "Equity Option",
+                "quantity" => "-1",
+                "close-price" => "4.25",
+                "strike-price" => "500.0",
+                "option-type" => "P",
+                "expiration-date" => "2024-04-19",
+                "amount-per-unit" => "100"
+              },
+              {
+                "instrument-symbol" => "SPY   240419P00495000",
+                "instrument-type" => "Equity Option",
+                "quantity" => "1",
+                "close-price" => "3.10",
+                "strike-price" => "495.0",
+                "option-type" => "P",
+                "expiration-date" => "2024-04-19",
+                "amount-per-unit" => "100"
+              }
+            ],
+            "groups" => [
+              {
+                "description" => "Vertical",
+                "code" => "SPY-VERTICAL",
+                "maintenance-requirement" => "500.0",
+                "maintenance-requirement-effect" => "Debit"
+              }
+            ]
+          },
+          {
+            "description" => "AAPL",
+            "code" => "AAPL",
+            "underlying-symbol" => "AAPL",
+            "underlying-type" => "Equity",
+            "expected-price-range-up-adjustment" => "0.15",
+            "expected-price-range-down-adjustment" => "-0.15",
+            "margin-requirement" => "5750.0",
+            "maintenance-requirement" => "5750.0",
+            "maintenance-requirement-effect" => "Debit",
+            "position-entries" => [
+              {
+                "instrument-symbol" => "AAPL",
+                "instrument-type" => "Equity",
+                "quantity" => "100",
+                "close-price" => "172.50",
+                "amount-per-unit" => "1"
+              }
+            ]
+          }
+        ]
+      },
+      "context" => "/margin/accounts/5WT0001/requirements"
+    }
+  end
+
+  describe ".get" do
+    before do
+      allow(session).to receive(:get)
+        .with("/margin/accounts/#{account_number}/requirements")
+        .and_return(response)
+    end
+
+    subject(:report) { described_class.get(session, account_number) }
+
+    it "parses account-level totals" do
+      expect(report.account_number).to eq(account_number)
+      expect(report.margin_calculation_type).to eq("Reg T")
+      expect(report.maintenance_requirement).to eq(BigDecimal("6250.0"))
+      expect(report.margin_excess).to eq(BigDecimal("43750.0"))
+      expect(report.buying_power_effect).to eq("Credit")
+      expect(report.last_state_timestamp).to eq(Time.utc(2024, 3, 15, 20, 0, 0))
+    end
+
+    it "parses groups by underlying" do
+      spy = report.group_for("SPY")
+
+      expect(report.groups.map(&:underlying_symbol)).to eq(%w[SPY AAPL])
+      expect(spy.expected_price_range_up_adjustment).to eq(BigDecimal("0.1"))
+      expect(spy.expected_price_range_down_adjustment).to eq(BigDecimal("-0.1"))
+      expect(spy.point_of_no_return_percent).to eq(BigDecimal("0.85"))
+      expect(spy.margin_requirement).to eq(BigDecimal("500.0"))
+      expect(spy.maintenance_requirement_effect).to eq("Debit")
+    end
+
+    it "parses per-leg entries" do
+      short_put = report.group_for("SPY").entries.first
+
+      expect(short_put.instrument_symbol).to eq("SPY   240419P00500000")
+      expect(short_put.quantity).to eq(BigDecimal("-1"))
+      expect(short_put.strike_price).to eq(BigDecimal("500.0"))
+      expect(short_put.expiration_date).to eq(Date.new(2024, 4, 19))
+      expect(report.group_for("AAPL").entries.first.expiration_date).to be_nil
+    end
+
+    it "parses nested groups" do
+      nested = report.group_for("SPY").groups
+
+      expect(nested.size).to eq(1)
+      expect(nested.first.code).to eq("SPY-VERTICAL")
+      expect(nested.first.entries).to eq([])
+    end
+  end
+
+  describe "#total_maintenance_requirement" do
+    it "sums maintenance requirements across groups" do
+      report = described_class.new(response["data"])
+
+      expect(report.total_maintenance_requirement).to eq(BigDecimal("6250.0"))
+    end
+
+    it "is zero without groups" do
+      expect(described_class.new({}).total_maintenance_requirement).to eq(BigDecimal("0"))
+    end
+  end
+
+  describe "#group_for" do
+    it "returns nil for an unknown underlying" do
+      expect(described_class.new(response["data"]).group_for("QQQ")).to be_nil
+    end
+  end
+end
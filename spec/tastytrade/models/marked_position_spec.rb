@@ -0,0 +1,77 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::MarkedPosition do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  def position(symbol, quantity, direction, average_open_price, instrument_type: "Equity Option", multiplier: 100)
+    Tastytrade::Models::CurrentPosition.new(
+      "account-number" => "5WT0001",
+      "symbol" => symbol,
+      "underlying-symbol" => "SPY",
+      "instrument-type" => instrument_type,
+      "quantity" => quantity.to_s,
+      "quantity-direction" => direction,
+      "average-open-price" => average_open_price,
+      "close-price" => "0.0",
+      "multiplier" => multiplier
+    )
+  end
+
+  let(:shares) { position("SPY", 50, "Long", "500.0", instrument_type: "Equity", multiplier: 1) }
+  let(:short_put) { position("SPY   240419P00500000", 2, "Short", "4.35") }
+  let(:long_call) { position("SPY   240419C00520000", 1, "Long", "2.1") }
+
+  before do
+    allow(session).to receive(:get)
+      .with("/market-data/by-type", { "equity" => "SPY" })
+      .and_return("data" => { "items" => [{ "symbol" => "SPY", "mark" => "505.25" }] })
+    allow(session).to receive(:get)
+      .with("/market-data/by-type", { "equity-option" => "SPY   240419P00500000,SPY   240419C00520000" })
+      .and_return("data" => { "items" => [{ "symbol" => "SPY   240419P00500000", "bid" => "3.8", "ask" => "4.0",
+                                             "mid" => "3.9" }] })
+  end
+
+  describe ".mark" do
+    subject(:marked) { described_class.mark(session, [shares, short_put, long_call]).to_h { |p| [p.symbol, p] } }
+
+    it "values shares once per share" do
+      expect(marked["SPY"].market_value).to eq(BigDecimal("25262.5"))
+      expect(marked["SPY"].unrealized_pnl).to eq(BigDecimal("262.5"))
+    end
+
+    it "values option contracts with their multiplier and direction" do
+      put = marked["SPY   240419P00500000"]
+
+      expect(put.mark).to eq(BigDecimal("3.9"))
+      expect(put.market_value).to eq(BigDecimal("-780"))
+      expect(put.unrealized_pnl).to eq(BigDecimal("90"))
+    end
+
+    it "leaves positions without a quote unvalued" do
+      call = marked["SPY   240419C00520000"]
+
+      expect(call).not_to be_marked
+      expect(call.market_value).to be_nil
+      expect(call.unrealized_pnl).to be_nil
+    end
+
+    it "skips closed positions" do
+      closed = position("QQQ", 0, "Zero", "400.0", instrument_type: "Equity", multiplier: 1)
+
+      expect(described_class.mark(session, [shares, closed]).map(&:symbol)).to eq(["SPY"])
+    end
+  end
+
+  describe "Account#get_marked_positions" do
+    it "values the account's positions" do
+      account = Tastytrade::Models::Account.new("account-number" => "5WT0001")
+      allow(account).to receive(:get_positions).with(session, instrument_type: "Equity").and_return([shares])
+
+      positions = account.get_marked_positions(session, instrument_type: "Equity")
+
+      expect(positions.map(&:market_value)).to eq([BigDecimal("25262.5")])
+    end
+  end
+end
@@ -106,6 +106,357 @@ RSpec.describe Tastytrade::Models::NestedOptionChain do
     end
   end
 
+  describe "multiple option roots" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:spx_response) do
+      {
+        "data" => {
+          "items" => [
+            nested_chain_data.merge("underlying-symbol" => "SPX", "root-symbol" => "SPX",
+                                    "expirations" => [expiration1_data]),
+            nested_chain_data.merge("underlying-symbol" => "SPX", "root-symbol" => "SPXW",
+                                    "expirations" => [expiration2_data])
+          ]
+        }
+      }
+    end
+
+    before do
+      allow(session).to receive(:get)
+        .with("/option-chains/SPX/nested", params: { symbol: "SPX" })
+        .and_return(spx_response)
+    end
+
+    it ".get_all returns one chain per root" do
+      chains = described_class.get_all(session, "SPX")
+
+      expect(chains.map(&:root_symbol)).to eq(%w[SPX SPXW])
+    end
+
+    it ".get_by_root returns the chain for the requested root" do
+      chain = described_class.get_by_root(session, "SPXW", underlying_symbol: "SPX")
+
+      expect(chain.root_symbol).to eq("SPXW")
+      expect(chain.expiration_dates).to eq([Date.parse("2024-03-22")])
+    end
+
+    it ".get_by_root returns nil for an unknown root" do
+      expect(described_class.get_by_root(session, "SPXQ", underlying_symbol: "SPX")).to be_nil
+    end
+
+    it "tags index chains as cash settled with their settlement times" do
+      spxw = {
+        "underlying-symbol" => "SPX", "root-symbol" => "SPXW", "option-chain-type" => "Standard",
+        "shares-per-contract" => 100,
+        "expirations" => [
+          { "expiration-type" => "Weekly", "expiration-date" => "2024-03-15", "days-to-expiration" => 2,
+            "settlement-type" => "PM",
+            "strikes" => [{ "strike-price" => "5000.0", "call" => "SPXW  240315C05000000",
+                            "put" => "SPXW  240315P05000000" }] }
+        ]
+      }
+      chain = described_class.new(spxw)
+
+      expect(chain).to be_cash_settled
+      expect(chain.expirations.first).to be_pm_settled
+      expect(described_class.new(nested_chain_data)).not_to be_cash_settled
+    end
+
+    it ".get still returns the first root" do
+      expect(described_class.get(session, "SPX").root_symbol).to eq("SPX")
+    end
+
+    it ".active_expirations combines the expirations of every root" do
+      expirations = described_class.active_expirations(session, "SPX")
+
+      expect(expirations.map(&:expiration_type)).to eq(%w[Regular Weekly])
+    end
+
+    it ".active_expirations filters by expiration type and days to expiration" do
+      expect(described_class.active_expirations(session, "SPX", expiration_type: "Weekly").map(&:expiration_date))
+        .to eq([Date.parse("2024-03-22")])
+      expect(described_class.active_expirations(session, "SPX", expiration_type: "Weekly", max_dte: 30)).to be_empty
+    end
+  end
+
+  describe "#strikes_by_liquidity" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:expiration) { Date.parse("2024-03-15") }
+    let(:market_data) do
+      contract = Struct.new(:open_interest, :volume)
+      {
+        "SPY240315C00450000" => contract.new(100, 50),
+        "SPY240315P00450000" => contract.new(300, 10),
+        "SPY240315C00455000" => contract.new(900, 5),
+        "SPY240315P00455000" => contract.new(20, 400)
+      }
+    end
+
+    it "ranks strikes by combined open interest" do
+      ranked = nested_chain.strikes_by_liquidity(session, expiration, market_data: market_data)
+
+      expect(ranked.map(&:strike_price)).to eq([BigDecimal("455"), BigDecimal("450")])
+      expect(ranked.first.open_interest).to eq(920)
+      expect(ranked.first.call_open_interest).to eq(900)
+    end
+
+    it "ranks by volume on one side" do
+      ranked = nested_chain.strikes_by_liquidity(session, expiration, sort_by: :volume, option_type: :call,
+                                                                      market_data: market_data)
+
+      expect(ranked.map(&:strike_price)).to eq([BigDecimal("450"), BigDecimal("455")])
+    end
+
+    it "sorts by strike on request" do
+      ranked = nested_chain.strikes_by_liquidity(session, expiration, sort_by: :strike, market_data: market_data)
+
+      expect(ranked.map(&:strike_price)).to eq([BigDecimal("450"), BigDecimal("455")])
+    end
+
+    it "fetches open interest from option instruments by default" do
+      options = market_data.map do |symbol, data|
+        instance_double(Tastytrade::Models::Option, symbol: symbol, open_interest: data.open_interest,
+                                                    volume: data.volume)
+      end
+      allow(Tastytrade::Models::Option).to receive(:get).and_return(options)
+
+      ranked = nested_chain.strikes_by_liquidity(session, expiration)
+
+      expect(Tastytrade::Models::Option).to have_received(:get).with(session, market_data.keys)
+      expect(ranked.first.strike_price).to eq(BigDecimal("455"))
+    end
+
+    it "treats contracts without data as illiquid" do
+      ranked = nested_chain.strikes_by_liquidity(session, expiration, market_data: {})
+
+      expect(ranked.map(&:open_interest)).to eq([0, 0])
+    end
+
+    it "returns an empty list for an unknown expiration" do
+      expect(nested_chain.strikes_by_liquidity(session, Date.parse("2030-01-01"), market_data: {})).to eq([])
+    end
+
+    it "validates the sort key" do
+      expect { nested_chain.strikes_by_liquidity(session, expiration, sort_by: :delta) }
+        .to raise_error(ArgumentError, /Invalid sort/)
+    end
+  end
+
+  describe "#find_option_by_delta" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:expiration) { Date.parse("2024-03-15") }
+    let(:greek) { Struct.new(:delta) }
+    let(:greeks) do
+      {
+        ".SPY240315C450" => greek.new(BigDecimal("0.52")),
+        ".SPY240315P450" => greek.new(BigDecimal("-0.48")),
+        ".SPY240315C455" => greek.new(BigDecimal("0.34")),
+        ".SPY240315P455" => greek.new(BigDecimal("-0.66"))
+      }
+    end
+    let(:option) { instance_double(Tastytrade::Models::Option) }
+
+    before do
+      allow(Tastytrade::Models::Option).to receive(:get).and_return([option])
+    end
+
+    it "returns the call with the nearest delta" do
+      expect(nested_chain.find_option_by_delta(session, expiration, :call, 0.30, greeks: greeks)).to eq(option)
+      expect(Tastytrade::Models::Option).to have_received(:get).with(session, "SPY240315C00455000")
+    end
+
+    it "matches put deltas by magnitude" do
+      nested_chain.find_option_by_delta(session, expiration, :put, -0.50, greeks: greeks)
+      nested_chain.find_option_by_delta(session, expiration, :put, 0.50, greeks: greeks)
+
+      expect(Tastytrade::Models::Option).to have_received(:get).with(session, "SPY240315P00450000").twice
+    end
+
+    it "skips strikes without greeks" do
+      greeks.delete(".SPY240315C455")
+
+      nested_chain.find_option_by_delta(session, expiration, :call, 0.30, greeks: greeks)
+
+      expect(Tastytrade::Models::Option).to have_received(:get).with(session, "SPY240315C00450000")
+    end
+
+    it "fetches greeks for the expiration by default" do
+      allow(Tastytrade::Models::Greeks).to receive(:get_all).and_return(greeks)
+
+      nested_chain.find_option_by_delta(session, expiration, :put, -0.70, timeout: 5)
+
+      expect(Tastytrade::Models::Greeks).to have_received(:get_all)
+        .with(session, [".SPY240315P450", ".SPY240315P455"], timeout: 5)
+      expect(Tastytrade::Models::Option).to have_received(:get).with(session, "SPY240315P00455000")
+    end
+
+    it "returns nil when no strike has a delta" do
+      expect(nested_chain.find_option_by_delta(session, expiration, :call, 0.30, greeks: {})).to be_nil
+      expect(nested_chain.find_option_by_delta(session, Date.parse("2030-01-01"), :call, 0.30)).to be_nil
+    end
+
+    it "validates the option type and target" do
+      expect { nested_chain.find_option_by_delta(session, expiration, :straddle, 0.30, greeks: greeks) }
+        .to raise_error(ArgumentError, /Invalid option type/)
+      expect { nested_chain.find_option_by_delta(session, expiration, :call, 30, greeks: greeks) }
+        .to raise_error(ArgumentError, "Target delta must be between -1 and 1")
+    end
+  end
+
+  describe "#enriched_strikes" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:expiration) { Date.parse("2024-03-15") }
+    let(:quote) { Struct.new(:bid, :ask) }
+    let(:quotes) do
+      {
+        "SPY240315C00450000" => quote.new(BigDecimal("5.1"), BigDecimal("5.2")),
+        "SPY240315P00450000" => quote.new(BigDecimal("4.8"), BigDecimal("4.9")),
+        "SPY240315C00455000" => quote.new(BigDecimal("2.3"), BigDecimal("2.4"))
+      }
+    end
+    let(:greek) { Struct.new(:delta, :gamma) }
+    let(:greeks) do
+      {
+        ".SPY240315C450" => greek.new(BigDecimal("0.52"), BigDecimal("0.04")),
+        ".SPY240315P450" => greek.new(BigDecimal("-0.48"), BigDecimal("0.04")),
+        ".SPY240315C455" => greek.new(BigDecimal("0.34"), BigDecimal("0.03"))
+      }
+    end
+
+    it "pairs each strike with its quotes and greeks" do
+      strike = nested_chain.enriched_strikes(session, expiration, quotes: quotes, greeks: greeks).first
+
+      expect(strike.strike_price).to eq(BigDecimal("450"))
+      expect(strike.call).to eq("SPY240315C00450000")
+      expect([strike.call_bid, strike.call_ask]).to eq([BigDecimal("5.1"), BigDecimal("5.2")])
+      expect([strike.put_bid, strike.put_ask]).to eq([BigDecimal("4.8"), BigDecimal("4.9")])
+      expect(strike.call_delta).to eq(BigDecimal("0.52"))
+      expect(strike.put_greeks.gamma).to eq(BigDecimal("0.04"))
+    end
+
+    it "leaves contracts without quotes or greeks nil" do
+      strike = nested_chain.enriched_strikes(session, expiration, quotes: quotes, greeks: greeks).last
+
+      expect(strike.strike_price).to eq(BigDecimal("455"))
+      expect(strike.call_delta).to eq(BigDecimal("0.34"))
+      expect(strike.put_bid).to be_nil
+      expect(strike.put_greeks).to be_nil
+      expect(strike.put_delta).to be_nil
+    end
+
+    it "fetches quotes and greeks for the expiration by default" do
+      allow(Tastytrade::Models::Quote).to receive(:get_all_resilient).and_return([quotes, {}])
+      allow(Tastytrade::Models::Greeks).to receive(:get_all).and_return(greeks)
+
+      strikes = nested_chain.enriched_strikes(session, expiration, timeout: 5)
+
+      expect(Tastytrade::Models::Quote).to have_received(:get_all_resilient)
+        .with(session, %w[SPY240315C00450000 SPY240315P00450000 SPY240315C00455000 SPY240315P00455000],
+              instrument_type: :option)
+      expect(Tastytrade::Models::Greeks).to have_received(:get_all)
+        .with(session, [".SPY240315C450", ".SPY240315P450", ".SPY240315C455", ".SPY240315P455"], timeout: 5)
+      expect(strikes.map(&:call_bid)).to eq([BigDecimal("5.1"), BigDecimal("2.3")])
+    end
+
+    it "returns an empty list for an unknown expiration" do
+      expect(nested_chain.enriched_strikes(session, Date.parse("2030-01-01"))).to eq([])
+    end
+
+    it "is available from the underlying symbol" do
+      allow(described_class).to receive(:get).with(session, "SPY").and_return(nested_chain)
+
+      strikes = described_class.get_enriched_strikes(session, "SPY", expiration, quotes: quotes, greeks: greeks)
+
+      expect(strikes.map(&:strike_price)).to eq([BigDecimal("450"), BigDecimal("455")])
+    end
+  end
+
+  describe "#filter" do
+    let(:synthetic_chain) do
+      expirations = [["2024-04-19", 65, "Regular"], ["2024-03-15", 30, "Regular"], ["2024-03-22", 37, "Weekly"],
+                     ["2024-03-08", 23, "Weekly"], ["2024-03-28", 43, "Quarterly"]]
+      described_class.new(
+        "underlying-symbol" => "SPY",
+        "expirations" => expirations.map do |date, dte, type|
+          {
+            "expiration-date" => date,
+            "days-to-expiration" => dte,
+            "expiration-type" => type,
+            "strikes" => (440..460).step(2).map { |strike| { "strike-price" => strike.to_s } }
+          }
+        end
+      )
+    end
+
+    def strike_prices(expiration)
+      expiration.strikes.map { |strike| strike.strike_price.to_i }
+    end
+
+    it "keeps the earliest expirations up to the limit" do
+      filtered = synthetic_chain.filter(expirations_limit: 2)
+
+      expect(filtered.expirations.map(&:expiration_date)).to eq([Date.new(2024, 3, 8), Date.new(2024, 3, 15)])
+    end
+
+    it "filters by days to expiration before applying the limit" do
+      filtered = synthetic_chain.filter(min_dte: 25, max_dte: 60, expirations_limit: 5)
+
+      expect(filtered.expirations.map(&:days_to_expiration)).to eq([30, 37, 43])
+    end
+
+    it "filters by expiration type" do
+      filtered = synthetic_chain.filter(expiration_type: "Weekly")
+
+      expect(filtered.expirations.map(&:expiration_date)).to eq([Date.new(2024, 3, 8), Date.new(2024, 3, 22)])
+    end
+
+    it "combines several expiration types with a days to expiration window" do
+      filtered = synthetic_chain.filter(expiration_type: %w[Regular Quarterly], max_dte: 60)
+
+      expect(filtered.expirations.map(&:days_to_expiration)).to eq([30, 43])
+    end
+
+    it "rejects unknown expiration types" do
+      expect { synthetic_chain.filter(expiration_type: "Daily") }
+        .to raise_error(ArgumentError, /Invalid expiration type: Daily/)
+    end
+
+    it "centers strikes on the reference price" do
+      filtered = synthetic_chain.filter(strikes_per_expiration: 3, reference_price: 445.5)
+
+      expect(filtered.expirations.map { |exp| strike_prices(exp) }.uniq).to eq([[444, 446, 448]])
+    end
+
+    it "keeps the middle strikes without a reference price" do
+      filtered = synthetic_chain.filter(expirations_limit: 1, strikes_per_expiration: 4)
+
+      expect(strike_prices(filtered.expirations.first)).to eq([446, 448, 450, 452])
+    end
+
+    it "keeps every strike when there are fewer than requested" do
+      filtered = synthetic_chain.filter(strikes_per_expiration: 50)
+
+      expect(strike_prices(filtered.expirations.first).size).to eq(11)
+    end
+
+    it "leaves the original chain unchanged" do
+      synthetic_chain.filter(expirations_limit: 1, strikes_per_expiration: 2)
+
+      expect(synthetic_chain.expirations.size).to eq(5)
+      expect(synthetic_chain.expirations.map { |exp| exp.strikes.size }.uniq).to eq([11])
+    end
+
+    it "fetches and filters with .get_filtered" do
+      session = instance_double(Tastytrade::Session)
+      allow(session).to receive(:get).and_return("data" => { "items" => [nested_chain_data] })
+
+      filtered = described_class.get_filtered(session, "SPY", max_dte: 30, strikes_per_expiration: 1,
+                                                              reference_price: 456)
+
+      expect(filtered.expirations.map { |exp| strike_prices(exp) }).to eq([[455]])
+    end
+  end
+
   describe "#expiration_dates" do
     it "returns sorted expiration dates" do
       dates = nested_chain.expiration_dates
@@ -0,0 +1,76 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::NetLiqSnapshot do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:account_number) { "5WT0001" }
+
+  let(:snapshot_data) do
+    {
+      "open" => "10000.00",
+      "high" => "10250.50",
+      "low" => "9900.25",
+      "close" => "10100.75",
+      "pending-cash-open" => "0.0",
+      "pending-cash-high" => "0.0",
+      "pending-cash-low" => "0.0",
+      "pending-cash-close" => "-25.00",
+      "total-open" => "10000.00",
+      "total-high" => "10250.50",
+      "total-low" => "9900.25",
+      "total-close" => "10075.75",
+      "time" => "2024-03-15 20:00:00+00"
+    }
+  end
+
+  describe "#initialize" do
+    subject(:snapshot) { described_class.new(snapshot_data) }
+
+    it "parses values as BigDecimal" do
+      expect(snapshot.close).to eq(BigDecimal("10100.75"))
+      expect(snapshot.pending_cash_close).to eq(BigDecimal("-25.00"))
+      expect(snapshot.total_open).to eq(BigDecimal("10000.00"))
+      expect(snapshot.total_close).to eq(BigDecimal("10075.75"))
+    end
+
+    it "parses the time" do
+      expect(snapshot.time).to eq(Time.utc(2024, 3, 15, 20, 0, 0))
+    end
+  end
+
+  describe ".get_history" do
+    it "fetches snapshots oldest first" do
+      later = snapshot_data.merge("time" => "2024-03-16 20:00:00+00", "close" => "10200.00")
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/net-liq/history", { "time-back" => "1m" })
+        .and_return("data" => { "items" => [later, snapshot_data] })
+
+      history = described_class.get_history(session, account_number, time_back: "1m")
+
+      expect(history.map(&:close)).to eq([BigDecimal("10100.75"), BigDecimal("10200.00")])
+    end
+
+    it "accepts a bare array of snapshots" do
+      allow(session).to receive(:get).and_return("data" => [snapshot_data])
+
+      expect(described_class.get_history(session, account_number).size).to eq(1)
+    end
+
+    it "rejects unknown time back values" do
+      expect { described_class.get_history(session, account_number, time_back: "2w") }
+        .to raise_error(ArgumentError, /Invalid time back: 2w/)
+    end
+  end
+
+  describe "Account#get_net_liquidating_value_history" do
+    it "delegates with the account number" do
+      account = Tastytrade::Models::Account.new("account-number" => account_number)
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/net-liq/history", { "time-back" => "1y" })
+        .and_return("data" => { "items" => [snapshot_data] })
+
+      expect(account.get_net_liquidating_value_history(session).first).to be_a(described_class)
+    end
+  end
+end
@@ -51,6 +51,92 @@ RSpec.describe Tastytrade::Models::OptionChain do
 
   let(:option_chain) { described_class.new(chain_data) }
 
+  describe ".each_option" do
+    let(:session) { instance_double(Tastytrade::Session) }
+
+    before do
+      allow(session).to receive(:get_items).with("/option-chains/SPY").and_yield(option1_data).and_yield(option2_data)
+    end
+
+    it "yields each contract as an option" do
+      symbols = []
+      described_class.each_option(session, "SPY") { |option| symbols << option.symbol }
+
+      expect(symbols).to eq(%w[SPY240315C00450000 SPY240315P00450000])
+    end
+
+    it "returns an enumerator without a block" do
+      expect(described_class.each_option(session, "SPY").map(&:class)).to all(eq(Tastytrade::Models::Option))
+    end
+  end
+
+  describe ".get_for_expiration" do
+    let(:session) { instance_double(Tastytrade::Session) }
+
+    let(:nested_response) do
+      {
+        "data" => {
+          "items" => [{
+            "underlying-symbol" => "SPY",
+            "root-symbol" => "SPY",
+            "expirations" => [
+              {
+                "expiration-date" => "2024-03-15",
+                "strikes" => [
+                  { "strike-price" => "450.0", "call" => "SPY240315C00450000", "put" => "SPY240315P00450000" }
+                ]
+              },
+              {
+                "expiration-date" => "2024-03-22",
+                "strikes" => [
+                  { "strike-price" => "455.0", "call" => "SPY240322C00455000", "put" => "SPY240322P00455000" }
+                ]
+              }
+            ]
+          }]
+        }
+      }
+    end
+
+    before do
+      allow(session).to receive(:get)
+        .with("/option-chains/SPY/nested", params: { symbol: "SPY" })
+        .and_return(nested_response)
+    end
+
+    it "fetches only the contracts for the expiration" do
+      allow(session).to receive(:get)
+        .with("/instruments/options", params: { symbols: "SPY240315C00450000,SPY240315P00450000" })
+        .and_return("data" => { "items" => [option1_data, option2_data] })
+
+      options = described_class.get_for_expiration(session, "SPY", "2024-03-15")
+
+      expect(options.map(&:symbol)).to eq(%w[SPY240315C00450000 SPY240315P00450000])
+      expect(options.map(&:expiration_date).uniq).to eq([Date.new(2024, 3, 15)])
+      expect(session).not_to have_received(:get).with("/option-chains/SPY/compact", anything)
+    end
+
+    it "returns an empty list for an unknown expiration" do
+      expect(described_class.get_for_expiration(session, "SPY", Date.new(2024, 3, 29))).to eq([])
+    end
+
+    it "requests large expirations in batches" do
+      strikes = (1..60).map do |i|
+        { "strike-price" => i.to_s, "call" => format("SPY240315C%08d", i * 1000),
+          "put" => format("SPY240315P%08d", i * 1000) }
+      end
+      nested_response["data"]["items"][0]["expirations"][0]["strikes"] = strikes
+      allow(session).to receive(:get).with("/instruments/options", anything) do |_path, query|
+        { "data" => { "items" => query[:params][:symbols].split(",").map { |symbol| { "symbol" => symbol } } } }
+      end
+
+      options = described_class.get_for_expiration(session, "SPY", Date.new(2024, 3, 15))
+
+      expect(options.size).to eq(120)
+      expect(session).to have_received(:get).with("/instruments/options", anything).twice
+    end
+  end
+
   describe "#initialize" do
     it "parses chain attributes" do
       expect(option_chain.underlying_symbol).to eq("SPY")
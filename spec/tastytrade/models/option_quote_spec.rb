@@ -0,0 +1,83 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::OptionQuote do
+  let(:session) { instance_double(Tastytrade::Session) }
+  let(:option) do
+    Tastytrade::Models::Option.new(
+      "symbol" => "SPY240315C00450000",
+      "root-symbol" => "SPY",
+      "underlying-symbol" => "SPY",
+      "option-type" => "Call",
+      "strike-price" => "450.0",
+      "expiration-date" => "2024-03-15"
+    )
+  end
+  let(:snapshot) do
+    { "bid" => "3.10", "ask" => "3.20", "mark" => "3.15", "delta" => "0.52", "implied-volatility" => "0.18" }
+  end
+
+  describe "#initialize" do
+    subject(:quote) { described_class.new(option, snapshot) }
+
+    it "combines contract data with the snapshot" do
+      expect(quote.symbol).to eq("SPY240315C00450000")
+      expect(quote.strike_price).to eq(BigDecimal("450"))
+      expect(quote).to be_call
+      expect(quote.bid).to eq(BigDecimal("3.10"))
+      expect(quote.ask).to eq(BigDecimal("3.20"))
+      expect(quote.delta).to eq(BigDecimal("0.52"))
+      expect(quote.implied_volatility).to eq(BigDecimal("0.18"))
+    end
+
+    it "calculates mid and spread" do
+      expect(quote.mid).to eq(BigDecimal("3.15"))
+      expect(quote.spread).to eq(BigDecimal("0.10"))
+      expect(quote).to be_live
+    end
+
+    it "handles a contract without a live market" do
+      empty = described_class.new(option, { "bid" => nil, "ask" => "NaN" })
+
+      expect(empty).not_to be_live
+      expect(empty.mid).to be_nil
+      expect(empty.delta).to be_nil
+    end
+  end
+
+  describe ".get" do
+    before do
+      allow(Tastytrade::Models::Option).to receive(:get).with(session, "SPY240315C00450000").and_return([option])
+    end
+
+    it "uses a supplied snapshot source" do
+      quote = described_class.get(session, "SPY240315C00450000", snapshot_source: ->(_option) { snapshot })
+
+      expect(quote.delta).to eq(BigDecimal("0.52"))
+    end
+
+    it "fetches a market data snapshot by default" do
+      expect(session).to receive(:get)
+        .with("/market-data/by-type", { "equity-option" => "SPY240315C00450000" })
+        .and_return("data" => { "items" => [snapshot] })
+
+      expect(described_class.get(session, "SPY240315C00450000").ask).to eq(BigDecimal("3.20"))
+    end
+
+    it "returns contract data when the snapshot request fails" do
+      allow(session).to receive(:get).and_raise(Tastytrade::Error, "Resource not found")
+
+      quote = described_class.get(session, "SPY240315C00450000")
+
+      expect(quote.symbol).to eq("SPY240315C00450000")
+      expect(quote).not_to be_live
+    end
+
+    it "returns nil for an unknown contract" do
+      allow(Tastytrade::Models::Option).to receive(:get).and_return([])
+
+      expect(described_class.get(session, "SPY240315C00999000")).to be_nil
+    end
+  end
+end
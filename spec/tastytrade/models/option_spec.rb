@@ -145,6 +145,62 @@ RSpec.describe Tastytrade::Models::Option do
     end
   end
 
+  describe ".get_by_components" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:components) { { underlying: "AAPL", expiration: "2024-03-15", option_type: "C", strike_price: 172.5 } }
+
+    it "fetches the option by its OCC symbol" do
+      allow(session).to receive(:get)
+        .with("/instruments/options", params: { symbols: "AAPL  240315C00172500" })
+        .and_return("data" => { "items" => [option_data.merge("symbol" => "AAPL  240315C00172500")] })
+
+      option = described_class.get_by_components(session, **components)
+
+      expect(option.symbol).to eq("AAPL  240315C00172500")
+    end
+
+    it "accepts a Date and a put" do
+      expect(described_class).to receive(:get).with(session, "SPXW  240315P05100000").and_return([])
+
+      expect(described_class.get_by_components(session, underlying: "SPXW", expiration: Date.new(2024, 3, 15),
+                                                        option_type: "Put", strike_price: "5100")).to be_nil
+    end
+
+    it "round-trips through a stubbed API" do
+      require "tastytrade/testing"
+      session = Tastytrade::Testing.session do |stub|
+        stub.get("/instruments/options") do |env|
+          expect(URI.decode_www_form_component(env.url.query)).to include("AAPL  240315C00172500")
+          Tastytrade::Testing.response(
+            "items" => [option_data.merge("symbol" => "AAPL  240315C00172500", "strike-price" => "172.5")]
+          )
+        end
+      end
+
+      option = described_class.get_by_components(session, **components)
+
+      expect(option.symbol).to eq("AAPL  240315C00172500")
+      expect(option.strike_price).to eq(BigDecimal("172.5"))
+    end
+
+    it "validates the expiration format" do
+      expect { described_class.get_by_components(session, **components, expiration: "03/15/2024") }
+        .to raise_error(ArgumentError, /Expiration must be a Date or YYYY-MM-DD/)
+    end
+
+    it "requires a positive strike" do
+      expect { described_class.get_by_components(session, **components, strike_price: 0) }
+        .to raise_error(ArgumentError, /Strike price must be positive/)
+      expect { described_class.get_by_components(session, **components, strike_price: "abc") }
+        .to raise_error(ArgumentError, /Strike price must be positive/)
+    end
+
+    it "requires a call or put" do
+      expect { described_class.get_by_components(session, **components, option_type: "X") }
+        .to raise_error(ArgumentError, /Option type must be C or P/)
+    end
+  end
+
   describe "#call?" do
     it "returns true for call options" do
       expect(option.call?).to be true
@@ -169,6 +225,45 @@ RSpec.describe Tastytrade::Models::Option do
     end
   end
 
+  describe "settlement" do
+    # Captured from /instruments/equity-options/SPXW%20%20240315P05000000
+    let(:spxw_put) do
+      described_class.new(
+        "symbol" => "SPXW  240315P05000000",
+        "instrument-type" => "Equity Option",
+        "root-symbol" => "SPXW",
+        "underlying-symbol" => "SPX",
+        "option-type" => "P",
+        "expiration-date" => "2024-03-15",
+        "strike-price" => "5000.0",
+        "exercise-style" => "European",
+        "expiration-type" => "Weekly",
+        "settlement-type" => "PM",
+        "shares-per-contract" => 100,
+        "streamer-symbol" => ".SPXW240315P5000"
+      )
+    end
+
+    it "treats index options as cash settled" do
+      expect(spxw_put).to be_cash_settled
+      expect(spxw_put).to be_pm_settled
+      expect(spxw_put).not_to be_am_settled
+    end
+
+    it "treats equity options as settled in shares" do
+      expect(option).not_to be_cash_settled
+      expect(described_class.new(option_data.merge("settlement-type" => "Cash"))).to be_cash_settled
+    end
+
+    it "builds an equity option order leg for an index option" do
+      leg = spxw_put.to_order_leg(action: Tastytrade::OrderAction::SELL_TO_OPEN, quantity: 1)
+
+      expect(leg.to_api_params).to eq(
+        "action" => "Sell to Open", "symbol" => "SPXW 240315P05000000", "quantity" => 1, "instrument-type" => "Option"
+      )
+    end
+  end
+
   describe "#expired?" do
     it "returns false for future expiration" do
       future_data = option_data.merge("expiration-date" => (Date.today + 30).to_s)
@@ -208,6 +303,52 @@ RSpec.describe Tastytrade::Models::Option do
       nil_option = described_class.new(nil_data)
       expect(nil_option.days_until_expiration).to be_nil
     end
+
+    context "with a given date" do
+      # Friday, March 15 2024
+      let(:option) { described_class.new(option_data.merge("expiration-date" => "2024-03-15")) }
+
+      it "counts calendar days, including weekends" do
+        expect(option.days_until_expiration(Date.new(2024, 3, 8))).to eq(7)
+        expect(option.days_until_expiration(Date.new(2024, 3, 15))).to eq(0)
+      end
+
+      it "takes a time's date in US/Eastern" do
+        friday_evening_in_new_york = Time.utc(2024, 3, 16, 1)
+
+        expect(option.expired?(friday_evening_in_new_york)).to be false
+        expect(option.days_until_expiration(friday_evening_in_new_york)).to eq(0)
+        expect(option.expired?(Time.utc(2024, 3, 16, 5))).to be true
+      end
+    end
+  end
+
+  describe "#trading_days_until_expiration" do
+    it "skips weekends" do
+      option = described_class.new(option_data.merge("expiration-date" => "2024-03-15"))
+
+      expect(option.trading_days_until_expiration(Date.new(2024, 3, 8))).to eq(5)
+      expect(option.trading_days_until_expiration(Date.new(2024, 3, 9))).to eq(5)
+    end
+
+    it "skips market holidays" do
+      # Good Friday, March 29 2024, falls between Thursday and the Monday expiration
+      option = described_class.new(option_data.merge("expiration-date" => "2024-04-01"))
+
+      expect(option.days_until_expiration(Date.new(2024, 3, 28))).to eq(4)
+      expect(option.trading_days_until_expiration(Date.new(2024, 3, 28))).to eq(1)
+    end
+
+    it "is 0 on expiration day and after" do
+      option = described_class.new(option_data.merge("expiration-date" => "2024-03-15"))
+
+      expect(option.trading_days_until_expiration(Date.new(2024, 3, 15))).to eq(0)
+      expect(option.trading_days_until_expiration(Date.new(2024, 3, 18))).to eq(0)
+    end
+
+    it "returns nil when expiration_date is nil" do
+      expect(described_class.new(option_data.merge("expiration-date" => nil)).trading_days_until_expiration).to be_nil
+    end
   end
 
   describe "moneyness methods" do
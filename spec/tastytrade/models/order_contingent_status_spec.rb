@@ -0,0 +1,35 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::OrderContingentStatus do
+  describe "constants" do
+    it "defines the contingent statuses" do
+      expect(described_class::PENDING).to eq("Pending")
+      expect(described_class::TRIGGERED).to eq("Triggered")
+      expect(described_class::ALL_STATUSES).to eq(%w[Pending Triggered])
+    end
+  end
+
+  describe ".pending?" do
+    it "returns true only for Pending" do
+      expect(described_class.pending?("Pending")).to be true
+      expect(described_class.pending?("Triggered")).to be false
+      expect(described_class.pending?(nil)).to be false
+    end
+  end
+
+  describe ".triggered?" do
+    it "returns true only for Triggered" do
+      expect(described_class.triggered?("Triggered")).to be true
+      expect(described_class.triggered?("Pending")).to be false
+    end
+  end
+
+  describe ".valid?" do
+    it "validates contingent status values" do
+      expect(described_class.valid?("Pending")).to be true
+      expect(described_class.valid?("Waiting")).to be false
+    end
+  end
+end
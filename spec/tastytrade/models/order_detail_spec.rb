@@ -0,0 +1,104 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::OrderDetail do
+  # A debit call spread for three contracts, each leg filled in two pieces
+  let(:order_data) do
+    {
+      "id" => 12345,
+      "status" => "Filled",
+      "size" => 3,
+      "underlying-symbol" => "SPY",
+      "legs" => [
+        {
+          "symbol" => "SPY   240315C00450000", "instrument-type" => "Equity Option", "action" => "Buy to Open",
+          "quantity" => 3, "remaining-quantity" => 0,
+          "fills" => [
+            { "fill-id" => "f1", "quantity" => 2, "fill-price" => "5.10" },
+            { "fill-id" => "f2", "quantity" => 1, "fill-price" => "5.16" }
+          ]
+        },
+        {
+          "symbol" => "SPY   240315C00455000", "instrument-type" => "Equity Option", "action" => "Sell to Open",
+          "quantity" => 3, "remaining-quantity" => 0,
+          "fills" => [
+            { "fill-id" => "f3", "quantity" => 1, "fill-price" => "2.95" },
+            { "fill-id" => "f4", "quantity" => 2, "fill-price" => "2.98" }
+          ]
+        }
+      ]
+    }
+  end
+  let(:order) { Tastytrade::Models::LiveOrder.new(order_data) }
+  let(:fees) { Tastytrade::Models::FeeCalculation.new("total-fees" => "3.90") }
+
+  describe "#net_execution_price" do
+    it "nets the average fill price of each leg" do
+      expect(described_class.new(order).net_execution_price).to eq(BigDecimal("-2.15"))
+    end
+
+    it "is positive for a credit" do
+      order_data["legs"].each { |leg| leg["action"] = leg["action"].sub(/Buy|Sell/, "Buy" => "Sell", "Sell" => "Buy") }
+
+      expect(described_class.new(order).net_execution_price).to eq(BigDecimal("2.15"))
+    end
+
+    it "uses the fills so far on a partially filled order" do
+      order_data["legs"][0]["fills"].pop
+      order_data["legs"][1]["fills"].pop
+
+      detail = described_class.new(order)
+
+      expect(detail.net_execution_price).to eq(BigDecimal("-2.15"))
+      expect(detail.filled_units).to eq(1)
+    end
+
+    it "weights legs by ratio" do
+      order_data["legs"][1]["ratio-quantity"] = 2
+      order_data["legs"][1]["fills"] = [{ "quantity" => 6, "fill-price" => "2.97" }]
+
+      expect(described_class.new(order).net_execution_price).to eq(BigDecimal("0.82"))
+    end
+
+    it "is nil until every leg has a fill" do
+      order_data["legs"][1]["fills"] = []
+
+      expect(described_class.new(order).net_execution_price).to be_nil
+    end
+  end
+
+  describe "#effective_price" do
+    it "spreads fees over the filled contracts" do
+      detail = described_class.new(order, fee_calculation: fees)
+
+      expect(detail.effective_price).to eq(BigDecimal("-2.163"))
+    end
+
+    it "spreads fees per share for equities" do
+      equity = Tastytrade::Models::LiveOrder.new(
+        "legs" => [{ "instrument-type" => "Equity", "action" => "Buy to Open",
+                     "fills" => [{ "quantity" => 100, "fill-price" => "150.00" }] }]
+      )
+
+      expect(described_class.new(equity, fee_calculation: fees).effective_price).to eq(BigDecimal("-150.039"))
+    end
+
+    it "spreads fees over futures contracts with the given multiplier" do
+      future = Tastytrade::Models::LiveOrder.new(
+        "legs" => [{ "symbol" => "/ESH4", "instrument-type" => "Future", "action" => "Buy to Open",
+                     "fills" => [{ "quantity" => 2, "fill-price" => "4800.00" }] }]
+      )
+
+      detail = described_class.new(future, fee_calculation: fees, multipliers: { "/ESH4" => 50 })
+
+      expect(detail.multiplier).to eq(50)
+      expect(detail.effective_price).to eq(BigDecimal("-4800.039"))
+      expect(described_class.new(future, fee_calculation: fees).effective_price).to be_nil
+    end
+
+    it "is nil without fees" do
+      expect(described_class.new(order).effective_price).to be_nil
+    end
+  end
+end
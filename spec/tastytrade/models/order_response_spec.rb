@@ -79,6 +79,19 @@ RSpec.describe Tastytrade::Models::OrderResponse do
     end
   end
 
+  describe "#fee_calculation" do
+    it "returns a typed fee breakdown" do
+      fees = described_class.new(order_response_data).fee_calculation
+
+      expect(fees).to be_a(Tastytrade::Models::FeeCalculation)
+      expect(fees.total).to eq(BigDecimal("0.65"))
+    end
+
+    it "returns nil when fees are not reported" do
+      expect(described_class.new("id" => "1").fee_calculation).to be_nil
+    end
+  end
+
   describe "leg parsing" do
     it "parses order legs correctly" do
       response = described_class.new(order_response_data)
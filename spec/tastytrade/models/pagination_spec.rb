@@ -0,0 +1,47 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Pagination do
+  let(:pagination_data) do
+    {
+      "per-page" => 250,
+      "page-offset" => 0,
+      "item-offset" => 0,
+      "total-items" => 400,
+      "total-pages" => 2,
+      "current-item-count" => 250,
+      "previous-link" => nil,
+      "next-link" => "/accounts/5WT0001/transactions?page-offset=1"
+    }
+  end
+
+  subject(:pagination) { described_class.new(pagination_data) }
+
+  it "parses pagination attributes" do
+    expect(pagination.per_page).to eq(250)
+    expect(pagination.total_items).to eq(400)
+    expect(pagination.total_pages).to eq(2)
+    expect(pagination.current_item_count).to eq(250)
+  end
+
+  describe "#next_page?" do
+    it "is true before the last page" do
+      expect(pagination).to be_next_page
+      expect(pagination.next_page_offset).to eq(1)
+    end
+
+    it "is false on the last page" do
+      last = described_class.new(pagination_data.merge("page-offset" => 1))
+
+      expect(last).not_to be_next_page
+      expect(last.next_page_offset).to be_nil
+    end
+
+    it "falls back to the next link without a page count" do
+      without_total = described_class.new("next-link" => nil)
+
+      expect(without_total).not_to be_next_page
+    end
+  end
+end
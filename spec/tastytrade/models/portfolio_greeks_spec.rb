@@ -0,0 +1,101 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::PortfolioGreeks do
+  def position(symbol, quantity, direction, instrument_type: "Equity Option", underlying: "SPY", multiplier: 100)
+    Tastytrade::Models::CurrentPosition.new(
+      "symbol" => symbol,
+      "underlying-symbol" => underlying,
+      "instrument-type" => instrument_type,
+      "quantity" => quantity.to_s,
+      "quantity-direction" => direction,
+      "multiplier" => multiplier
+    )
+  end
+
+  let(:greeks_data) { Struct.new(:delta, :gamma, :theta, :vega) }
+
+  let(:long_call) { position("SPY 240119C00450000", 2, "Long") }
+  let(:short_put) { position("SPY 240119P00440000", 1, "Short") }
+  let(:shares) { position("SPY", 50, "Long", instrument_type: "Equity", multiplier: 1) }
+
+  let(:greeks) do
+    {
+      "SPY 240119C00450000" => greeks_data.new(BigDecimal("0.5"), BigDecimal("0.02"), BigDecimal("-0.10"),
+                                               BigDecimal("0.20")),
+      "SPY 240119P00440000" => greeks_data.new(BigDecimal("-0.3"), BigDecimal("0.01"), BigDecimal("-0.05"),
+                                               BigDecimal("0.15"))
+    }
+  end
+
+  describe ".calculate" do
+    subject(:portfolio) { described_class.calculate([long_call, short_put, shares], greeks) }
+
+    it "weights greeks by quantity and multiplier with long/short signs" do
+      # 2 * 100 * 0.5 - 1 * 100 * -0.3 + 50 shares
+      expect(portfolio.delta).to eq(BigDecimal("180"))
+      expect(portfolio.gamma).to eq(BigDecimal("3"))
+      expect(portfolio.theta).to eq(BigDecimal("-15"))
+      expect(portfolio.vega).to eq(BigDecimal("25"))
+    end
+
+    it "can exclude equity delta" do
+      options_only = described_class.calculate([long_call, short_put, shares], greeks, include_equities: false)
+
+      expect(options_only.delta).to eq(BigDecimal("130"))
+    end
+
+    it "reports option positions without greeks" do
+      unknown = position("SPY 240119C00500000", 1, "Long")
+      result = described_class.calculate([long_call, unknown], greeks)
+
+      expect(result).to be_incomplete
+      expect(result.missing_symbols).to eq(["SPY 240119C00500000"])
+      expect(result.delta).to eq(BigDecimal("100"))
+    end
+
+    it "skips closed positions" do
+      closed = position("SPY 240119C00450000", 0, "Zero")
+
+      expect(described_class.calculate([closed], greeks).delta).to eq(BigDecimal("0"))
+    end
+
+    it "groups greeks by underlying" do
+      qqq_call = position("QQQ 240119C00400000", 1, "Long", underlying: "QQQ")
+      all_greeks = greeks.merge("QQQ 240119C00400000" => greeks_data.new(1, 0, 0, 0))
+      result = described_class.calculate([long_call, qqq_call], all_greeks)
+
+      expect(result.by_underlying["SPY"][:delta]).to eq(BigDecimal("100"))
+      expect(result.by_underlying["QQQ"][:delta]).to eq(BigDecimal("100"))
+    end
+  end
+
+  describe "Account#get_portfolio_greeks" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:account) { Tastytrade::Models::Account.new("account-number" => "5WV12345") }
+
+    before do
+      allow(account).to receive(:get_positions).with(session).and_return([long_call, short_put])
+    end
+
+    it "uses a supplied greeks source" do
+      source = ->(symbols) { greeks.slice(*symbols) }
+
+      result = account.get_portfolio_greeks(session, greeks_source: source)
+
+      expect(result.delta).to eq(BigDecimal("130"))
+    end
+
+    it "falls back to option instrument data" do
+      options = greeks.map do |symbol, g|
+        instance_double(Tastytrade::Models::Option, symbol: symbol, delta: g.delta, gamma: g.gamma,
+                                                    theta: g.theta, vega: g.vega)
+      end
+      allow(Tastytrade::Models::Option).to receive(:get)
+        .with(session, ["SPY 240119C00450000", "SPY 240119P00440000"]).and_return(options)
+
+      expect(account.get_portfolio_greeks(session).delta).to eq(BigDecimal("130"))
+    end
+  end
+end
@@ -0,0 +1,118 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::Quote do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  let(:quote_data) do
+    {
+      "symbol" => "AAPL",
+      "instrument-type" => "Equity",
+      "bid" => "189.5",
+      "ask" => "189.55",
+      "mid" => "189.525",
+      "mark" => "189.525",
+      "last" => "189.52",
+      "prev-close" => "188.1",
+      "volume" => "51234567.0",
+      "updated-at" => "2024-03-15T19:59:59.000Z"
+    }
+  end
+
+  describe "#initialize" do
+    subject(:quote) { described_class.new(quote_data) }
+
+    it "parses prices as BigDecimal" do
+      expect(quote.bid).to eq(BigDecimal("189.5"))
+      expect(quote.mid).to eq(BigDecimal("189.525"))
+      expect(quote.prev_close).to eq(BigDecimal("188.1"))
+      expect(quote.updated_at).to eq(Time.utc(2024, 3, 15, 19, 59, 59))
+      expect(quote).to be_live
+    end
+  end
+
+  describe ".get_all" do
+    it "requests quotes by instrument type" do
+      allow(session).to receive(:get)
+        .with("/market-data/by-type", { "equity" => "AAPL,MSFT" })
+        .and_return("data" => { "items" => [quote_data, quote_data.merge("symbol" => "MSFT")] })
+
+      expect(described_class.get_all(session, %w[AAPL MSFT]).map(&:symbol)).to eq(%w[AAPL MSFT])
+    end
+
+    it "normalizes mixed-case symbols" do
+      allow(session).to receive(:get)
+        .with("/market-data/by-type", { "equity-option" => "SPY   240419P00500000,AAPL" })
+        .and_return("data" => { "items" => [] })
+
+      described_class.get_all(session, ["spy   240419p00500000", " aapl"], instrument_type: :option)
+
+      expect(session).to have_received(:get).once
+    end
+
+    it "rejects unknown instrument types" do
+      expect { described_class.get_all(session, ["AAPL"], instrument_type: :bond) }
+        .to raise_error(ArgumentError, /Unknown instrument type/)
+    end
+  end
+
+  describe ".get_all_resilient" do
+    let(:requests) { Queue.new }
+
+    # Fails any request containing a malformed symbol, as the API does
+    before do
+      allow(session).to receive(:get).with("/market-data/by-type", anything) do |_path, params|
+        symbols = params["equity"].split(",")
+        requests << symbols
+        raise Tastytrade::Error, "Invalid symbol" if symbols.include?("BAD!")
+
+        items = symbols.reject { |symbol| symbol == "DELISTED" }.map { |symbol| quote_data.merge("symbol" => symbol) }
+        { "data" => { "items" => items } }
+      end
+    end
+
+    it "fetches every batch" do
+      symbols = %w[A B C D E F G]
+
+      quotes, errors = described_class.get_all_resilient(session, symbols, batch_size: 3, concurrency: 2)
+
+      expect(quotes.keys).to match_array(symbols)
+      expect(errors).to be_empty
+      expect(requests.size).to eq(3)
+    end
+
+    it "keys quotes by the normalized symbol" do
+      quotes, errors = described_class.get_all_resilient(session, %w[aapl AAPL msft])
+
+      expect(quotes.keys).to match_array(%w[AAPL MSFT])
+      expect(errors).to be_empty
+    end
+
+    it "isolates a bad symbol to its own error" do
+      quotes, errors = described_class.get_all_resilient(session, %w[AAPL BAD! MSFT SPY], batch_size: 2)
+
+      expect(quotes.keys).to match_array(%w[AAPL MSFT SPY])
+      expect(errors.keys).to eq(["BAD!"])
+      expect(errors["BAD!"].message).to eq("Invalid symbol")
+    end
+
+    it "reports symbols without a quote" do
+      quotes, errors = described_class.get_all_resilient(session, %w[AAPL DELISTED])
+
+      expect(quotes.keys).to eq(["AAPL"])
+      expect(errors["DELISTED"].message).to include("No quote returned")
+    end
+
+    it "returns empty results for no symbols" do
+      expect(described_class.get_all_resilient(session, [])).to eq([{}, {}])
+    end
+
+    it "validates the batch size and concurrency" do
+      expect { described_class.get_all_resilient(session, ["AAPL"], batch_size: 0) }
+        .to raise_error(ArgumentError, /positive integers/)
+      expect { described_class.get_all_resilient(session, ["AAPL"], concurrency: nil) }
+        .to raise_error(ArgumentError, /positive integers/)
+    end
+  end
+end
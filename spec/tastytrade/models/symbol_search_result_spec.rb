@@ -0,0 +1,50 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::Models::SymbolSearchResult do
+  let(:session) { instance_double(Tastytrade::Session) }
+
+  # Captured from /symbols/search/SP
+  let(:search_response) do
+    {
+      "data" => {
+        "items" => [
+          { "symbol" => "SPY", "description" => "SPDR S&P 500 ETF TRUST", "instrument-type" => "Equity",
+            "listed-market" => "ARCX", "options" => true, "price-increments" => "0.01" },
+          { "symbol" => "SPX", "description" => "S&P 500 INDEX", "instrument-type" => "Index",
+            "listed-market" => "CBOE", "options" => true },
+          { "symbol" => "SPOT", "description" => "SPOTIFY TECHNOLOGY S.A.", "instrument-type" => "Equity",
+            "listed-market" => "XNYS", "options" => false }
+        ]
+      }
+    }
+  end
+
+  describe ".search" do
+    it "returns mixed equity and index matches in order" do
+      allow(session).to receive(:get).with("/symbols/search/SP").and_return(search_response)
+
+      results = described_class.search(session, "SP")
+
+      expect(results.map(&:symbol)).to eq(%w[SPY SPX SPOT])
+      expect(results[0]).to be_equity
+      expect(results[0].listed_market).to eq("ARCX")
+      expect(results[1]).to be_index
+      expect(results[1].description).to eq("S&P 500 INDEX")
+      expect(results[2]).not_to be_options
+    end
+
+    it "encodes the query" do
+      allow(session).to receive(:get).with("/symbols/search/S%26P%20500").and_return("data" => { "items" => [] })
+
+      expect(described_class.search(session, " S&P 500 ")).to eq([])
+    end
+
+    it "returns no results for a blank query without a request" do
+      expect(session).not_to receive(:get)
+
+      expect(described_class.search(session, "  ")).to eq([])
+    end
+  end
+end
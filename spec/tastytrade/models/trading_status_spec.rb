@@ -47,6 +47,24 @@ RSpec.describe Tastytrade::Models::TradingStatus do
 
   subject(:trading_status) { described_class.new(trading_status_data) }
 
+  describe ".get" do
+    let(:session) { instance_double(Tastytrade::Session) }
+
+    it "fetches the trading status by account number" do
+      allow(session).to receive(:get).with("/accounts/5WT0001/trading-status/")
+                                     .and_return("data" => trading_status_data.merge("is-closing-only" => true,
+                                                                                     "day-trade-count" => 2))
+
+      status = described_class.get(session, "5WT0001")
+
+      expect(status.account_number).to eq("5WT0001")
+      expect(status.is_closing_only).to be true
+      expect(status.day_trade_count).to eq(2)
+      expect(status.options_level).to eq("Level 2")
+      expect(status).to be_restricted
+    end
+  end
+
   describe "#initialize" do
     it "parses all required fields correctly" do
       expect(trading_status.account_number).to eq("5WT0001")
@@ -158,6 +158,58 @@ RSpec.describe Tastytrade::Models::Transaction do
 
       expect(transactions.length).to eq(1)
     end
+
+    it "stops on the last page reported by pagination" do
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/transactions", {})
+        .and_return(response_data.merge("pagination" => { "page-offset" => 0, "total-pages" => 1 }))
+
+      transactions = described_class.get_all(session, account_number)
+
+      expect(transactions.length).to eq(1)
+      expect(session).to have_received(:get).once
+    end
+
+    it "fetches more than 250 transactions when pages remain" do
+      full_page = { "data" => { "items" => Array.new(250) { |i| transaction_data.merge("id" => i) } },
+                    "pagination" => { "page-offset" => 0, "total-pages" => 2 } }
+      last_page = { "data" => { "items" => [transaction_data] },
+                    "pagination" => { "page-offset" => 1, "total-pages" => 2 } }
+
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/transactions", {}).and_return(full_page)
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/transactions", { "page-offset" => 1 }).and_return(last_page)
+
+      expect(described_class.get_all(session, account_number).length).to eq(251)
+    end
+  end
+
+  describe ".get_page" do
+    it "returns transactions with pagination metadata" do
+      allow(session).to receive(:get)
+        .with("/accounts/#{account_number}/transactions", { "per-page" => 1, "page-offset" => 1 })
+        .and_return(
+          "data" => { "items" => [transaction_data] },
+          "pagination" => { "per-page" => 1, "page-offset" => 1, "total-items" => 3, "total-pages" => 3 }
+        )
+
+      transactions, pagination = described_class.get_page(session, account_number, per_page: 1, page_offset: 1)
+
+      expect(transactions.map(&:id)).to eq([252640963])
+      expect(pagination.total_items).to eq(3)
+      expect(pagination).to be_next_page
+      expect(pagination.next_page_offset).to eq(2)
+    end
+
+    it "returns nil pagination when the response has none" do
+      allow(session).to receive(:get).and_return("data" => { "items" => [] })
+
+      transactions, pagination = described_class.get_page(session, account_number)
+
+      expect(transactions).to eq([])
+      expect(pagination).to be_nil
+    end
   end
 
   describe "attribute parsing" do
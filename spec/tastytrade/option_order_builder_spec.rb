@@ -430,6 +430,97 @@ RSpec.describe Tastytrade::OptionOrderBuilder do
     end
   end
 
+  describe "#multi_leg" do
+    let(:iron_condor_legs) do
+      [
+        { symbol: "SPY   240119P00460000", quantity: 1, action: Tastytrade::OrderAction::BUY_TO_OPEN },
+        { symbol: "SPY   240119P00465000", quantity: 1, action: Tastytrade::OrderAction::SELL_TO_OPEN },
+        { symbol: "SPY   240119C00485000", quantity: 1, action: Tastytrade::OrderAction::SELL_TO_OPEN },
+        { symbol: "SPY   240119C00490000", quantity: 1, action: Tastytrade::OrderAction::BUY_TO_OPEN }
+      ]
+    end
+
+    it "serializes a four-leg iron condor" do
+      order = builder.multi_leg(
+        iron_condor_legs,
+        price: BigDecimal("1.85"),
+        price_effect: Tastytrade::PriceEffect::CREDIT
+      )
+
+      expect(order.to_api_params).to eq(
+        "order-type" => "Limit",
+        "time-in-force" => "Day",
+        "legs" => [
+          { "action" => "Buy to Open", "symbol" => "SPY 240119P00460000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Sell to Open", "symbol" => "SPY 240119P00465000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Sell to Open", "symbol" => "SPY 240119C00485000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Buy to Open", "symbol" => "SPY 240119C00490000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" }
+        ],
+        "price" => "1.85",
+        "price-effect" => "Credit"
+      )
+    end
+
+    it "accepts OptionLeg structs and ratio quantities" do
+      legs = [
+        described_class::OptionLeg.new(symbol: "AAPL  240119C00150000", quantity: 1,
+                                       action: Tastytrade::OrderAction::BUY_TO_OPEN),
+        described_class::OptionLeg.new(symbol: "AAPL  240119C00160000", quantity: 2,
+                                       action: Tastytrade::OrderAction::SELL_TO_OPEN)
+      ]
+
+      order = builder.multi_leg(legs)
+
+      expect(order.type).to eq(Tastytrade::OrderType::MARKET)
+      expect(order.legs.map(&:quantity)).to eq([1, 2])
+    end
+
+    it "allows an equity leg on the same underlying" do
+      order = builder.multi_leg([
+        { symbol: "AAPL", quantity: 100, action: Tastytrade::OrderAction::BUY_TO_OPEN, instrument_type: "Equity" },
+        { symbol: "AAPL  240119C00160000", quantity: 1, action: Tastytrade::OrderAction::SELL_TO_OPEN }
+      ])
+
+      expect(order.legs.map(&:instrument_type)).to eq(%w[Equity Option])
+    end
+
+    it "rejects legs on different underlyings" do
+      legs = iron_condor_legs.dup
+      legs[0] = legs[0].merge(symbol: "QQQ   240119P00380000")
+
+      expect { builder.multi_leg(legs) }
+        .to raise_error(Tastytrade::OptionOrderBuilder::InvalidStrategyError, /same underlying/)
+    end
+
+    it "rejects buying and selling the same contract" do
+      legs = [
+        { symbol: "SPY   240119P00460000", quantity: 1, action: Tastytrade::OrderAction::BUY_TO_OPEN },
+        { symbol: "SPY 240119P00460000", quantity: 1, action: Tastytrade::OrderAction::SELL_TO_OPEN }
+      ]
+
+      expect { builder.multi_leg(legs) }
+        .to raise_error(Tastytrade::OptionOrderBuilder::InvalidStrategyError, /buy and sell the same contract/)
+    end
+
+    it "rejects more than four legs" do
+      extra = { symbol: "SPY   240119C00495000", quantity: 1, action: Tastytrade::OrderAction::SELL_TO_OPEN }
+
+      expect { builder.multi_leg(iron_condor_legs + [extra]) }
+        .to raise_error(Tastytrade::OptionOrderBuilder::InvalidStrategyError, /at most 4 legs/)
+    end
+
+    it "rejects invalid OCC symbols" do
+      legs = [{ symbol: "SPY PUT", quantity: 1, action: Tastytrade::OrderAction::BUY_TO_OPEN }]
+
+      expect { builder.multi_leg(legs) }
+        .to raise_error(Tastytrade::OptionOrderBuilder::InvalidStrategyError, /Invalid OCC option symbol/)
+    end
+  end
+
   describe "#calculate_net_premium" do
     it "calculates net debit for buying options" do
       order = instance_double(
@@ -0,0 +1,80 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::OptionSymbol do
+  describe ".parse" do
+    {
+      "AAPL  230721C00190000" => ["AAPL", Date.new(2023, 7, 21), "C", "190"],
+      "SPY   250811P00400000" => ["SPY", Date.new(2025, 8, 11), "P", "400"],
+      "F     240119C00012500" => ["F", Date.new(2024, 1, 19), "C", "12.5"],
+      "SPXW  240315P04525000" => ["SPXW", Date.new(2024, 3, 15), "P", "4525"],
+      "GOOGL1240621C00150000" => ["GOOGL1", Date.new(2024, 6, 21), "C", "150"],
+      "BRK.B 240315C00400250" => ["BRK.B", Date.new(2024, 3, 15), "C", "400.25"],
+      "AAPL 240315C00150000" => ["AAPL", Date.new(2024, 3, 15), "C", "150"],
+      "QQQ240315C00000500" => ["QQQ", Date.new(2024, 3, 15), "C", "0.5"]
+    }.each do |symbol, (underlying, expiration, option_type, strike)|
+      it "parses #{symbol.inspect}" do
+        parsed = described_class.parse(symbol)
+
+        expect(parsed.underlying).to eq(underlying)
+        expect(parsed.expiration).to eq(expiration)
+        expect(parsed.option_type).to eq(option_type)
+        expect(parsed.strike_price).to eq(BigDecimal(strike))
+      end
+    end
+
+    it "identifies calls and puts" do
+      expect(described_class.parse("SPY   250811C00400000")).to be_call
+      expect(described_class.parse("SPY   250811P00400000")).to be_put
+    end
+
+    ["", "AAPL", "AAPL  230721X00190000", "AAPL  230721C0019000", "TOOLONG230721C00190000",
+     "aapl  230721C00190000", "AAPL  231341C00190000", nil].each do |symbol|
+      it "rejects #{symbol.inspect}" do
+        expect { described_class.parse(symbol) }.to raise_error(ArgumentError)
+        expect(described_class.valid?(symbol)).to be false
+      end
+    end
+  end
+
+  describe ".format" do
+    it "pads the root to six characters and the strike to eight digits" do
+      symbol = described_class.format(underlying: "F", expiration: Date.new(2024, 1, 19), option_type: "C",
+                                      strike_price: BigDecimal("12.5"))
+
+      expect(symbol).to eq("F     240119C00012500")
+    end
+
+    it "accepts Call and Put option types" do
+      symbol = described_class.format(underlying: "SPY", expiration: Date.new(2025, 8, 11), option_type: "Put",
+                                      strike_price: 400)
+
+      expect(symbol).to eq("SPY   250811P00400000")
+    end
+
+    it "round-trips parsed symbols" do
+      ["AAPL  230721C00190000", "BRK.B 240315C00400250", "GOOGL1240621C00150000"].each do |symbol|
+        expect(described_class.parse(symbol).to_s).to eq(symbol)
+      end
+    end
+
+    it "rejects strikes finer than a tenth of a cent" do
+      expect {
+        described_class.format(underlying: "SPY", expiration: Date.new(2025, 8, 11), option_type: "C",
+                               strike_price: "400.0001")
+      }.to raise_error(ArgumentError, /Strike price/)
+    end
+
+    it "rejects roots longer than six characters" do
+      expect {
+        described_class.format(underlying: "TOOLONG", expiration: Date.new(2025, 8, 11), option_type: "C",
+                               strike_price: 1)
+      }.to raise_error(ArgumentError, /Underlying/)
+    end
+  end
+
+  it "compares by components" do
+    expect(described_class.parse("SPY 250811C00400000")).to eq(described_class.parse("SPY   250811C00400000"))
+  end
+end
@@ -0,0 +1,206 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+require "tastytrade/option_order_builder"
+
+RSpec.describe Tastytrade::OrderDefaults do
+  describe "#initialize" do
+    it "creates empty defaults" do
+      defaults = described_class.new
+
+      expect(defaults.time_in_force).to be_nil
+      expect(defaults.account_number).to be_nil
+      expect(defaults).to be_empty
+    end
+
+    it "accepts a valid time in force and account number" do
+      defaults = described_class.new(time_in_force: Tastytrade::OrderTimeInForce::GTC, account_number: "5WV12345")
+
+      expect(defaults.time_in_force).to eq("GTC")
+      expect(defaults.account_number).to eq("5WV12345")
+      expect(defaults).not_to be_empty
+    end
+
+    it "rejects an invalid time in force" do
+      expect { described_class.new(time_in_force: "Forever") }
+        .to raise_error(ArgumentError, /Invalid default time in force/)
+    end
+
+    it "rejects an invalid account number" do
+      expect { described_class.new(account_number: "not an account") }
+        .to raise_error(ArgumentError, /Invalid default account number/)
+    end
+
+    it "rejects a blank source" do
+      expect { described_class.new(source: " ") }
+        .to raise_error(ArgumentError, /Invalid default source/)
+    end
+
+    it "is frozen" do
+      expect(described_class.new).to be_frozen
+    end
+  end
+
+  describe "#resolve_time_in_force" do
+    it "prefers an explicit override" do
+      defaults = described_class.new(time_in_force: "GTC")
+
+      expect(defaults.resolve_time_in_force("Day")).to eq("Day")
+    end
+
+    it "falls back to the configured default" do
+      defaults = described_class.new(time_in_force: "GTC")
+
+      expect(defaults.resolve_time_in_force).to eq("GTC")
+    end
+
+    it "falls back to Day when nothing is configured" do
+      expect(described_class.new.resolve_time_in_force).to eq("Day")
+    end
+  end
+
+  describe "#resolve_account_number" do
+    it "prefers an explicit override" do
+      defaults = described_class.new(account_number: "5WV12345")
+
+      expect(defaults.resolve_account_number("5WV99999")).to eq("5WV99999")
+    end
+
+    it "falls back to the configured default" do
+      defaults = described_class.new(account_number: "5WV12345")
+
+      expect(defaults.resolve_account_number).to eq("5WV12345")
+    end
+
+    it "raises when no account number is available" do
+      expect { described_class.new.resolve_account_number }
+        .to raise_error(ArgumentError, /No account number given/)
+    end
+  end
+
+  describe "#resolve_source" do
+    it "prefers the order's own source" do
+      expect(described_class.new(source: "my-app").resolve_source("rebalancer")).to eq("rebalancer")
+    end
+
+    it "falls back to the configured default only when unset" do
+      expect(described_class.new(source: "my-app").resolve_source).to eq("my-app")
+      expect(described_class.new.resolve_source).to be_nil
+    end
+  end
+
+  describe "session integration" do
+    before do
+      allow(Tastytrade::Client).to receive(:new).and_return(instance_double(Tastytrade::Client))
+    end
+
+    let(:session) { Tastytrade::Session.new(username: "testuser", password: "testpass") }
+
+    it "starts with empty defaults" do
+      expect(session.order_defaults).to be_a(described_class)
+      expect(session.order_defaults).to be_empty
+    end
+
+    it "sets defaults with with_order_defaults" do
+      result = session.with_order_defaults(time_in_force: "GTC", account_number: "5WV12345")
+
+      expect(result).to eq(session)
+      expect(session.order_defaults.time_in_force).to eq("GTC")
+      expect(session.order_defaults.account_number).to eq("5WV12345")
+    end
+
+    it "accepts defaults as a hash in the constructor" do
+      configured = Tastytrade::Session.new(username: "testuser", password: "testpass",
+                                           order_defaults: { time_in_force: "GTC" })
+
+      expect(configured.order_defaults.time_in_force).to eq("GTC")
+    end
+
+    it "validates defaults at set time" do
+      expect { session.with_order_defaults(time_in_force: "Forever") }.to raise_error(ArgumentError)
+      expect { session.order_defaults = "GTC" }.to raise_error(ArgumentError, /must be an OrderDefaults or Hash/)
+    end
+  end
+
+  describe "builder integration" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:builder) { Tastytrade::OptionOrderBuilder.new(session) }
+    let(:option) do
+      instance_double(
+        Tastytrade::Models::Option,
+        symbol: "AAPL 240119C00150000",
+        option_type: "C",
+        expired?: false
+      )
+    end
+
+    before do
+      allow(session).to receive(:order_defaults)
+        .and_return(described_class.new(time_in_force: "GTC", account_number: "5WV12345"))
+    end
+
+    it "uses the default time in force when none is given" do
+      order = builder.buy_call(option, 1, price: BigDecimal("2.50"))
+
+      expect(order.time_in_force).to eq("GTC")
+    end
+
+    it "allows a per-call override" do
+      order = builder.buy_call(option, 1, price: BigDecimal("2.50"), time_in_force: "Day")
+
+      expect(order.time_in_force).to eq("Day")
+    end
+
+    it "loads the default account when none is given" do
+      account = instance_double(Tastytrade::Models::Account)
+      allow(Tastytrade::Models::Account).to receive(:get).with(session, "5WV12345").and_return(account)
+
+      expect(builder.account).to eq(account)
+    end
+  end
+  describe "account integration" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:account) { Tastytrade::Models::Account.new("account-number" => "5WV12345") }
+    let(:position) do
+      Tastytrade::Models::CurrentPosition.new(
+        "symbol" => "AAPL", "instrument-type" => "Equity", "quantity" => "25", "quantity-direction" => "Long"
+      )
+    end
+
+    before do
+      allow(session).to receive(:order_defaults)
+        .and_return(described_class.new(time_in_force: "GTC", account_number: "5WV12345"))
+      allow(session).to receive(:post).and_return("data" => { "id" => "123456", "status" => "Routed" })
+    end
+
+    it "fetches the default account when no account number is given" do
+      allow(session).to receive(:get).with("/accounts/5WV12345/")
+                                     .and_return("data" => { "account-number" => "5WV12345" })
+
+      expect(Tastytrade::Models::Account.get(session).account_number).to eq("5WV12345")
+    end
+
+    it "requires an account number without a default" do
+      allow(session).to receive(:order_defaults).and_return(described_class.new)
+
+      expect { Tastytrade::Models::Account.get(session) }.to raise_error(ArgumentError, /No account number given/)
+    end
+
+    it "uses the default time in force in the order helpers" do
+      account.place_equity_market_order(session, "AAPL", 10, Tastytrade::OrderAction::BUY_TO_OPEN,
+                                        skip_validation: true)
+      account.place_equity_notional_market_order(session, "AAPL", 250, Tastytrade::OrderAction::BUY_TO_OPEN,
+                                                 skip_validation: true)
+      account.close_position(session, position, price: "190.5", skip_validation: true)
+
+      expect(session).to have_received(:post)
+        .with("/accounts/5WV12345/orders", hash_including("time-in-force" => "GTC")).exactly(3).times
+    end
+
+    it "lets an explicit time in force override the default" do
+      account.close_position(session, position, price: "190.5", time_in_force: "Day", skip_validation: true)
+
+      expect(session).to have_received(:post).with(anything, hash_including("time-in-force" => "Day"))
+    end
+  end
+end
@@ -214,6 +214,56 @@ RSpec.describe Tastytrade::Order do
       end.to raise_error(ArgumentError, /Invalid time in force/)
     end
 
+    it "accepts extended hours time in force" do
+      order = described_class.new(
+        type: Tastytrade::OrderType::LIMIT,
+        time_in_force: Tastytrade::OrderTimeInForce::EXT,
+        legs: leg,
+        price: 150.50
+      )
+
+      expect(order.time_in_force).to eq("Ext")
+    end
+
+    context "with GTD time in force" do
+      let(:tomorrow) { Date.today + 1 }
+
+      def gtd_order(gtc_date)
+        described_class.new(type: Tastytrade::OrderType::LIMIT, time_in_force: Tastytrade::OrderTimeInForce::GTD,
+                            legs: leg, price: 150.50, gtc_date: gtc_date)
+      end
+
+      it "accepts a future date as a Date or YYYY-MM-DD string" do
+        expect(gtd_order(tomorrow).gtc_date).to eq(tomorrow)
+        expect(gtd_order(tomorrow.strftime("%Y-%m-%d")).gtc_date).to eq(tomorrow)
+      end
+
+      it "requires a GTC date" do
+        expect { gtd_order(nil) }.to raise_error(ArgumentError, /required for GTD/)
+      end
+
+      it "rejects dates that are not in the future" do
+        expect { gtd_order(Date.today) }.to raise_error(ArgumentError, /must be in the future/)
+      end
+
+      it "rejects malformed dates" do
+        expect { gtd_order("12/31/2030") }.to raise_error(ArgumentError, /YYYY-MM-DD/)
+        expect { gtd_order("2030-02-30") }.to raise_error(ArgumentError, /YYYY-MM-DD/)
+      end
+
+      it "rejects a GTC date on other time in force values" do
+        expect do
+          described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, gtc_date: tomorrow)
+        end.to raise_error(ArgumentError, /only allowed for GTD/)
+      end
+
+      it "includes the date in the API params only for GTD orders" do
+        expect(gtd_order(tomorrow).to_api_params["gtc-date"]).to eq(tomorrow.strftime("%Y-%m-%d"))
+        expect(described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg).to_api_params)
+          .not_to have_key("gtc-date")
+      end
+    end
+
     it "requires price for limit orders" do
       expect do
         described_class.new(
@@ -317,4 +367,343 @@ RSpec.describe Tastytrade::Order do
       expect(params["price-effect"]).to eq("Credit")
     end
   end
+
+  describe "multi-leg orders" do
+    def option_leg(action, symbol)
+      Tastytrade::OrderLeg.new(action: action, symbol: symbol, quantity: 1, instrument_type: "Option")
+    end
+
+    let(:iron_condor_legs) do
+      [
+        option_leg(Tastytrade::OrderAction::BUY_TO_OPEN, "SPY 240119P00440000"),
+        option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, "SPY 240119P00450000"),
+        option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, "SPY 240119C00480000"),
+        option_leg(Tastytrade::OrderAction::BUY_TO_OPEN, "SPY 240119C00490000")
+      ]
+    end
+
+    it "serializes a four-leg iron condor with a net credit" do
+      order = described_class.new(
+        type: Tastytrade::OrderType::LIMIT,
+        legs: iron_condor_legs,
+        price: BigDecimal("2.35"),
+        price_effect: Tastytrade::PriceEffect::CREDIT
+      )
+
+      expect(order.to_api_params).to eq(
+        "order-type" => "Limit",
+        "time-in-force" => "Day",
+        "price" => "2.35",
+        "price-effect" => "Credit",
+        "legs" => [
+          { "action" => "Buy to Open", "symbol" => "SPY 240119P00440000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Sell to Open", "symbol" => "SPY 240119P00450000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Sell to Open", "symbol" => "SPY 240119C00480000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" },
+          { "action" => "Buy to Open", "symbol" => "SPY 240119C00490000", "quantity" => 1,
+            "instrument-type" => "Option", "position-effect" => "Opening" }
+        ]
+      )
+    end
+
+    it "keeps the net price positive regardless of leg direction" do
+      order = described_class.new(
+        type: Tastytrade::OrderType::LIMIT,
+        legs: iron_condor_legs,
+        price: BigDecimal("2.35"),
+        price_effect: Tastytrade::PriceEffect::CREDIT
+      )
+
+      expect(order.to_api_params["price"]).not_to start_with("-")
+      expect(order).to be_credit
+    end
+
+    it "infers the price effect from the first leg when not given" do
+      order = described_class.new(type: Tastytrade::OrderType::LIMIT, legs: iron_condor_legs, price: 2.35)
+
+      expect(order.price_effect).to eq("Debit")
+      expect(order).to be_debit
+    end
+
+    it "does not send price or price-effect for market orders" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: iron_condor_legs,
+                                  price_effect: Tastytrade::PriceEffect::CREDIT)
+
+      params = order.to_api_params
+      expect(params).not_to have_key("price")
+      expect(params).not_to have_key("price-effect")
+    end
+
+    it "validates the price effect" do
+      expect do
+        described_class.new(type: Tastytrade::OrderType::LIMIT, legs: iron_condor_legs, price: 2.35,
+                            price_effect: "Even")
+      end.to raise_error(ArgumentError, /Invalid price effect/)
+    end
+
+    context "when checking the price effect against the legs" do
+      let(:long_put) { option_leg(Tastytrade::OrderAction::BUY_TO_OPEN, "SPY 240119P00440000") }
+      let(:short_put) { option_leg(Tastytrade::OrderAction::SELL_TO_OPEN, "SPY 240119P00450000") }
+
+      def limit_order(legs, price_effect: nil)
+        described_class.new(type: Tastytrade::OrderType::LIMIT, legs: legs, price: 1.5, price_effect: price_effect)
+      end
+
+      it "accepts a credit spread listed long leg first" do
+        order = limit_order([long_put, short_put], price_effect: Tastytrade::PriceEffect::CREDIT)
+
+        expect(order.validation_errors).to be_empty
+        expect(order.validation_warnings).to be_empty
+      end
+
+      it "accepts a debit spread" do
+        order = limit_order([long_put, short_put], price_effect: Tastytrade::PriceEffect::DEBIT)
+
+        expect(order.validation_errors).to be_empty
+        expect(order.validation_warnings).to be_empty
+      end
+
+      it "rejects a credit on an order that only buys" do
+        order = limit_order([long_put], price_effect: Tastytrade::PriceEffect::CREDIT)
+
+        expect(order.validation_errors).to eq(["Credit price effect on an order that only buys"])
+        expect { order.validate_structure! }.to raise_error(Tastytrade::OrderValidationError)
+      end
+
+      it "rejects a debit on an order that only sells" do
+        order = limit_order([short_put], price_effect: Tastytrade::PriceEffect::DEBIT)
+
+        expect(order.price_effect_errors).to eq(["Debit price effect on an order that only sells"])
+      end
+
+      it "warns when the effect of a spread is inferred from its first leg" do
+        order = limit_order([long_put, short_put])
+
+        expect(order.validation_errors).to be_empty
+        expect(order.validation_warnings).to eq([
+          "Price effect Debit was inferred from the first leg of an order that both buys and sells; " \
+          "pass price_effect to confirm it"
+        ])
+      end
+
+      it "does not check market orders" do
+        order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: [long_put],
+                                    price_effect: Tastytrade::PriceEffect::CREDIT)
+
+        expect(order.price_effect_errors).to be_empty
+      end
+    end
+  end
+
+  describe "stop orders" do
+    it "sends the stop trigger" do
+      order = described_class.new(type: Tastytrade::OrderType::STOP, legs: leg, stop_trigger: "148.50")
+
+      expect(order.stop_trigger).to eq(BigDecimal("148.50"))
+      expect(order.to_api_params["stop-trigger"]).to eq("148.5")
+    end
+  end
+
+  describe "trailing stop orders" do
+    let(:sell_leg) do
+      Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::SELL_TO_CLOSE, symbol: "AAPL", quantity: 100)
+    end
+
+    it "sends the trailing value and value type" do
+      order = described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg,
+                                  time_in_force: Tastytrade::OrderTimeInForce::GTC, trailing_value: 5,
+                                  trailing_value_type: Tastytrade::TrailingValueType::PERCENTAGE)
+
+      expect(order).to be_trailing_stop
+      expect(order.to_api_params).to eq(
+        "order-type" => "Trailing Stop",
+        "time-in-force" => "GTC",
+        "legs" => [
+          { "action" => "Sell to Close", "symbol" => "AAPL", "quantity" => 100, "instrument-type" => "Equity" }
+        ],
+        "trailing-stop" => { "value" => "5.0", "value-type" => "percentage" }
+      )
+    end
+
+    it "accepts a trailing amount" do
+      order = described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg, trailing_value: "2.50",
+                                  trailing_value_type: Tastytrade::TrailingValueType::AMOUNT)
+
+      expect(order.to_api_params["trailing-stop"]).to eq("value" => "2.5", "value-type" => "amount")
+    end
+
+    it "requires a positive trailing value" do
+      expect do
+        described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg, trailing_value: 0,
+                            trailing_value_type: "amount")
+      end.to raise_error(ArgumentError, "Trailing value must be greater than 0")
+      expect do
+        described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg, trailing_value_type: "amount")
+      end.to raise_error(ArgumentError, "Trailing stop orders require a trailing value")
+    end
+
+    it "validates the value type" do
+      expect do
+        described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg, trailing_value: 5,
+                            trailing_value_type: "points")
+      end.to raise_error(ArgumentError, /Invalid trailing value type: "points"/)
+    end
+
+    it "rejects percentages of 100 or more" do
+      expect do
+        described_class.new(type: Tastytrade::OrderType::TRAILING_STOP, legs: sell_leg, trailing_value: 100,
+                            trailing_value_type: "percentage")
+      end.to raise_error(ArgumentError, "Trailing percentage must be less than 100")
+    end
+
+    it "rejects trailing values on other order types" do
+      expect do
+        described_class.new(type: Tastytrade::OrderType::STOP, legs: sell_leg, stop_trigger: 140, trailing_value: 5)
+      end.to raise_error(ArgumentError, "Trailing values are only allowed for trailing stop orders")
+    end
+  end
+
+  describe "client order IDs" do
+    let(:leg) { Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: 1) }
+
+    it "sends the client order ID" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, client_order_id: "abc-123")
+
+      expect(order.client_order_id).to eq("abc-123")
+      expect(order.to_api_params["ext-client-order-id"]).to eq("abc-123")
+    end
+
+    it "omits it when not set" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg)
+
+      expect(order.to_api_params).not_to have_key("ext-client-order-id")
+    end
+
+    it "rejects a blank ID" do
+      expect { described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, client_order_id: " ") }
+        .to raise_error(ArgumentError, "Client order ID must be a non-empty string")
+    end
+  end
+
+  describe "source" do
+    let(:leg) { Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: 10) }
+
+    it "sends the source tag" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, source: "my-app")
+
+      expect(order.to_api_params["source"]).to eq("my-app")
+    end
+
+    it "omits the source when unset" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg)
+
+      expect(order.to_api_params).not_to have_key("source")
+    end
+
+    it "rejects a blank source" do
+      expect { described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, source: "") }
+        .to raise_error(ArgumentError, "Source must be a non-empty string")
+    end
+  end
+
+  describe "notional orders" do
+    let(:leg) do
+      Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: nil)
+    end
+
+    it "sends the value and omits leg quantity" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, value: 100)
+      params = order.to_api_params
+
+      expect(order).to be_notional
+      expect(params).to include("value" => "100.0", "value-effect" => "Debit")
+      expect(params["legs"].first).not_to have_key("quantity")
+      expect(order.validation_errors).to be_empty
+    end
+
+    it "accepts an explicit value effect" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, value: 100,
+                                  value_effect: Tastytrade::PriceEffect::CREDIT)
+
+      expect(order.value_effect).to eq("Credit")
+    end
+
+    it "has no value effect for quantity orders" do
+      order = described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg)
+
+      expect(order).not_to be_notional
+      expect(order.value_effect).to be_nil
+      expect(order.to_api_params).not_to have_key("value")
+    end
+
+    it "rejects a value on non-market orders" do
+      expect {
+        described_class.new(type: Tastytrade::OrderType::LIMIT, legs: leg, price: 10, value: 100)
+      }.to raise_error(ArgumentError, /only allowed for market orders/)
+    end
+
+    it "rejects a non-positive value" do
+      expect {
+        described_class.new(type: Tastytrade::OrderType::MARKET, legs: leg, value: 0)
+      }.to raise_error(ArgumentError, /Value must be greater than 0/)
+    end
+  end
+
+  describe "#validation_errors" do
+    def build_leg(**overrides)
+      instance_double(Tastytrade::OrderLeg, { action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL",
+                                              quantity: 100, instrument_type: "Equity" }.merge(overrides))
+    end
+
+    it "is empty for a well-formed order" do
+      order = described_class.new(type: Tastytrade::OrderType::LIMIT, legs: leg, price: 150)
+
+      expect(order.validation_errors).to eq([])
+      expect(order).to be_valid
+      expect(order.validate_structure!).to be true
+    end
+
+    [
+      ["an order without legs", { legs: [] }, "Order must have at least one leg"],
+      ["a leg without a symbol", { legs: [:symbol, ""] }, "Leg 1: symbol is required"],
+      ["a leg with zero quantity", { legs: [:quantity, 0] }, "Leg 1: quantity must be greater than 0"],
+      ["a leg with negative quantity", { legs: [:quantity, -5] }, "Leg 1: quantity must be greater than 0"],
+      ["a leg with an unknown action", { legs: [:action, "Buy"] }, 'Leg 1: invalid action "Buy"'],
+      ["a leg with an unknown instrument type", { legs: [:instrument_type, "Bond"] },
+       'Leg 1: invalid instrument type "Bond"'],
+      ["a stop order without a trigger", { type: Tastytrade::OrderType::STOP }, "Stop orders require a stop trigger"],
+      ["a negative stop trigger", { type: Tastytrade::OrderType::STOP, stop_trigger: -1 },
+       "Stop trigger must be greater than 0"]
+    ].each do |description, overrides, message|
+      it "reports #{description}" do
+        legs = if overrides[:legs] == []
+          []
+        elsif overrides[:legs]
+          [build_leg(overrides[:legs][0] => overrides[:legs][1])]
+        else
+          [leg]
+        end
+        params = { type: Tastytrade::OrderType::MARKET }.merge(overrides.except(:legs)).merge(legs: legs)
+
+        expect(described_class.new(**params).validation_errors).to include(message)
+      end
+    end
+
+    it "reports every problem at once" do
+      bad_legs = [build_leg(symbol: nil), build_leg(quantity: 0, instrument_type: "Bond")]
+      order = described_class.new(type: Tastytrade::OrderType::STOP, legs: bad_legs)
+
+      expect(order.validation_errors).to eq([
+        "Leg 1: symbol is required",
+        "Leg 2: quantity must be greater than 0",
+        'Leg 2: invalid instrument type "Bond"',
+        "Stop orders require a stop trigger"
+      ])
+      expect { order.validate_structure! }.to raise_error(Tastytrade::OrderValidationError) { |error|
+        expect(error.errors.size).to eq(4)
+      }
+    end
+  end
 end
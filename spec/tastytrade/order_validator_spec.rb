@@ -27,6 +27,7 @@ RSpec.describe Tastytrade::OrderValidator do
       allow(order).to receive(:market?).and_return(false)
       allow(order).to receive(:price).and_return(BigDecimal("150.00"))
       allow(order).to receive(:time_in_force).and_return(Tastytrade::OrderTimeInForce::DAY)
+      allow(order).to receive_messages(price_effect_errors: [], validation_errors: [], validation_warnings: [])
       allow(account).to receive(:get_trading_status).and_return(trading_status)
       allow(trading_status).to receive(:restricted?).and_return(false)
       allow(trading_status).to receive(:is_closing_only).and_return(false)
@@ -120,6 +121,51 @@ RSpec.describe Tastytrade::OrderValidator do
       end
     end
 
+    context "with structural problems in the order" do
+      before do
+        allow(order).to receive(:validation_errors)
+          .and_return(["Leg 1: quantity must be greater than 0", "Stop orders require a stop trigger"])
+        allow(Tastytrade::Instruments::Equity).to receive(:get).and_return(
+          instance_double(Tastytrade::Instruments::Equity, symbol: "AAPL")
+        )
+      end
+
+      it "reports every problem" do
+        expect { validator.validate!(skip_dry_run: true) }.to raise_error(Tastytrade::OrderValidationError) { |error|
+          expect(error.errors)
+            .to include("Leg 1: quantity must be greater than 0", "Stop orders require a stop trigger")
+        }
+      end
+    end
+
+    context "with a contradictory price effect" do
+      before do
+        allow(order).to receive(:price_effect_errors).and_return(["Credit price effect on an order that only buys"])
+        allow(Tastytrade::Instruments::Equity).to receive(:get).and_return(
+          instance_double(Tastytrade::Instruments::Equity, symbol: "AAPL")
+        )
+      end
+
+      it "raises OrderValidationError" do
+        expect { validator.validate!(skip_dry_run: true) }
+          .to raise_error(Tastytrade::OrderValidationError, /only buys/)
+      end
+    end
+
+    context "with an inferred price effect on mixed legs" do
+      before do
+        allow(order).to receive(:validation_warnings).and_return(["Price effect Debit was inferred"])
+        allow(Tastytrade::Instruments::Equity).to receive(:get).and_return(
+          instance_double(Tastytrade::Instruments::Equity, symbol: "AAPL")
+        )
+      end
+
+      it "passes with a warning" do
+        expect(validator.validate!(skip_dry_run: true)).to be true
+        expect(validator.warnings).to include("Price effect Debit was inferred")
+      end
+    end
+
     context "with account restrictions" do
       before do
         allow(Tastytrade::Instruments::Equity).to receive(:get).and_return(
@@ -313,6 +359,76 @@ RSpec.describe Tastytrade::OrderValidator do
         end
       end
     end
+
+    context "with cryptocurrency order" do
+      let(:crypto_leg) do
+        instance_double(
+          Tastytrade::OrderLeg,
+          symbol: "BTC/USD",
+          quantity: BigDecimal("0.05"),
+          action: Tastytrade::OrderAction::BUY_TO_OPEN,
+          instrument_type: "Cryptocurrency"
+        )
+      end
+
+      before do
+        allow(order).to receive(:legs).and_return([crypto_leg])
+        allow(trading_status).to receive(:can_trade_cryptocurrency?).and_return(true)
+        allow(Tastytrade::Instruments::Cryptocurrency).to receive(:get).with(session, "BTC/USD").and_return(
+          instance_double(Tastytrade::Instruments::Cryptocurrency, active?: true)
+        )
+      end
+
+      it "accepts a fractional quantity" do
+        expect(validator.validate!(skip_dry_run: true)).to be true
+      end
+
+      it "rejects a zero quantity" do
+        allow(crypto_leg).to receive(:quantity).and_return(BigDecimal("0"))
+
+        expect { validator.validate!(skip_dry_run: true) }
+          .to raise_error(Tastytrade::OrderValidationError, /must be greater than 0/)
+      end
+
+      it "rejects inactive cryptocurrencies" do
+        allow(Tastytrade::Instruments::Cryptocurrency).to receive(:get).and_return(
+          instance_double(Tastytrade::Instruments::Cryptocurrency, active?: false)
+        )
+
+        expect { validator.validate!(skip_dry_run: true) }
+          .to raise_error(Tastytrade::OrderValidationError, /is not active/)
+      end
+    end
+
+    context "with future option order" do
+      let(:future_option_leg) do
+        instance_double(
+          Tastytrade::OrderLeg,
+          symbol: "./ESZ4 EW4X4 241129C5800",
+          quantity: 1,
+          action: Tastytrade::OrderAction::BUY_TO_OPEN,
+          instrument_type: "Future Option"
+        )
+      end
+
+      before do
+        allow(order).to receive(:legs).and_return([future_option_leg])
+        allow(trading_status).to receive_messages(can_trade_futures?: true, can_trade_options?: true)
+        allow(Tastytrade::Instruments::FutureOption).to receive(:get).with(session, "./ESZ4 EW4X4 241129C5800")
+          .and_return(instance_double(Tastytrade::Instruments::FutureOption, closing_only?: false))
+      end
+
+      it "accepts a tradeable future option" do
+        expect(validator.validate!(skip_dry_run: true)).to be true
+      end
+
+      it "requires futures permissions" do
+        allow(trading_status).to receive(:can_trade_futures?).and_return(false)
+
+        expect { validator.validate!(skip_dry_run: true) }
+          .to raise_error(Tastytrade::OrderValidationError, /futures options trading permissions/)
+      end
+    end
   end
 
   describe "#dry_run_validate!" do
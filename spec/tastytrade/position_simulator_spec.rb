@@ -0,0 +1,153 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::PositionSimulator do
+  def position(symbol, quantity, direction, instrument_type: "Equity")
+    Tastytrade::Models::CurrentPosition.new(
+      "account-number" => "5WV12345",
+      "symbol" => symbol,
+      "underlying-symbol" => symbol.split.first,
+      "instrument-type" => instrument_type,
+      "quantity" => quantity.to_s,
+      "quantity-direction" => direction,
+      "average-open-price" => "150.0"
+    )
+  end
+
+  def order(*legs)
+    legs = legs.map do |action, symbol, quantity, instrument_type|
+      Tastytrade::OrderLeg.new(action: action, symbol: symbol, quantity: quantity,
+                               instrument_type: instrument_type || "Equity")
+    end
+    Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: legs)
+  end
+
+  let(:aapl) { position("AAPL", 100, "Long") }
+
+  describe ".simulate_fill" do
+    it "opens a new position" do
+      result = described_class.simulate_fill([], order([Tastytrade::OrderAction::BUY_TO_OPEN, "MSFT", 10]))
+
+      expect(result.size).to eq(1)
+      expect(result.first.symbol).to eq("MSFT")
+      expect(result.first.quantity).to eq(BigDecimal("10"))
+      expect(result.first).to be_long
+    end
+
+    it "adds to an existing position and keeps its other attributes" do
+      result = described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::BUY_TO_OPEN, "AAPL", 50]))
+
+      expect(result.first.quantity).to eq(BigDecimal("150"))
+      expect(result.first.account_number).to eq("5WV12345")
+      expect(result.first.average_open_price).to eq(BigDecimal("150"))
+    end
+
+    it "partially closes a position" do
+      result = described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::SELL_TO_CLOSE, "AAPL", 40]))
+
+      expect(result.first.quantity).to eq(BigDecimal("60"))
+      expect(result.first).to be_long
+    end
+
+    it "drops fully closed positions" do
+      result = described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::SELL_TO_CLOSE, "AAPL", 100]))
+
+      expect(result).to be_empty
+    end
+
+    it "flips direction when an opening leg exceeds the position" do
+      result = described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::SELL_TO_OPEN, "AAPL", 150]))
+
+      expect(result.first.quantity).to eq(BigDecimal("50"))
+      expect(result.first).to be_short
+    end
+
+    it "applies each leg of a multi-leg order" do
+      short_put = position("SPY 240119P00440000", 1, "Short", instrument_type: "Equity Option")
+      spread = order(
+        [Tastytrade::OrderAction::BUY_TO_CLOSE, "SPY 240119P00440000", 1, "Option"],
+        [Tastytrade::OrderAction::SELL_TO_OPEN, "SPY 240119P00430000", 1, "Option"]
+      )
+
+      result = described_class.simulate_fill([aapl, short_put], spread)
+
+      expect(result.map(&:symbol)).to eq(["AAPL", "SPY 240119P00430000"])
+      new_put = result.last
+      expect(new_put).to be_short
+      expect(new_put.instrument_type).to eq("Equity Option")
+      expect(new_put.underlying_symbol).to eq("SPY")
+      expect(new_put.multiplier).to eq(100)
+    end
+
+    it "matches legs to positions whose OCC symbols are padded" do
+      short_put = position("SPY   240119P00440000", 1, "Short", instrument_type: "Equity Option")
+      long_call = position("SPY   240119C00460000", 1, "Long", instrument_type: "Equity Option")
+      legs = order(
+        [Tastytrade::OrderAction::BUY_TO_CLOSE, "SPY 240119P00440000", 1, "Option"],
+        [Tastytrade::OrderAction::BUY_TO_OPEN, "SPY 240119C00460000", 1, "Option"]
+      )
+
+      result = described_class.simulate_fill([short_put, long_call], legs)
+
+      expect(result.map(&:symbol)).to eq(["SPY   240119C00460000"])
+      expect(result.first.quantity).to eq(BigDecimal("2"))
+    end
+
+    it "closes an option position with its closing leg" do
+      short_put = position("SPY   240119P00440000", 1, "Short", instrument_type: "Equity Option")
+      closing = Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: [short_put.closing_leg])
+
+      expect(described_class.simulate_fill([short_put], closing)).to be_empty
+    end
+
+    it "does not modify the given positions" do
+      described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::SELL_TO_CLOSE, "AAPL", 40]))
+
+      expect(aapl.quantity).to eq(BigDecimal("100"))
+    end
+
+    it "rejects closing more than is held" do
+      expect do
+        described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::SELL_TO_CLOSE, "AAPL", 150]))
+      end.to raise_error(Tastytrade::InvalidOrderError, /Cannot sell to close 150 AAPL: 100\.0 long held/)
+    end
+
+    it "rejects closing a position that does not exist" do
+      expect do
+        described_class.simulate_fill([], order([Tastytrade::OrderAction::BUY_TO_CLOSE, "AAPL", 1]))
+      end.to raise_error(Tastytrade::InvalidOrderError, /no position held/)
+    end
+
+    it "rejects closing in the same direction as the position" do
+      expect do
+        described_class.simulate_fill([aapl], order([Tastytrade::OrderAction::BUY_TO_CLOSE, "AAPL", 10]))
+      end.to raise_error(Tastytrade::InvalidOrderError)
+    end
+
+    it "rejects notional orders" do
+      leg = Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: nil)
+      notional = Tastytrade::Order.new(type: Tastytrade::OrderType::MARKET, legs: leg, value: 250)
+
+      expect { described_class.simulate_fill([aapl], notional) }
+        .to raise_error(Tastytrade::InvalidOrderError, "Cannot simulate a notional order for AAPL")
+    end
+
+    it "opens a futures option position with the given multiplier" do
+      symbol = "./ESZ3 EW3X3 231117C4500"
+      buy = order([Tastytrade::OrderAction::BUY_TO_OPEN, symbol, 2, "Future Option"])
+
+      result = described_class.simulate_fill([], buy, multipliers: { symbol => 50 })
+
+      expect(result.first.instrument_type).to eq("Future Option")
+      expect(result.first.multiplier).to eq(50)
+    end
+
+    it "requires the multiplier of a new futures position" do
+      buy = order([Tastytrade::OrderAction::BUY_TO_OPEN, "./ESZ3 EW3X3 231117C4500", 2, "Future Option"])
+
+      expect { described_class.simulate_fill([], buy) }
+        .to raise_error(Tastytrade::InvalidOrderError, /Multiplier for \.\/ESZ3 EW3X3 231117C4500 is unknown/)
+    end
+  end
+end
@@ -0,0 +1,63 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::RateLimiter do
+  let(:now) { [0.0] }
+  let(:clock) { -> { now[0] } }
+  let(:sleeper) { ->(seconds) { now[0] += seconds } }
+  let(:limiter) { described_class.new(2, clock: clock, sleeper: sleeper) }
+
+  it "rejects a non-positive rate" do
+    expect { described_class.new(0) }.to raise_error(ArgumentError, /positive integer/)
+  end
+
+  it "allows a burst up to the per-minute cap without waiting" do
+    expect(2.times.map { limiter.acquire }).to eq([0.0, 0.0])
+    expect(now[0]).to eq(0.0)
+  end
+
+  it "spaces requests beyond the cap evenly over the minute" do
+    4.times { limiter.acquire }
+
+    expect(now[0]).to be >= 60.0
+  end
+
+  it "refills tokens as time passes" do
+    2.times { limiter.acquire }
+    now[0] += 30
+
+    expect(limiter.available_tokens).to eq(1.0)
+    expect(limiter.acquire).to eq(0.0)
+  end
+
+  it "never holds more than one minute of tokens" do
+    now[0] += 600
+
+    expect(limiter.state).to eq(requests_per_minute: 2, available_tokens: 2.0)
+  end
+
+  describe "with a client" do
+    let(:base_url) { "https://api.example.com" }
+
+    it "takes a token for every request" do
+      stub_request(:get, "#{base_url}/accounts").to_return(status: 200, body: "{}")
+      client = Tastytrade::Client.new(base_url: base_url, rate_limit: limiter)
+
+      3.times { client.get("/accounts") }
+
+      expect(now[0]).to be >= 30.0
+      expect(client.rate_limiter).to be(limiter)
+    end
+
+    it "builds a limiter from a requests-per-minute count" do
+      client = Tastytrade::Client.new(base_url: base_url, rate_limit: 120)
+
+      expect(client.rate_limiter.requests_per_minute).to eq(120)
+    end
+
+    it "does not limit by default" do
+      expect(Tastytrade::Client.new(base_url: base_url).rate_limiter).to be_nil
+    end
+  end
+end
@@ -0,0 +1,91 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::ScheduledOrder do
+  let(:order) { instance_double(Tastytrade::Order) }
+  let(:response) { instance_double(Tastytrade::Models::OrderResponse) }
+
+  describe ".resolve_time" do
+    it "returns a Time unchanged" do
+      time = Time.utc(2024, 1, 10, 15)
+
+      expect(described_class.resolve_time(time)).to eq(time)
+    end
+
+    it "resolves :open to the next regular session open" do
+      now = Time.utc(2024, 1, 12, 22, 0)
+
+      expect(described_class.resolve_time(:open, now: now)).to eq(Time.utc(2024, 1, 16, 14, 30))
+    end
+
+    it "rejects other values" do
+      expect { described_class.resolve_time("tomorrow") }.to raise_error(ArgumentError, /must be a Time or :open/)
+    end
+  end
+
+  describe "submission" do
+    it "submits immediately when the time has passed" do
+      scheduled = described_class.new(order: order, scheduled_at: Time.now - 1) { response }.start
+
+      scheduled.wait(5)
+
+      expect(scheduled).to be_submitted
+      expect(scheduled.response).to eq(response)
+    end
+
+    it "passes the order to the submit block" do
+      submitted = nil
+      scheduled = described_class.new(order: order, scheduled_at: Time.now) { |o| submitted = o }.start
+
+      scheduled.wait(5)
+
+      expect(submitted).to eq(order)
+    end
+
+    it "records errors raised while submitting" do
+      scheduled = described_class.new(order: order, scheduled_at: Time.now) { raise Tastytrade::Error, "rejected" }
+      scheduled.start.wait(5)
+
+      expect(scheduled).to be_failed
+      expect(scheduled.error.message).to eq("rejected")
+    end
+  end
+
+  describe "#cancel" do
+    it "prevents submission when cancelled before the scheduled time" do
+      submitted = false
+      scheduled = described_class.new(order: order, scheduled_at: Time.now + 3600) { submitted = true }.start
+
+      expect(scheduled).to be_pending
+      expect(scheduled.cancel).to be true
+
+      scheduled.wait(5)
+
+      expect(scheduled).to be_cancelled
+      expect(submitted).to be false
+    end
+
+    it "returns false once the order has been submitted" do
+      scheduled = described_class.new(order: order, scheduled_at: Time.now) { response }.start
+      scheduled.wait(5)
+
+      expect(scheduled.cancel).to be false
+    end
+  end
+
+  describe "Account#schedule_order" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:account) { Tastytrade::Models::Account.new("account-number" => "5WV12345") }
+
+    it "places the order through the account at the scheduled time" do
+      allow(account).to receive(:place_order).with(session, order, skip_validation: true).and_return(response)
+
+      scheduled = account.schedule_order(session, order, at: Time.now, skip_validation: true)
+      scheduled.wait(5)
+
+      expect(scheduled).to be_submitted
+      expect(scheduled.response).to eq(response)
+    end
+  end
+end
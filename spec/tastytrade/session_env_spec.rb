@@ -140,6 +140,32 @@ RSpec.describe Tastytrade::Session do
       end
     end
 
+    context "with a custom prefix" do
+      before do
+        ENV["TT_TEST_USERNAME"] = "integration@example.com"
+        ENV["TT_TEST_PASSWORD"] = "integration_password"
+        ENV["TT_TEST_ENVIRONMENT"] = "sandbox"
+        ENV["TASTYTRADE_USERNAME"] = "tastytrade@example.com"
+        ENV["TASTYTRADE_PASSWORD"] = "tastytrade_password"
+      end
+
+      it "reads only the prefixed variables" do
+        session = described_class.from_environment(prefix: "TT_TEST")
+        expect(session.instance_variable_get(:@username)).to eq("integration@example.com")
+        expect(session.instance_variable_get(:@password)).to eq("integration_password")
+        expect(session.is_test).to be true
+      end
+
+      it "accepts a prefix with a trailing underscore" do
+        session = described_class.from_environment(prefix: "TT_TEST_")
+        expect(session.instance_variable_get(:@username)).to eq("integration@example.com")
+      end
+
+      it "returns nil when the prefixed variables are missing" do
+        expect(described_class.from_environment(prefix: "MISSING")).to be_nil
+      end
+    end
+
     context "with missing username" do
       before do
         ENV["TASTYTRADE_PASSWORD"] = "test_password"
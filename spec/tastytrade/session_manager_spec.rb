@@ -268,6 +268,15 @@ RSpec.describe Tastytrade::SessionManager do
     end
   end
 
+  describe "#remember_token" do
+    it "returns the saved remember token" do
+      allow(Tastytrade::FileStore).to receive(:get)
+        .with("remember_test@example.com_production").and_return("saved_remember")
+
+      expect(manager.remember_token).to eq("saved_remember")
+    end
+  end
+
   describe "#saved_credentials?" do
     context "with saved password" do
       before do
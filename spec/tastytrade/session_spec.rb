@@ -20,6 +20,14 @@ RSpec.describe Tastytrade::Session do
       expect(session.session_token).to be_nil
     end
 
+    it "normalizes lookup symbols unless turned off" do
+      expect(described_class.new(username: username, password: password)).to be_normalize_symbols
+      session = described_class.new(username: username, password: password, normalize_symbols: false)
+
+      expect(session).not_to be_normalize_symbols
+      expect(Tastytrade::Client).to have_received(:new).with(base_url: Tastytrade::API_URL, timeout: 30).twice
+    end
+
     it "creates session with test environment" do
       session = described_class.new(username: username, password: password, is_test: true)
 
@@ -27,6 +35,13 @@ RSpec.describe Tastytrade::Session do
       expect(Tastytrade::Client).to have_received(:new).with(base_url: Tastytrade::CERT_URL, timeout: 30)
     end
 
+    it "uses a custom base URL over the environment default" do
+      described_class.new(username: username, password: password, is_test: true,
+                          base_url: "http://localhost:8080")
+
+      expect(Tastytrade::Client).to have_received(:new).with(base_url: "http://localhost:8080", timeout: 30)
+    end
+
     it "creates session with remember_me" do
       session = described_class.new(username: username, password: password, remember_me: true)
 
@@ -140,6 +155,26 @@ RSpec.describe Tastytrade::Session do
         expect(session.session_expiration).to be_a(Time)
         expect(session.session_expiration.iso8601).to eq("2024-01-01T12:00:00Z")
       end
+
+      context "when the expiration is malformed" do
+        before do
+          login_response["data"]["session-expiration"] = "not a time"
+          allow(client).to receive(:post).and_return(login_response)
+        end
+
+        it "logs in without an expiration by default" do
+          session.login
+
+          expect(session.session_token).to eq("test-session-token")
+          expect(session.session_expiration).to be_nil
+        end
+
+        it "raises in strict time parsing mode" do
+          session = described_class.new(username: username, password: password, strict_time_parsing: true)
+
+          expect { session.login }.to raise_error(Tastytrade::TimeParseError, 'Invalid time: "not a time"')
+        end
+      end
     end
   end
 
@@ -171,6 +206,19 @@ RSpec.describe Tastytrade::Session do
 
       expect(session.validate).to be false
     end
+
+    it "logs validation through the configured logger" do
+      log = StringIO.new
+      session = described_class.new(username: username, password: password, logger: Logger.new(log))
+      session.instance_variable_set(:@user, user)
+      session.instance_variable_set(:@session_token, "token")
+      allow(client).to receive(:get).and_raise(Tastytrade::Error, "Unauthorized")
+
+      session.validate
+
+      expect(Tastytrade::Client).to have_received(:new).with(hash_including(logger: kind_of(Logger)))
+      expect(log.string).to include("Validate error: Unauthorized")
+    end
   end
 
   describe "#destroy" do
@@ -199,6 +247,51 @@ RSpec.describe Tastytrade::Session do
 
       session.destroy
     end
+
+    it "treats an expired session as logged out" do
+      allow(client).to receive(:delete)
+        .and_raise(Tastytrade::InvalidCredentialsError.new("Authentication failed", status: 401))
+
+      expect { session.destroy }.not_to raise_error
+      expect(session.session_token).to be_nil
+      expect(session.remember_token).to be_nil
+    end
+
+    it "treats a missing session as logged out" do
+      allow(client).to receive(:delete).and_raise(Tastytrade::Error.new("Resource not found", status: 404))
+
+      expect { session.destroy }.not_to raise_error
+      expect(session.session_token).to be_nil
+    end
+
+    it "logs out with the current token without refreshing it" do
+      session.instance_variable_set(:@session_expiration, Time.now + 30)
+      expect(client).not_to receive(:post)
+      expect(client).to receive(:delete).with("/sessions", { "Authorization" => "token" })
+
+      session.destroy
+
+      expect(session.session_token).to be_nil
+    end
+
+    it "treats an expired session with a rejected remember token as logged out" do
+      session.instance_variable_set(:@session_expiration, Time.now - 60)
+      allow(client).to receive(:post)
+        .and_raise(Tastytrade::InvalidCredentialsError.new("Authentication failed", status: 401))
+      expect(client).not_to receive(:delete)
+
+      expect { session.destroy }.not_to raise_error
+      expect(session.session_token).to be_nil
+      expect(session.remember_token).to be_nil
+    end
+
+    it "raises server errors and keeps the session for a retry" do
+      allow(client).to receive(:delete).and_raise(Tastytrade::Error.new("Server error", status: 503))
+
+      expect { session.destroy }.to raise_error(Tastytrade::Error, "Server error")
+      expect(session.session_token).to eq("token")
+      expect(session.remember_token).to eq("remember")
+    end
   end
 
   describe "HTTP methods" do
@@ -389,4 +482,344 @@ RSpec.describe Tastytrade::Session do
       end
     end
   end
+
+  describe "#account_stream" do
+    it "builds an unconnected stream for the session's environment" do
+      session = described_class.new(username: username, password: password, is_test: true)
+
+      stream = session.account_stream(heartbeat_interval: nil)
+
+      expect(stream).to be_a(Tastytrade::AccountStream)
+      expect(stream.url).to eq(Tastytrade::CERT_STREAMER_URL)
+      expect(stream).not_to be_connected
+    end
+  end
+
+  describe "#current_customer" do
+    let(:session) { described_class.new(username: username, password: password) }
+    let(:customer_response) { { "data" => { "id" => "me|12345", "first-name" => "Jane" } } }
+
+    before do
+      session.instance_variable_set(:@session_token, "token")
+    end
+
+    it "fetches /customers/me once and caches the customer" do
+      expect(client).to receive(:get)
+        .with("/customers/me", {}, { "Authorization" => "token" })
+        .once
+        .and_return(customer_response)
+
+      expect(session.current_customer.first_name).to eq("Jane")
+      expect(session.customer_id).to eq("me|12345")
+    end
+
+    it "fetches again when asked to refresh" do
+      expect(client).to receive(:get).twice.and_return(customer_response)
+
+      session.current_customer
+      session.current_customer(refresh: true)
+    end
+
+    it "lists the customer's accounts" do
+      expect(client).to receive(:get)
+        .with("/customers/me/accounts/", {}, { "Authorization" => "token" })
+        .and_return("data" => { "items" => [{ "account" => { "account-number" => "5WT0001" } }] })
+
+      expect(session.accounts.map(&:account_number)).to eq(["5WT0001"])
+    end
+  end
+
+  describe "#quote_token" do
+    let(:session) { described_class.new(username: username, password: password) }
+
+    def token_response(token, expires_at)
+      { "data" => { "token" => token, "dxlink-url" => "wss://tasty-openapi-ws.dxfeed.com/realtime",
+                    "level" => "api", "expires-at" => expires_at.utc.iso8601 } }
+    end
+
+    before do
+      session.instance_variable_set(:@session_token, "token")
+    end
+
+    it "reuses the cached token until it nears expiration" do
+      expect(client).to receive(:get)
+        .with("/api-quote-tokens", {}, { "Authorization" => "token" })
+        .once
+        .and_return(token_response("first", Time.now + 86_400))
+
+      expect(session.quote_token.token).to eq("first")
+      expect(session.quote_token.token).to eq("first")
+    end
+
+    it "fetches a new token within a minute of expiration" do
+      allow(client).to receive(:get)
+        .and_return(token_response("expiring", Time.now + 30), token_response("fresh", Time.now + 86_400))
+
+      expect(session.quote_token.token).to eq("expiring")
+      expect(session.quote_token.token).to eq("fresh")
+      expect(session.quote_token.token).to eq("fresh")
+      expect(client).to have_received(:get).twice
+    end
+
+    it "fetches a new token when asked to refresh" do
+      allow(client).to receive(:get)
+        .and_return(token_response("first", Time.now + 86_400), token_response("second", Time.now + 86_400))
+
+      session.quote_token
+
+      expect(session.quote_token(refresh: true).token).to eq("second")
+    end
+
+    it "drops the cached token on logout" do
+      allow(client).to receive(:get).and_return(token_response("first", Time.now + 86_400))
+      allow(client).to receive(:delete).and_return({})
+
+      session.quote_token
+      session.destroy
+      session.instance_variable_set(:@session_token, "token")
+      session.quote_token
+
+      expect(client).to have_received(:get).twice
+    end
+  end
+
+  describe "option chain cache" do
+    let(:session) { described_class.new(username: username, password: password, chain_cache_ttl: 30) }
+    let(:chain_response) { { "data" => { "items" => [{ "underlying-symbol" => "SPY", "expirations" => [] }] } } }
+
+    before do
+      session.instance_variable_set(:@session_token, "token")
+      allow(client).to receive(:get).and_return(chain_response)
+    end
+
+    it "serves a repeated chain request within the TTL from the cache" do
+      first = Tastytrade::Models::NestedOptionChain.get(session, "SPY")
+      second = Tastytrade::Models::NestedOptionChain.get(session, "SPY")
+
+      expect(client).to have_received(:get).once
+      expect(second.underlying_symbol).to eq(first.underlying_symbol)
+    end
+
+    it "caches each symbol and chain format separately" do
+      Tastytrade::Models::NestedOptionChain.get(session, "SPY")
+      Tastytrade::Models::NestedOptionChain.get(session, "QQQ")
+      Tastytrade::Models::OptionChain.get_chain(session, "SPY")
+
+      expect(client).to have_received(:get).exactly(3).times
+    end
+
+    it "fetches the chain again once the TTL has passed" do
+      allow(Process).to receive(:clock_gettime).with(Process::CLOCK_MONOTONIC).and_return(100.0, 120.0, 131.0)
+
+      3.times { session.get("/option-chains/SPY/nested") }
+
+      expect(client).to have_received(:get).twice
+    end
+
+    it "evicts expired entries when storing a response" do
+      allow(Process).to receive(:clock_gettime).with(Process::CLOCK_MONOTONIC).and_return(100.0, 131.0)
+
+      session.get("/option-chains/SPY/nested")
+      session.get("/option-chains/QQQ/nested")
+
+      expect(session.instance_variable_get(:@chain_cache).keys.map(&:first)).to eq(["/option-chains/QQQ/nested"])
+    end
+
+    it "gives each caller its own copy of a cached response" do
+      session.get("/option-chains/SPY/nested")["data"]["items"].clear
+      cached = session.get("/option-chains/SPY/nested")
+
+      expect(client).to have_received(:get).once
+      expect(cached["data"]["items"].size).to eq(1)
+    end
+
+    it "fetches the chain again after the cache is cleared" do
+      session.get("/option-chains/SPY/nested")
+      session.clear_chain_cache
+      session.get("/option-chains/SPY/nested")
+
+      expect(client).to have_received(:get).twice
+    end
+
+    it "does not cache other endpoints" do
+      2.times { session.get("/instruments/equities/SPY") }
+
+      expect(client).to have_received(:get).twice
+    end
+
+    it "is off by default" do
+      uncached = described_class.new(username: username, password: password)
+      uncached.instance_variable_set(:@session_token, "token")
+
+      2.times { uncached.get("/option-chains/SPY/nested") }
+
+      expect(client).to have_received(:get).twice
+    end
+  end
+
+  describe "session state persistence" do
+    let(:session) { described_class.new(username: username, password: password, remember_me: true, is_test: true) }
+    let(:expiration) { Time.now.utc.round + 3600 }
+
+    before do
+      session.instance_variable_set(:@session_token, "saved-token")
+      session.instance_variable_set(:@remember_token, "saved-remember-token")
+      session.instance_variable_set(:@session_expiration, expiration)
+      session.instance_variable_set(:@user, Tastytrade::Models::User.new("email" => "test@example.com",
+                                                                         "username" => "testuser"))
+    end
+
+    it "exports JSON-serializable state without the password" do
+      state = session.export_state
+
+      expect(state).to include("username" => username, "session_token" => "saved-token",
+                               "remember_token" => "saved-remember-token", "is_test" => true,
+                               "base_url" => Tastytrade::CERT_URL, "session_expiration" => expiration.iso8601)
+      expect(JSON.generate(state)).not_to include(password)
+    end
+
+    it "round-trips through JSON into a new session" do
+      restored = described_class.from_state(JSON.parse(JSON.generate(session.export_state)))
+
+      expect(restored.session_token).to eq("saved-token")
+      expect(restored.remember_token).to eq("saved-remember-token")
+      expect(restored.session_expiration).to eq(expiration)
+      expect(restored.user.email).to eq("test@example.com")
+      expect(restored.is_test).to be true
+      expect(restored.export_state).to eq(session.export_state)
+    end
+
+    it "refreshes a restored session whose token has expired" do
+      session.instance_variable_set(:@session_expiration, Time.now - 60)
+      restored = described_class.from_state(session.export_state)
+      expect(client).to receive(:post)
+        .with("/sessions", hash_including("remember-token" => "saved-remember-token"))
+        .and_return("data" => { "user" => { "email" => "test@example.com" }, "session-token" => "fresh-token" })
+      expect(client).to receive(:get)
+        .with("/customers/me", {}, { "Authorization" => "fresh-token" })
+        .and_return({ "data" => {} })
+
+      restored.get("/customers/me")
+    end
+  end
+
+  describe "automatic refresh near expiry" do
+    let(:session) { described_class.new(username: username, password: password, remember_me: true) }
+    let(:refresh_response) do
+      {
+        "data" => {
+          "user" => { "email" => "test@example.com", "username" => "testuser" },
+          "session-token" => "refreshed-token",
+          "remember-token" => "next-remember-token",
+          "session-expiration" => (Time.now + 86_400).utc.iso8601
+        }
+      }
+    end
+
+    before do
+      session.instance_variable_set(:@session_token, "old-token")
+      session.instance_variable_set(:@remember_token, "valid-remember-token")
+    end
+
+    it "silently refreshes an expired token before the request" do
+      session.instance_variable_set(:@session_expiration, Time.now - 60)
+      expect(client).to receive(:post)
+        .with("/sessions", hash_including("remember-token" => "valid-remember-token"))
+        .and_return(refresh_response)
+      expect(client).to receive(:get)
+        .with("/customers/me", {}, { "Authorization" => "refreshed-token" })
+        .and_return({ "data" => {} })
+
+      session.get("/customers/me")
+
+      expect(session.remember_token).to eq("next-remember-token")
+      expect(session).not_to be_expiring_soon
+    end
+
+    it "refreshes a token within five minutes of expiring" do
+      session.instance_variable_set(:@session_expiration, Time.now + 120)
+      expect(client).to receive(:post).and_return(refresh_response)
+
+      session.ensure_valid_token
+
+      expect(session.session_token).to eq("refreshed-token")
+    end
+
+    it "leaves a token with time remaining alone" do
+      session.instance_variable_set(:@session_expiration, Time.now + 3600)
+      expect(client).not_to receive(:post)
+
+      session.ensure_valid_token
+    end
+
+    it "does not refresh without a remember token" do
+      session.instance_variable_set(:@remember_token, nil)
+      session.instance_variable_set(:@session_expiration, Time.now + 60)
+      expect(client).not_to receive(:post)
+
+      session.ensure_valid_token
+    end
+
+    it "keeps using a still-valid token when the refresh is rejected" do
+      session.instance_variable_set(:@session_expiration, Time.now + 60)
+      allow(client).to receive(:post).and_raise(Tastytrade::InvalidCredentialsError, "Authentication failed")
+
+      expect(session.ensure_valid_token).to eq(session)
+      expect(session.session_token).to eq("old-token")
+    end
+
+    it "raises when an expired token cannot be refreshed" do
+      session.instance_variable_set(:@session_expiration, Time.now - 60)
+      allow(client).to receive(:post).and_raise(Tastytrade::InvalidCredentialsError, "Authentication failed")
+
+      expect { session.ensure_valid_token }.to raise_error(Tastytrade::TokenRefreshError)
+    end
+  end
+
+  describe "automatic remember token login" do
+    let(:session) { described_class.new(username: username, remember_token: "saved-remember-token") }
+    let(:login_response) do
+      {
+        "data" => {
+          "user" => { "email" => "test@example.com", "username" => "testuser" },
+          "session-token" => "auto-session-token"
+        }
+      }
+    end
+
+    it "logs in with the remember token on the first authenticated request" do
+      expect(client).to receive(:post).with("/sessions", {
+                                              "login" => username,
+                                              "remember-token" => "saved-remember-token",
+                                              "remember-me" => false
+                                            }).and_return(login_response)
+      expect(client).to receive(:get)
+        .with("/customers/me", {}, { "Authorization" => "auto-session-token" })
+        .and_return({ "data" => {} })
+
+      session.get("/customers/me")
+
+      expect(session).to be_authenticated
+    end
+
+    it "raises a clear error when the remember token is rejected" do
+      allow(client).to receive(:post).and_raise(Tastytrade::InvalidCredentialsError, "Authentication failed: invalid")
+
+      expect { session.get("/customers/me") }
+        .to raise_error(Tastytrade::TokenRefreshError, /Remember token is expired or invalid, log in again/)
+    end
+
+    it "does not log in automatically when a password is set" do
+      password_session = described_class.new(username: username, password: password)
+
+      expect { password_session.get("/customers/me") }.to raise_error(Tastytrade::Error, "Not authenticated")
+    end
+
+    it "keeps password login errors unchanged" do
+      password_session = described_class.new(username: username, password: password)
+      allow(client).to receive(:post).and_raise(Tastytrade::InvalidCredentialsError, "Authentication failed")
+
+      expect { password_session.login }.to raise_error(Tastytrade::InvalidCredentialsError)
+    end
+  end
 end
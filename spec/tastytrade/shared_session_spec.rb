@@ -0,0 +1,127 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::SharedSession do
+  let(:shared) { described_class.new }
+  let(:client) { instance_double(Tastytrade::Client) }
+
+  def login_response(token, remember_token: nil)
+    {
+      "data" => {
+        "user" => { "email" => "test@example.com", "username" => "testuser" },
+        "session-token" => token,
+        "remember-token" => remember_token
+      }.compact
+    }
+  end
+
+  before do
+    allow(Tastytrade::Client).to receive(:new).and_return(client)
+  end
+
+  describe "#update and #clear" do
+    it "stores and clears authentication state" do
+      shared.update(user: nil, session_token: "token", remember_token: "remember")
+
+      expect(shared.session_token).to eq("token")
+      expect(shared.remember_token).to eq("remember")
+
+      shared.clear
+
+      expect(shared.session_token).to be_nil
+      expect(shared.remember_token).to be_nil
+    end
+
+    it "keeps the existing remember token when none is given" do
+      shared.update(user: nil, session_token: "token", remember_token: "remember")
+      shared.update(user: nil, session_token: "token-2")
+
+      expect(shared.remember_token).to eq("remember")
+    end
+  end
+
+  describe "#refresh" do
+    it "runs the block when the token is still the stale one" do
+      shared.update(user: nil, session_token: "old")
+
+      expect(shared.refresh("old") { shared.update(user: nil, session_token: "new") }).to be true
+      expect(shared.session_token).to eq("new")
+    end
+
+    it "skips the block when another session already refreshed" do
+      shared.update(user: nil, session_token: "new")
+      ran = false
+
+      expect(shared.refresh("old") { ran = true }).to be false
+      expect(ran).to be false
+    end
+
+    it "serializes concurrent refreshes" do
+      shared.update(user: nil, session_token: "old")
+      refreshes = 0
+
+      threads = Array.new(5) do
+        Thread.new do
+          shared.refresh("old") do
+            refreshes += 1
+            shared.update(user: nil, session_token: "new")
+          end
+        end
+      end
+      threads.each(&:join)
+
+      expect(refreshes).to eq(1)
+    end
+  end
+
+  describe "session integration" do
+    let(:rest) do
+      Tastytrade::Session.new(username: "testuser", password: "testpass", remember_me: true, shared_session: shared)
+    end
+    let(:streaming) { Tastytrade::Session.new(username: "testuser", shared_session: shared) }
+
+    before do
+      allow(client).to receive(:post).with("/sessions", hash_including("password" => "testpass"))
+                                     .and_return(login_response("token-1", remember_token: "remember-1"))
+    end
+
+    it "makes a login visible to every attached session" do
+      rest.login
+
+      expect(streaming).to be_authenticated
+      expect(streaming.session_token).to eq("token-1")
+      expect(streaming.user.email).to eq("test@example.com")
+      expect(streaming.remember_token).to eq("remember-1")
+    end
+
+    it "uses the shared token for requests" do
+      rest.login
+      allow(client).to receive(:get).and_return({})
+
+      streaming.get("/customers/me")
+
+      expect(client).to have_received(:get).with("/customers/me", {}, { "Authorization" => "token-1" })
+    end
+
+    it "shares a refresh performed by one session" do
+      rest.login
+      allow(client).to receive(:post).with("/sessions", hash_including("remember-token" => "remember-1"))
+                                     .and_return(login_response("token-2"))
+
+      streaming.refresh_session
+
+      expect(rest.session_token).to eq("token-2")
+      expect(streaming.session_token).to eq("token-2")
+    end
+
+    it "clears the shared state on destroy" do
+      rest.login
+      allow(client).to receive(:delete).and_return(nil)
+
+      streaming.destroy
+
+      expect(rest).not_to be_authenticated
+    end
+  end
+end
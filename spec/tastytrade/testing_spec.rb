@@ -0,0 +1,77 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+require "tastytrade/testing"
+
+RSpec.describe Tastytrade::Testing do
+  let(:stubs) { Faraday::Adapter::Test::Stubs.new }
+  let(:session) { described_class.session(stubs) }
+  let(:account) { Tastytrade::Models::Account.new("account-number" => "5WT0001") }
+
+  describe ".session" do
+    it "answers requests from the stubs" do
+      stubs.get("/instruments/equities/AAPL") do
+        described_class.response("symbol" => "AAPL", "description" => "Apple Inc.")
+      end
+
+      equity = Tastytrade::Instruments::Equity.get(session, "AAPL")
+
+      expect(equity.symbol).to eq("AAPL")
+      expect(equity.description).to eq("Apple Inc.")
+      stubs.verify_stubbed_calls
+    end
+
+    it "yields the stubs" do
+      session = described_class.session do |stub|
+        stub.get("/instruments/equities/MSFT") { described_class.response("symbol" => "MSFT") }
+      end
+
+      expect(Tastytrade::Instruments::Equity.get(session, "MSFT").symbol).to eq("MSFT")
+    end
+
+    it "sends the test session token" do
+      stubs.get("/instruments/equities/AAPL") do |env|
+        expect(env.request_headers["Authorization"]).to eq(described_class::SESSION_TOKEN)
+        described_class.response("symbol" => "AAPL")
+      end
+
+      Tastytrade::Instruments::Equity.get(session, "AAPL")
+    end
+
+    it "exposes the submitted order for assertions" do
+      order = Tastytrade::Order.new(
+        type: Tastytrade::OrderType::LIMIT,
+        legs: Tastytrade::OrderLeg.new(action: Tastytrade::OrderAction::BUY_TO_OPEN, symbol: "AAPL", quantity: 10),
+        price: "150.00"
+      )
+      stubs.post("/accounts/5WT0001/orders") do |env|
+        body = JSON.parse(env.body)
+        expect(body["order-type"]).to eq("Limit")
+        expect(body["legs"].first).to include("symbol" => "AAPL", "quantity" => 10)
+        described_class.response("id" => 98765, "status" => "Received")
+      end
+
+      response = account.place_order(session, order, skip_validation: true)
+
+      expect(response.order_id).to eq(98765)
+      expect(response.status).to eq("Received")
+    end
+
+    it "raises API errors from stubbed error responses without retrying" do
+      calls = 0
+      stubs.get("/instruments/equities/BAD") do
+        calls += 1
+        described_class.response(status: 503, body: { "error" => { "message" => "unavailable" } })
+      end
+
+      expect { Tastytrade::Instruments::Equity.get(session, "BAD") }
+        .to raise_error(Tastytrade::Error, /Server error: unavailable/)
+      expect(calls).to eq(1)
+    end
+
+    it "uses the production API unless is_test is given" do
+      expect(session.is_test).to be false
+      expect(described_class.session(is_test: true).is_test).to be true
+    end
+  end
+end
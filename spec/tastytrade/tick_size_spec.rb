@@ -0,0 +1,91 @@
+# frozen_string_literal: true
+
+require "spec_helper"
+
+RSpec.describe Tastytrade::TickSize do
+  let(:option_ticks) { [{ "value" => "0.01", "threshold" => "3.0" }, { "value" => "0.05" }] }
+
+  describe ".increment_for" do
+    it "uses the tier below the threshold" do
+      expect(described_class.increment_for(BigDecimal("2.99"), option_ticks)).to eq(BigDecimal("0.01"))
+    end
+
+    it "uses the open-ended tier at and above the threshold" do
+      expect(described_class.increment_for(BigDecimal("3.00"), option_ticks)).to eq(BigDecimal("0.05"))
+      expect(described_class.increment_for(BigDecimal("12.40"), option_ticks)).to eq(BigDecimal("0.05"))
+    end
+
+    it "accepts symbol keys" do
+      expect(described_class.increment_for(1, [{ value: "0.0001", threshold: "1.0" }, { value: "0.01" }]))
+        .to eq(BigDecimal("0.0001"))
+    end
+
+    it "returns nil without tiers" do
+      expect(described_class.increment_for(1, [])).to be_nil
+    end
+  end
+
+  describe ".round" do
+    it "rounds to the nearest increment" do
+      expect(described_class.round(BigDecimal("1.234"), option_ticks)).to eq(BigDecimal("1.23"))
+      expect(described_class.round(BigDecimal("3.1234"), option_ticks)).to eq(BigDecimal("3.10"))
+      expect(described_class.round(BigDecimal("3.125"), option_ticks)).to eq(BigDecimal("3.15"))
+    end
+
+    it "rounds up or down on request" do
+      expect(described_class.round(BigDecimal("3.11"), option_ticks, direction: :up)).to eq(BigDecimal("3.15"))
+      expect(described_class.round(BigDecimal("3.14"), option_ticks, direction: :down)).to eq(BigDecimal("3.10"))
+    end
+
+    it "accepts floats" do
+      expect(described_class.round(2.005, option_ticks)).to eq(BigDecimal("2.01"))
+    end
+
+    it "returns the price unchanged without tiers" do
+      expect(described_class.round(BigDecimal("1.2345"), [])).to eq(BigDecimal("1.2345"))
+    end
+
+    it "rejects unknown directions" do
+      expect { described_class.round(1, option_ticks, direction: :sideways) }
+        .to raise_error(ArgumentError, /Invalid rounding direction/)
+    end
+  end
+
+  describe ".valid?" do
+    it "checks whether a price is on an increment" do
+      expect(described_class.valid?(BigDecimal("3.10"), option_ticks)).to be true
+      expect(described_class.valid?(BigDecimal("3.12"), option_ticks)).to be false
+    end
+  end
+
+  describe ".round_price" do
+    let(:session) { instance_double(Tastytrade::Session) }
+    let(:equity_response) do
+      {
+        "data" => {
+          "symbol" => "SPY",
+          "tick-sizes" => [{ "value" => "0.0001", "threshold" => "1.0" }, { "value" => "0.01" }],
+          "option-tick-sizes" => option_ticks
+        }
+      }
+    end
+
+    before do
+      allow(session).to receive(:get).with("/instruments/equities/SPY").and_return(equity_response)
+    end
+
+    it "uses equity tick sizes for equities" do
+      expect(described_class.round_price(session, "SPY", BigDecimal("450.123"))).to eq(BigDecimal("450.12"))
+    end
+
+    it "uses the underlying's option tick sizes for options" do
+      expect(described_class.round_price(session, "SPY 240119C00450000", BigDecimal("3.1234")))
+        .to eq(BigDecimal("3.10"))
+    end
+
+    it "recognizes padded OCC option symbols" do
+      expect(described_class.round_price(session, "SPY   240119C00450000", BigDecimal("3.1234")))
+        .to eq(BigDecimal("3.10"))
+    end
+  end
+end
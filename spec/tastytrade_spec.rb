@@ -5,5 +5,66 @@ RSpec.describe Tastytrade do
     expect(Tastytrade::VERSION).not_to be nil
   end
 
-  # TODO: Add actual specs when implementing the SDK
+  describe ".normalize_symbol" do
+    it "trims and upper-cases symbols" do
+      expect(described_class.normalize_symbol(" aapl ")).to eq("AAPL")
+      expect(described_class.normalize_symbol("brk/b")).to eq("BRK/B")
+      expect(described_class.normalize_symbol("/esz4")).to eq("/ESZ4")
+    end
+
+    it "keeps the padding of OCC option symbols" do
+      expect(described_class.normalize_symbol("spy   240419p00500000")).to eq("SPY   240419P00500000")
+      expect(described_class.normalize_symbol("SPY   240419P00500000")).to eq("SPY   240419P00500000")
+    end
+
+    it "passes nil through" do
+      expect(described_class.normalize_symbol(nil)).to be_nil
+    end
+
+    it "leaves symbols unchanged for a session that turns normalization off" do
+      session = instance_double(Tastytrade::Session, normalize_symbols?: false)
+
+      expect(described_class.normalize_symbol(" aapl", session)).to eq(" aapl")
+    end
+
+    it "normalizes for a session that keeps the default" do
+      session = instance_double(Tastytrade::Session, normalize_symbols?: true)
+
+      expect(described_class.normalize_symbol(" aapl", session)).to eq("AAPL")
+    end
+  end
+
+  describe ".parse_time" do
+    it "parses API timestamps" do
+      expect(described_class.parse_time("2024-03-15T14:30:00.000+00:00")).to eq(Time.utc(2024, 3, 15, 14, 30))
+    end
+
+    it "returns nil for blank values" do
+      expect(described_class.parse_time(nil)).to be_nil
+      expect(described_class.parse_time("")).to be_nil
+    end
+
+    it "reads a malformed timestamp as nil by default" do
+      expect(described_class.parse_time("2024-13-45T99:00:00Z")).to be_nil
+    end
+
+    it "raises for a malformed timestamp in strict mode" do
+      expect { described_class.parse_time("2024-13-45T99:00:00Z", strict: true) }
+        .to raise_error(Tastytrade::TimeParseError, /Invalid time: "2024-13-45T99:00:00Z"/)
+    end
+
+    it "applies to models built from a strict client's data" do
+      order_data = Tastytrade::StrictTimeHash["id" => 1, "updated-at" => "yesterday-ish"]
+
+      expect { Tastytrade::Models::LiveOrder.new(order_data) }.to raise_error(Tastytrade::TimeParseError)
+      expect { Tastytrade::Models::Transaction.new(Tastytrade::StrictTimeHash["id" => 1, "executed-at" => "bad"]) }
+        .to raise_error(Tastytrade::TimeParseError)
+    end
+
+    it "leaves models built from plain data lenient" do
+      order = Tastytrade::Models::LiveOrder.new("id" => 1, "updated-at" => "yesterday-ish")
+
+      expect(order.updated_at).to be_nil
+    end
+  end
 end